@@ -56,7 +56,7 @@ func GetBinary(arch string) ([]byte, error) {
 		}
 		return agentLinuxArm64, nil
 	default:
-		return nil, fmt.Errorf("unsupported architecture: %s", arch)
+		return nil, fmt.Errorf("unsupported architecture %q: agent binaries are built for linux/amd64 and linux/arm64", arch)
 	}
 }
 
@@ -5,10 +5,11 @@ import (
 	"os"
 
 	"github.com/griffithind/dcx/internal/cli"
+	dcxerrors "github.com/griffithind/dcx/internal/errors"
 )
 
 func main() {
 	if err := cli.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(dcxerrors.ExitCode(err))
 	}
 }
@@ -0,0 +1,271 @@
+// Package api exposes core dcx operations over a JSON-RPC 2.0 stream
+// (stdin/stdout), so editor plugins and GUIs can embed dcx without
+// scraping CLI text. One request per line in, one response per line out.
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/griffithind/dcx/internal/devcontainer"
+	"github.com/griffithind/dcx/internal/service"
+	sshexec "github.com/griffithind/dcx/internal/ssh/exec"
+	"github.com/griffithind/dcx/internal/state"
+)
+
+// Service is the surface of DevContainerService the API uses. An
+// interface so tests can drive the protocol with a stub.
+type Service interface {
+	GetIdentifiers() (*service.Identifiers, error)
+	GetStateManager() *state.StateManager
+	Plan(ctx context.Context, opts service.PlanOptions) (*service.PlanResult, error)
+	Up(ctx context.Context, opts service.UpOptions) error
+	Load(ctx context.Context) (*devcontainer.ResolvedDevContainer, error)
+}
+
+// JSON-RPC 2.0 error codes used by the server.
+const (
+	codeParseError     = -32700
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeInternalError  = -32603
+)
+
+// request is a JSON-RPC 2.0 request.
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// response is a JSON-RPC 2.0 response.
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is a JSON-RPC 2.0 error object.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Server dispatches JSON-RPC requests to the devcontainer service.
+type Server struct {
+	svc           Service
+	workspacePath string
+	configPath    string
+}
+
+// NewServer creates an API server bound to one workspace.
+func NewServer(svc Service, workspacePath, configPath string) *Server {
+	return &Server{svc: svc, workspacePath: workspacePath, configPath: configPath}
+}
+
+// Serve reads newline-delimited JSON-RPC requests from r and writes
+// responses to w until EOF.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	enc := json.NewEncoder(w)
+
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var req request
+		if err := json.Unmarshal(line, &req); err != nil {
+			_ = enc.Encode(response{JSONRPC: "2.0", Error: &rpcError{Code: codeParseError, Message: err.Error()}})
+			continue
+		}
+
+		resp := response{JSONRPC: "2.0", ID: req.ID}
+		result, rpcErr := s.dispatch(ctx, req.Method, req.Params)
+		if rpcErr != nil {
+			resp.Error = rpcErr
+		} else {
+			resp.Result = result
+		}
+		if err := enc.Encode(resp); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// dispatch routes a method call.
+func (s *Server) dispatch(ctx context.Context, method string, params json.RawMessage) (interface{}, *rpcError) {
+	switch method {
+	case "status":
+		return s.handleStatus(ctx)
+	case "plan":
+		return s.handlePlan(ctx)
+	case "up":
+		return s.handleUp(ctx, params)
+	case "exec":
+		return s.handleExec(ctx, params)
+	case "ports":
+		return s.handlePorts(ctx)
+	default:
+		return nil, &rpcError{Code: codeMethodNotFound, Message: "method not found: " + method}
+	}
+}
+
+// StatusResult is the result of the "status" method.
+type StatusResult struct {
+	State         string `json:"state"`
+	ContainerID   string `json:"containerID,omitempty"`
+	ContainerName string `json:"containerName,omitempty"`
+	Running       bool   `json:"running"`
+	SSHHost       string `json:"sshHost,omitempty"`
+}
+
+func (s *Server) handleStatus(ctx context.Context) (interface{}, *rpcError) {
+	ids, err := s.svc.GetIdentifiers()
+	if err != nil {
+		return nil, &rpcError{Code: codeInternalError, Message: err.Error()}
+	}
+	currentState, info, err := s.svc.GetStateManager().GetStateWithProject(ctx, ids.ProjectName, ids.WorkspaceID)
+	if err != nil {
+		return nil, &rpcError{Code: codeInternalError, Message: err.Error()}
+	}
+	result := StatusResult{State: string(currentState), SSHHost: ids.SSHHost}
+	if info != nil {
+		result.ContainerID = info.ID
+		result.ContainerName = info.Name
+		result.Running = info.Running
+	}
+	return result, nil
+}
+
+// PlanResult is the result of the "plan" method.
+type PlanResult struct {
+	State   string   `json:"state"`
+	Action  string   `json:"action"`
+	Reason  string   `json:"reason,omitempty"`
+	Changes []string `json:"changes,omitempty"`
+}
+
+func (s *Server) handlePlan(ctx context.Context) (interface{}, *rpcError) {
+	plan, err := s.svc.Plan(ctx, service.PlanOptions{})
+	if err != nil {
+		return nil, &rpcError{Code: codeInternalError, Message: err.Error()}
+	}
+	return PlanResult{
+		State:   string(plan.State),
+		Action:  string(plan.Action),
+		Reason:  plan.Reason,
+		Changes: plan.Changes,
+	}, nil
+}
+
+// upParams are the parameters of the "up" method.
+type upParams struct {
+	Rebuild  bool `json:"rebuild,omitempty"`
+	Recreate bool `json:"recreate,omitempty"`
+	Pull     bool `json:"pull,omitempty"`
+}
+
+func (s *Server) handleUp(ctx context.Context, params json.RawMessage) (interface{}, *rpcError) {
+	var p upParams
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+	}
+	if err := s.svc.Up(ctx, service.UpOptions{
+		Rebuild:  p.Rebuild,
+		Recreate: p.Recreate,
+		Pull:     p.Pull,
+	}); err != nil {
+		return nil, &rpcError{Code: codeInternalError, Message: err.Error()}
+	}
+	return s.handleStatus(ctx)
+}
+
+// execParams are the parameters of the "exec" method.
+type execParams struct {
+	Command []string `json:"command"`
+}
+
+// ExecResult is the result of the "exec" method.
+type ExecResult struct {
+	ExitCode int    `json:"exitCode"`
+	Output   string `json:"output"`
+}
+
+func (s *Server) handleExec(ctx context.Context, params json.RawMessage) (interface{}, *rpcError) {
+	var p execParams
+	if err := json.Unmarshal(params, &p); err != nil || len(p.Command) == 0 {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "command array required"}
+	}
+
+	ids, err := s.svc.GetIdentifiers()
+	if err != nil {
+		return nil, &rpcError{Code: codeInternalError, Message: err.Error()}
+	}
+	_, info, err := s.svc.GetStateManager().GetStateWithProject(ctx, ids.ProjectName, ids.WorkspaceID)
+	if err != nil || info == nil {
+		return nil, &rpcError{Code: codeInternalError, Message: "no container found"}
+	}
+
+	cfg, _, _ := devcontainer.Load(s.workspacePath, s.configPath)
+
+	var output bytes.Buffer
+	tty := false
+	exitCode, err := sshexec.ExecInContainer(ctx, sshexec.ContainerExecOptions{
+		ContainerName: info.Name,
+		WorkspaceID:   info.Labels.WorkspaceID,
+		Config:        cfg,
+		WorkspacePath: s.workspacePath,
+		Command:       p.Command,
+		Stdin:         bytes.NewReader(nil),
+		Stdout:        &output,
+		Stderr:        &output,
+		TTY:           &tty,
+	})
+	if err != nil {
+		return nil, &rpcError{Code: codeInternalError, Message: err.Error()}
+	}
+	return ExecResult{ExitCode: exitCode, Output: output.String()}, nil
+}
+
+// PortInfo describes one forwarded port.
+type PortInfo struct {
+	ContainerPort int    `json:"containerPort"`
+	HostPort      int    `json:"hostPort,omitempty"`
+	Label         string `json:"label,omitempty"`
+}
+
+func (s *Server) handlePorts(ctx context.Context) (interface{}, *rpcError) {
+	resolved, err := s.svc.Load(ctx)
+	if err != nil {
+		return nil, &rpcError{Code: codeInternalError, Message: err.Error()}
+	}
+
+	var ports []PortInfo
+	for _, p := range resolved.ForwardPorts {
+		ports = append(ports, PortInfo{
+			ContainerPort: p.ContainerPort,
+			HostPort:      p.HostPort,
+			Label:         p.Label,
+		})
+	}
+
+	// Include the dcx SSH port when the container exists.
+	ids, _ := s.svc.GetIdentifiers()
+	if _, info, err := s.svc.GetStateManager().GetStateWithProject(ctx, ids.ProjectName, ids.WorkspaceID); err == nil && info != nil {
+		if port := info.Labels.SSHHostPort; port > 0 {
+			ports = append(ports, PortInfo{ContainerPort: 48022, HostPort: port, Label: "dcx SSH"})
+		}
+	}
+	return ports, nil
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// serveOne pushes one request line through Serve and decodes the single
+// response. Only protocol-level paths are exercised here (parse errors,
+// unknown methods, invalid params) — they never touch the service, so a
+// nil-service Server is safe.
+func serveOne(t *testing.T, input string) response {
+	t.Helper()
+	server := NewServer(nil, "", "")
+
+	var out bytes.Buffer
+	if err := server.Serve(context.Background(), strings.NewReader(input+"\n"), &out); err != nil {
+		t.Fatalf("Serve returned error: %v", err)
+	}
+
+	var resp response
+	if err := json.Unmarshal(out.Bytes(), &resp); err != nil {
+		t.Fatalf("response is not valid JSON: %v\noutput: %s", err, out.String())
+	}
+	return resp
+}
+
+func TestServeParseError(t *testing.T) {
+	resp := serveOne(t, "{not json")
+	if resp.Error == nil || resp.Error.Code != codeParseError {
+		t.Errorf("expected parse error %d, got %+v", codeParseError, resp.Error)
+	}
+}
+
+func TestServeMethodNotFound(t *testing.T) {
+	resp := serveOne(t, `{"jsonrpc":"2.0","id":1,"method":"nope"}`)
+	if resp.Error == nil || resp.Error.Code != codeMethodNotFound {
+		t.Errorf("expected method-not-found %d, got %+v", codeMethodNotFound, resp.Error)
+	}
+	if string(resp.ID) != "1" {
+		t.Errorf("response should echo the request id, got %s", resp.ID)
+	}
+}
+
+func TestServeExecInvalidParams(t *testing.T) {
+	// An empty command array is rejected before the service is consulted.
+	resp := serveOne(t, `{"jsonrpc":"2.0","id":2,"method":"exec","params":{"command":[]}}`)
+	if resp.Error == nil || resp.Error.Code != codeInvalidParams {
+		t.Errorf("expected invalid-params %d, got %+v", codeInvalidParams, resp.Error)
+	}
+}
+
+func TestServeSkipsBlankLines(t *testing.T) {
+	server := NewServer(nil, "", "")
+	var out bytes.Buffer
+	input := "\n\n" + `{"jsonrpc":"2.0","id":3,"method":"nope"}` + "\n"
+	if err := server.Serve(context.Background(), strings.NewReader(input), &out); err != nil {
+		t.Fatalf("Serve returned error: %v", err)
+	}
+	if got := strings.Count(out.String(), "\n"); got != 1 {
+		t.Errorf("expected exactly one response line, got %d (%q)", got, out.String())
+	}
+}
@@ -0,0 +1,73 @@
+// Package audit records security-relevant actions (privileged containers,
+// docker socket mounts, host networking, secret fetches) to an
+// append-only local JSONL log for compliance review.
+package audit
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Entry is one audited action.
+type Entry struct {
+	Time        time.Time         `json:"time"`
+	Action      string            `json:"action"`
+	WorkspaceID string            `json:"workspaceID,omitempty"`
+	ConfigHash  string            `json:"configHash,omitempty"`
+	Details     map[string]string `json:"details,omitempty"`
+}
+
+// Audited actions.
+const (
+	ActionPrivilegedContainer = "privileged-container"
+	ActionDockerSocketMount   = "docker-socket-mount"
+	ActionHostNetwork         = "host-network"
+	ActionSecretsFetched      = "secrets-fetched"
+	ActionCapabilityAdded     = "capability-added"
+)
+
+// LogPath returns the audit log location under the XDG state dir.
+func LogPath() string {
+	if stateHome := os.Getenv("XDG_STATE_HOME"); stateHome != "" {
+		return filepath.Join(stateHome, "dcx", "audit.jsonl")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".local", "state", "dcx", "audit.jsonl")
+}
+
+// Record appends one entry. Best-effort — auditing never blocks an
+// operation — but append-only: the file is opened O_APPEND and existing
+// content is never rewritten.
+func Record(action, workspaceID, configHash string, details map[string]string) {
+	entry := Entry{
+		Time:        time.Now(),
+		Action:      action,
+		WorkspaceID: workspaceID,
+		ConfigHash:  configHash,
+		Details:     details,
+	}
+
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	path := LogPath()
+	if path == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	defer func() { _ = f.Close() }()
+	_, _ = f.Write(append(payload, '\n'))
+}
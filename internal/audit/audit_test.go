@@ -0,0 +1,54 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecordAppendsEntries(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	Record(ActionPrivilegedContainer, "ws1", "hash1", nil)
+	Record(ActionSecretsFetched, "ws1", "hash1", map[string]string{"count": "2"})
+
+	f, err := os.Open(LogPath())
+	if err != nil {
+		t.Fatalf("audit log not written: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("log line is not JSON: %v", err)
+		}
+		entries = append(entries, e)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	first, second := entries[0], entries[1]
+	if first.Action != ActionPrivilegedContainer || first.WorkspaceID != "ws1" || first.ConfigHash != "hash1" {
+		t.Errorf("first entry malformed: %+v", first)
+	}
+	if first.Time.IsZero() {
+		t.Error("entries must carry timestamps")
+	}
+	if second.Action != ActionSecretsFetched || second.Details["count"] != "2" {
+		t.Errorf("second entry malformed: %+v", second)
+	}
+}
+
+func TestLogPathHonorsStateHome(t *testing.T) {
+	stateHome := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", stateHome)
+	if got := LogPath(); got != filepath.Join(stateHome, "dcx", "audit.jsonl") {
+		t.Errorf("LogPath() = %q", got)
+	}
+}
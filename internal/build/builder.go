@@ -49,6 +49,9 @@ type DockerfileBuildOptions struct {
 	// CacheFrom is a list of images to use as cache sources.
 	CacheFrom []string
 
+	// CacheTo is a list of cache export destinations (--cache-to).
+	CacheTo []string
+
 	// NoCache disables build cache.
 	NoCache bool
 
@@ -75,6 +78,21 @@ type DockerfileBuildOptions struct {
 	// Options are additional docker build CLI options from devcontainer.json build.options.
 	// These are passed directly to the docker build command.
 	Options []string
+
+	// ForwardSSHAgent mounts the host SSH agent into the build
+	// (--ssh default) for builds fetching private git dependencies.
+	ForwardSSHAgent bool
+
+	// SBOM attaches an SPDX SBOM attestation (buildx --sbom=true).
+	SBOM bool
+
+	// Provenance attaches SLSA provenance (buildx --provenance=mode=max).
+	Provenance bool
+
+	// Platform is the target platform (e.g. "linux/arm64") for
+	// cross-platform builds via buildx. Empty means the daemon's native
+	// platform.
+	Platform string
 }
 
 // FeatureBuildOptions contains options for building with features.
@@ -106,6 +124,22 @@ type FeatureBuildOptions struct {
 
 	// LocalConfig is the local devcontainer.json config for metadata merging.
 	LocalConfig *devcontainer.DevContainerConfig
+
+	// Platform is the target platform for cross-platform builds.
+	Platform string
+
+	// CacheFrom/CacheTo are buildx cache import/export flags so feature
+	// installation layers can be shared via a registry.
+	CacheFrom []string
+	CacheTo   []string
+
+	// SBOM/Provenance request buildx attestations on the derived image.
+	SBOM       bool
+	Provenance bool
+
+	// CacheDirs are persistent BuildKit cache mounts attached to feature
+	// install steps (apt/npm/pip caches).
+	CacheDirs []string
 }
 
 // UIDBuildOptions contains options for UID update builds.
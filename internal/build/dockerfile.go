@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/griffithind/dcx/internal/common"
 	"github.com/griffithind/dcx/internal/devcontainer"
 )
 
@@ -54,10 +55,13 @@ func (b *CLIBuilder) BuildFromDockerfile(ctx context.Context, opts DockerfileBui
 		args = append(args, "--label", fmt.Sprintf("%s=%s", devcontainer.DevcontainerMetadataLabel, opts.Metadata))
 	}
 
-	// Cache from
+	// Cache import/export
 	for _, cache := range opts.CacheFrom {
 		args = append(args, "--cache-from", cache)
 	}
+	for _, cache := range opts.CacheTo {
+		args = append(args, "--cache-to", cache)
+	}
 
 	// Other flags
 	if opts.NoCache {
@@ -69,6 +73,15 @@ func (b *CLIBuilder) BuildFromDockerfile(ctx context.Context, opts DockerfileBui
 	if opts.Target != "" {
 		args = append(args, "--target", opts.Target)
 	}
+	if opts.Platform != "" {
+		args = append(args, "--platform", opts.Platform)
+	}
+	if opts.SBOM {
+		args = append(args, "--sbom=true")
+	}
+	if opts.Provenance {
+		args = append(args, "--provenance=mode=max")
+	}
 
 	// Add build contexts (for BuildKit builds with --build-context flag)
 	for name, path := range opts.BuildContexts {
@@ -80,6 +93,11 @@ func (b *CLIBuilder) BuildFromDockerfile(ctx context.Context, opts DockerfileBui
 		args = append(args, "--secret", fmt.Sprintf("id=%s,src=%s", id, path))
 	}
 
+	// SSH agent forwarding for private git dependencies
+	if opts.ForwardSSHAgent && common.IsSSHAgentAvailable() {
+		args = append(args, "--ssh", "default")
+	}
+
 	// Add additional build options from devcontainer.json build.options
 	args = append(args, opts.Options...)
 
@@ -102,7 +120,10 @@ func (b *CLIBuilder) BuildFromDockerfile(ctx context.Context, opts DockerfileBui
 	}
 
 	// Run the build
-	if err := cmd.Run(); err != nil {
+	done := common.DebugCommand("docker", args)
+	err = cmd.Run()
+	done(err)
+	if err != nil {
 		return "", fmt.Errorf("docker build failed: %w", err)
 	}
 
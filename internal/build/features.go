@@ -2,14 +2,58 @@ package build
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 
 	"github.com/griffithind/dcx/internal/common"
 	"github.com/griffithind/dcx/internal/features"
 )
 
+// SharedFeatureTag returns a workspace-independent tag for a derived
+// image, keyed by everything that shapes its content: the base image
+// digest, the remote/container users (the _REMOTE_USER build arg drives
+// where feature installs land), the merged devcontainer.metadata label
+// baked into the image, and the ordered feature set with options.
+// Workspaces agreeing on all of these resolve to the same tag and share
+// one derived image; any difference — including a different remoteUser or
+// local config — produces a distinct tag instead of silently reusing an
+// image with the wrong user layout or foreign metadata.
+func SharedFeatureTag(baseImageDigest, remoteUser, containerUser, metadata string, featureList []*features.Feature) string {
+	h := sha256.New()
+	h.Write([]byte(baseImageDigest))
+	fmt.Fprintf(h, "\x00users:%s:%s", remoteUser, containerUser)
+	fmt.Fprintf(h, "\x00metadata:%s", metadata)
+	for _, f := range featureList {
+		version := ""
+		if f.Metadata != nil {
+			version = f.Metadata.Version
+		}
+		optData, _ := json.Marshal(sortedOptions(f.Options))
+		fmt.Fprintf(h, "\x00%s:%s:%s", f.ID, version, optData)
+	}
+	return fmt.Sprintf("dcx-features:%x", h.Sum(nil)[:6])
+}
+
+// sortedOptions renders options as ordered key=value pairs so the hash is
+// independent of map iteration order.
+func sortedOptions(options map[string]interface{}) []string {
+	keys := make([]string, 0, len(options))
+	for k := range options {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		value, _ := json.Marshal(options[k])
+		pairs[i] = k + "=" + string(value)
+	}
+	return pairs
+}
+
 // BuildWithFeatures builds a derived image with features installed.
 // Uses BuildKit build contexts for efficient feature content delivery.
 func (b *CLIBuilder) BuildWithFeatures(ctx context.Context, opts FeatureBuildOptions) (string, error) {
@@ -79,6 +123,7 @@ func (b *CLIBuilder) BuildWithFeatures(ctx context.Context, opts FeatureBuildOpt
 	// Generate Dockerfile using the features package
 	generator := features.NewDockerfileGenerator(opts.BaseImage, opts.Features, buildContextDir, remoteUser, containerUser)
 	generator.SetMetadata(metadataLabel)
+	generator.SetCacheDirs(opts.CacheDirs)
 	dockerfile := generator.Generate()
 
 	// Write Dockerfile to build context
@@ -97,6 +142,11 @@ func (b *CLIBuilder) BuildWithFeatures(ctx context.Context, opts FeatureBuildOpt
 		Tag:        opts.Tag,
 		Dockerfile: "Dockerfile.dcx-features",
 		Context:    buildContextDir,
+		Platform:   opts.Platform,
+		CacheFrom:  opts.CacheFrom,
+		CacheTo:    opts.CacheTo,
+		SBOM:       opts.SBOM,
+		Provenance: opts.Provenance,
 		BuildContexts: map[string]string{
 			"dev_containers_feature_content_source": featureSourceDir,
 		},
@@ -0,0 +1,57 @@
+package build
+
+import (
+	"os"
+	"runtime"
+	"strings"
+)
+
+// qemuBinfmtNames maps a docker platform architecture to the binfmt_misc
+// handler QEMU registers for it.
+var qemuBinfmtNames = map[string]string{
+	"amd64":   "qemu-x86_64",
+	"arm64":   "qemu-aarch64",
+	"arm":     "qemu-arm",
+	"riscv64": "qemu-riscv64",
+	"ppc64le": "qemu-ppc64le",
+	"s390x":   "qemu-s390x",
+}
+
+// PlatformArch extracts the architecture component from a docker platform
+// string ("linux/arm64" → "arm64", "linux/arm/v7" → "arm").
+func PlatformArch(platform string) string {
+	parts := strings.Split(platform, "/")
+	if len(parts) >= 2 {
+		return parts[1]
+	}
+	return platform
+}
+
+// NormalizeArch maps `docker info` architecture names to docker platform
+// architecture names (x86_64 → amd64, aarch64 → arm64).
+func NormalizeArch(arch string) string {
+	switch arch {
+	case "x86_64":
+		return "amd64"
+	case "aarch64":
+		return "arm64"
+	}
+	return arch
+}
+
+// QEMUAvailable reports whether a QEMU binfmt handler is registered for the
+// given target architecture, enabling cross-platform builds. Only
+// meaningful on Linux hosts where /proc/sys/fs/binfmt_misc is visible; on
+// macOS the Docker Desktop/Lima VM carries its own handlers, so this
+// returns true there and detection is left to the build itself.
+func QEMUAvailable(targetArch string) bool {
+	if runtime.GOOS != "linux" {
+		return true
+	}
+	name, ok := qemuBinfmtNames[targetArch]
+	if !ok {
+		return false
+	}
+	_, err := os.Stat("/proc/sys/fs/binfmt_misc/" + name)
+	return err == nil
+}
@@ -0,0 +1,58 @@
+package build
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/griffithind/dcx/internal/features"
+)
+
+func sharedTagFeature(id, version string, options map[string]interface{}) *features.Feature {
+	return &features.Feature{
+		ID:       id,
+		Options:  options,
+		Metadata: &features.FeatureMetadata{Version: version},
+	}
+}
+
+func TestSharedFeatureTagDeterministic(t *testing.T) {
+	feats := []*features.Feature{
+		sharedTagFeature("ghcr.io/devcontainers/features/go:1", "1.3.0", map[string]interface{}{
+			"version": "1.22", "installTools": true,
+		}),
+	}
+
+	first := SharedFeatureTag("sha256:abc", "vscode", "vscode", "meta", feats)
+	second := SharedFeatureTag("sha256:abc", "vscode", "vscode", "meta", feats)
+	if first != second {
+		t.Errorf("tag not deterministic: %s vs %s", first, second)
+	}
+	if !strings.HasPrefix(first, "dcx-features:") {
+		t.Errorf("unexpected tag shape: %s", first)
+	}
+}
+
+func TestSharedFeatureTagVariesWithInputs(t *testing.T) {
+	feats := []*features.Feature{
+		sharedTagFeature("ghcr.io/devcontainers/features/go:1", "1.3.0", map[string]interface{}{"version": "1.22"}),
+	}
+
+	base := SharedFeatureTag("sha256:abc", "vscode", "vscode", "meta", feats)
+
+	if got := SharedFeatureTag("sha256:other", "vscode", "vscode", "meta", feats); got == base {
+		t.Error("tag should change with the base image digest")
+	}
+	if got := SharedFeatureTag("sha256:abc", "node", "vscode", "meta", feats); got == base {
+		t.Error("tag should change with the remote user")
+	}
+	if got := SharedFeatureTag("sha256:abc", "vscode", "vscode", "other-meta", feats); got == base {
+		t.Error("tag should change with the merged metadata label")
+	}
+
+	changed := []*features.Feature{
+		sharedTagFeature("ghcr.io/devcontainers/features/go:1", "1.3.0", map[string]interface{}{"version": "1.23"}),
+	}
+	if got := SharedFeatureTag("sha256:abc", "vscode", "vscode", "meta", changed); got == base {
+		t.Error("tag should change with feature options")
+	}
+}
@@ -0,0 +1,209 @@
+package build
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/griffithind/dcx/internal/features"
+)
+
+// SinglePassBuildOptions drives BuildSinglePass: one multi-stage BuildKit
+// build covering the user Dockerfile, the feature installation layer, and
+// the UID update layer.
+type SinglePassBuildOptions struct {
+	// Tag for the final image.
+	Tag string
+
+	// Dockerfile is the path to the user's Dockerfile.
+	Dockerfile string
+
+	// Context is the user's build context directory.
+	Context string
+
+	// Args are the user's build args.
+	Args map[string]string
+
+	// Features to install on top of the user stage.
+	Features []*features.Feature
+
+	// RemoteUser / ContainerUser as in FeatureBuildOptions.
+	RemoteUser    string
+	ContainerUser string
+
+	// UpdateUID appends the UID rewrite stage.
+	UpdateUID bool
+	HostUID   int
+	HostGID   int
+
+	// Metadata is the merged devcontainer.metadata label value.
+	Metadata string
+
+	// CacheDirs are persistent BuildKit cache mounts for feature installs.
+	CacheDirs []string
+
+	// Secrets, cache flags, and platform as in DockerfileBuildOptions.
+	Secrets    map[string]string
+	CacheFrom  []string
+	CacheTo    []string
+	Platform   string
+	NoCache    bool
+	Progress   io.Writer
+	SBOM       bool
+	Provenance bool
+}
+
+// Stage names used in the combined Dockerfile.
+const (
+	singlePassBaseStage    = "dcx_user_base"
+	singlePassFeatureStage = "dcx_features"
+)
+
+// BuildSinglePass collapses the sequential base/features/UID builds into
+// one multi-stage BuildKit build: the user Dockerfile's final stage is
+// named and becomes the FROM of the feature stage, which in turn feeds
+// the UID stage. No intermediate images are written and the build context
+// transfers once.
+//
+// Dockerfiles using --target (the caller checks) or exotic syntax that
+// defeats stage naming fall back to the sequential path.
+func (b *CLIBuilder) BuildSinglePass(ctx context.Context, opts SinglePassBuildOptions) (string, error) {
+	userContent, err := os.ReadFile(opts.Dockerfile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read Dockerfile: %w", err)
+	}
+
+	named, baseStage, ok := nameFinalStage(string(userContent), singlePassBaseStage)
+	if !ok {
+		return "", fmt.Errorf("could not identify the Dockerfile's final stage")
+	}
+
+	// Stage feature content for the BuildKit build context.
+	tempDir, err := os.MkdirTemp("", "dcx-singlepass-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tempDir) }()
+
+	featureSourceDir := filepath.Join(tempDir, "features")
+	if err := os.MkdirAll(featureSourceDir, 0755); err != nil {
+		return "", err
+	}
+
+	containerUser := opts.ContainerUser
+	if containerUser == "" {
+		containerUser = "root"
+	}
+	remoteUser := opts.RemoteUser
+	if remoteUser == "" {
+		remoteUser = containerUser
+	}
+	if err := stageFeatures(opts.Features, featureSourceDir, containerUser, remoteUser); err != nil {
+		return "", fmt.Errorf("failed to stage features: %w", err)
+	}
+
+	var sb strings.Builder
+	// The feature stage needs RUN --mount; one syntax directive covers
+	// the whole combined file and must be the first line.
+	sb.WriteString("# syntax=docker/dockerfile:1.4\n")
+	sb.WriteString("# Generated by dcx - single-pass build (user Dockerfile + features + UID)\n\n")
+	sb.WriteString(stripSyntaxDirective(named))
+	sb.WriteString("\n")
+
+	finalStage := baseStage
+	if len(opts.Features) > 0 {
+		generator := features.NewDockerfileGenerator(baseStage, opts.Features, tempDir, remoteUser, containerUser)
+		generator.SetMetadata(opts.Metadata)
+		generator.SetCacheDirs(opts.CacheDirs)
+		featureContent := stripSyntaxDirective(generator.Generate())
+		featureContent = strings.Replace(featureContent,
+			"FROM "+baseStage+"\n",
+			"FROM "+baseStage+" AS "+singlePassFeatureStage+"\n", 1)
+		sb.WriteString("\n# dcx: feature installation stage\n")
+		sb.WriteString(featureContent)
+		finalStage = singlePassFeatureStage
+	}
+
+	buildArgs := make(map[string]string, len(opts.Args)+5)
+	for k, v := range opts.Args {
+		buildArgs[k] = v
+	}
+
+	if opts.UpdateUID && ShouldUpdateRemoteUserUID(remoteUser, opts.HostUID) {
+		sb.WriteString("\n# dcx: UID update stage\n")
+		sb.WriteString(updateUIDDockerfile)
+		buildArgs["BASE_IMAGE"] = finalStage
+		buildArgs["REMOTE_USER"] = remoteUser
+		buildArgs["NEW_UID"] = strconv.Itoa(opts.HostUID)
+		buildArgs["NEW_GID"] = strconv.Itoa(opts.HostGID)
+		buildArgs["IMAGE_USER"] = containerUser
+		buildArgs["METADATA_LABEL"] = opts.Metadata
+	}
+
+	combinedPath := filepath.Join(tempDir, "Dockerfile.dcx-singlepass")
+	if err := os.WriteFile(combinedPath, []byte(sb.String()), 0644); err != nil {
+		return "", fmt.Errorf("failed to write combined Dockerfile: %w", err)
+	}
+
+	_, err = b.BuildFromDockerfile(ctx, DockerfileBuildOptions{
+		Tag:        opts.Tag,
+		Dockerfile: combinedPath,
+		Context:    opts.Context,
+		Args:       buildArgs,
+		Secrets:    opts.Secrets,
+		CacheFrom:  opts.CacheFrom,
+		CacheTo:    opts.CacheTo,
+		Platform:   opts.Platform,
+		NoCache:    opts.NoCache,
+		SBOM:       opts.SBOM,
+		Provenance: opts.Provenance,
+		Progress:   opts.Progress,
+		BuildContexts: map[string]string{
+			"dev_containers_feature_content_source": featureSourceDir,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("single-pass build failed: %w", err)
+	}
+	return opts.Tag, nil
+}
+
+// nameFinalStage ensures the Dockerfile's final stage carries a name the
+// appended stages can reference. Returns the (possibly rewritten)
+// content, the stage name, and whether a final FROM was found.
+func nameFinalStage(content, defaultName string) (named, stageName string, ok bool) {
+	lines := strings.Split(content, "\n")
+	lastFrom := -1
+	for i, line := range lines {
+		if strings.HasPrefix(strings.ToUpper(strings.TrimSpace(line)), "FROM ") {
+			lastFrom = i
+		}
+	}
+	if lastFrom < 0 {
+		return content, "", false
+	}
+
+	fields := strings.Fields(lines[lastFrom])
+	for i, f := range fields {
+		if strings.EqualFold(f, "AS") && i+1 < len(fields) {
+			return content, fields[i+1], true
+		}
+	}
+
+	lines[lastFrom] = strings.TrimRight(lines[lastFrom], " \t") + " AS " + defaultName
+	return strings.Join(lines, "\n"), defaultName, true
+}
+
+// stripSyntaxDirective removes a leading "# syntax=" line; the combined
+// file carries a single directive at the very top.
+func stripSyntaxDirective(content string) string {
+	lines := strings.SplitN(content, "\n", 2)
+	if len(lines) == 2 && strings.HasPrefix(strings.TrimSpace(lines[0]), "# syntax=") {
+		return lines[1]
+	}
+	return content
+}
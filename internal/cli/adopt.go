@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/griffithind/dcx/internal/ui"
+)
+
+var adoptFrom string
+
+var adoptCmd = &cobra.Command{
+	Use:   "adopt",
+	Short: "Rebind a container from a moved workspace to this path",
+	Long: `Adopt a dcx container created for this project at a different
+workspace path — e.g. after the repo was cloned to a new directory.
+
+Container labels (and the bind-mounted workspace path) are immutable,
+so adoption commits the old container's filesystem, removes it, and
+recreates the container here with fresh labels and the new workspace
+mount. Named volumes and installed state are preserved; lifecycle
+create hooks are not re-run.
+
+With --from vscode, takes over a container created by VS Code Remote
+Containers or the devcontainers/cli for this workspace folder instead.`,
+	RunE: runAdopt,
+}
+
+func init() {
+	adoptCmd.Flags().StringVar(&adoptFrom, "from", "", "adopt from another tool's container (vscode)")
+	adoptCmd.GroupID = "maintenance"
+	rootCmd.AddCommand(adoptCmd)
+}
+
+func runAdopt(cmd *cobra.Command, args []string) error {
+	cliCtx, err := NewCLIContext()
+	if err != nil {
+		return err
+	}
+	defer cliCtx.Close()
+
+	if err := cliCtx.Service.Adopt(cliCtx.Ctx, adoptFrom); err != nil {
+		return err
+	}
+
+	ui.Success("Environment adopted")
+	return nil
+}
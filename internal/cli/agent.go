@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/griffithind/dcx/internal/common"
+	"github.com/griffithind/dcx/internal/container"
+	"github.com/griffithind/dcx/internal/ssh/deploy"
+	"github.com/griffithind/dcx/internal/ssh/server"
+	"github.com/griffithind/dcx/internal/ui"
+	"github.com/griffithind/dcx/internal/version"
+)
+
+var agentCmd = &cobra.Command{
+	Use:   "agent",
+	Short: "Inspect the in-container dcx-agent",
+}
+
+var agentStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show agent version, uptime, and active sessions",
+	RunE:  runAgentStatus,
+}
+
+func init() {
+	agentCmd.AddCommand(agentStatusCmd)
+	agentCmd.GroupID = "info"
+	rootCmd.AddCommand(agentCmd)
+}
+
+func runAgentStatus(cmd *cobra.Command, args []string) error {
+	cliCtx, err := NewCLIContext()
+	if err != nil {
+		return err
+	}
+	defer cliCtx.Close()
+
+	containerInfo, err := RequireRunningContainer(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	deployed := deploy.DeployedAgentVersion(cliCtx.Ctx, containerInfo.Name, common.AgentBinaryPath)
+	if deployed == "" {
+		ui.Printf("%s", ui.FormatLabel("Agent", "not deployed (run 'dcx up')"))
+		return nil
+	}
+
+	versionText := deployed
+	if deployed != version.Version {
+		versionText = fmt.Sprintf("%s (host is %s — redeployed on next up)", deployed, version.Version)
+	}
+	ui.Printf("%s", ui.FormatLabel("Version", versionText))
+
+	docker := container.MustDocker()
+
+	// Uptime from the ready file's recorded start time (second line).
+	if output, err := docker.SimpleExecInContainer(cliCtx.Ctx, containerInfo.Name, container.SimpleExecOptions{
+		Cmd: []string{"cat", server.DefaultReadyFilePath},
+	}); err == nil {
+		lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+		if len(lines) >= 2 {
+			if ts, err := strconv.ParseInt(strings.TrimSpace(lines[1]), 10, 64); err == nil {
+				uptime := time.Since(time.Unix(ts, 0)).Round(time.Second)
+				ui.Printf("%s", ui.FormatLabel("Uptime", uptime.String()))
+			}
+		}
+		if len(lines) >= 1 {
+			ui.Printf("%s", ui.FormatLabel("Listening", lines[0]))
+		}
+	}
+
+	sessions := "0"
+	if output, err := docker.SimpleExecInContainer(cliCtx.Ctx, containerInfo.Name, container.SimpleExecOptions{
+		Cmd: []string{"cat", server.SessionCountPath},
+	}); err == nil {
+		if text := strings.TrimSpace(string(output)); text != "" {
+			sessions = text
+		}
+	}
+	ui.Printf("%s", ui.FormatLabel("Active Sessions", sessions))
+
+	return nil
+}
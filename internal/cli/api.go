@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/griffithind/dcx/internal/api"
+	"github.com/griffithind/dcx/internal/ui"
+)
+
+var apiCmd = &cobra.Command{
+	Use:   "api",
+	Short: "Serve a JSON-RPC API on stdin/stdout",
+	Long: `Serve core dcx operations over JSON-RPC 2.0 on stdin/stdout.
+
+One request per line in, one response per line out. Methods: status,
+plan, up, exec, ports. Intended for editor plugins and GUIs that embed
+dcx programmatically instead of scraping CLI output.
+
+Example:
+  echo '{"jsonrpc":"2.0","id":1,"method":"status"}' | dcx api`,
+	RunE: runAPI,
+}
+
+func init() {
+	apiCmd.GroupID = "utilities"
+	rootCmd.AddCommand(apiCmd)
+}
+
+func runAPI(cmd *cobra.Command, args []string) error {
+	cliCtx, err := NewCLIContext()
+	if err != nil {
+		return err
+	}
+	defer cliCtx.Close()
+
+	return serveAPI(cliCtx.Ctx, cliCtx.Service, os.Stdin, os.Stdout)
+}
+
+// serveAPI serves the JSON-RPC protocol on protocolOut while routing
+// every other output stream to stderr: methods like "up" drive the full
+// service path, whose ui messages and docker build/compose progress
+// otherwise interleave free text into the protocol stream and break
+// clients. os.Stdout is swapped for the duration (build progress is
+// wired to it at call time) and ui is reconfigured onto stderr.
+func serveAPI(ctx context.Context, svc api.Service, in io.Reader, protocolOut *os.File) error {
+	origStdout := os.Stdout
+	os.Stdout = os.Stderr
+	defer func() { os.Stdout = origStdout }()
+
+	verbosity := ui.VerbosityNormal
+	if quiet {
+		verbosity = ui.VerbosityQuiet
+	}
+	ui.Configure(ui.Config{
+		Verbosity: verbosity,
+		NoColor:   true,
+		Writer:    os.Stderr,
+		ErrWriter: os.Stderr,
+	})
+
+	server := api.NewServer(svc, workspacePath, configPath)
+	return server.Serve(ctx, in, protocolOut)
+}
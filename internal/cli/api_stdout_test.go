@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/griffithind/dcx/internal/devcontainer"
+	"github.com/griffithind/dcx/internal/service"
+	"github.com/griffithind/dcx/internal/state"
+)
+
+// noisyService stubs api.Service with an Up that spews free text to
+// os.Stdout, the way real ui output and docker build progress do.
+type noisyService struct {
+	stateManager *state.StateManager
+}
+
+func (s *noisyService) GetIdentifiers() (*service.Identifiers, error) {
+	return &service.Identifiers{ProjectName: "demo", WorkspaceID: "ws1", SSHHost: "ws1.dcx"}, nil
+}
+
+func (s *noisyService) GetStateManager() *state.StateManager { return s.stateManager }
+
+func (s *noisyService) Plan(ctx context.Context, opts service.PlanOptions) (*service.PlanResult, error) {
+	return &service.PlanResult{}, nil
+}
+
+func (s *noisyService) Up(ctx context.Context, opts service.UpOptions) error {
+	// Simulates ui.Println and `Progress: os.Stdout` build output.
+	fmt.Fprintln(os.Stdout, "Creating single-container devcontainer...")
+	fmt.Fprintln(os.Stdout, "#1 [internal] load build definition")
+	return nil
+}
+
+func (s *noisyService) Load(ctx context.Context) (*devcontainer.ResolvedDevContainer, error) {
+	return devcontainer.NewResolvedDevContainer(), nil
+}
+
+// emptyClient backs a StateManager with no containers.
+type emptyClient struct{}
+
+func (emptyClient) ListContainersWithLabels(context.Context, map[string]string) ([]state.ContainerSummary, error) {
+	return nil, nil
+}
+func (emptyClient) InspectContainer(context.Context, string) (*state.ContainerDetails, error) {
+	return nil, fmt.Errorf("not found")
+}
+func (emptyClient) StopContainer(context.Context, string, *time.Duration) error { return nil }
+func (emptyClient) RemoveContainer(context.Context, string, bool, bool) error   { return nil }
+
+func TestServeAPIKeepsProtocolStreamClean(t *testing.T) {
+	svc := &noisyService{stateManager: state.NewStateManager(emptyClient{})}
+
+	protocolPath := filepath.Join(t.TempDir(), "protocol")
+	protocolOut, err := os.Create(protocolPath)
+	require.NoError(t, err)
+
+	input := strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"up"}` + "\n")
+	require.NoError(t, serveAPI(context.Background(), svc, input, protocolOut))
+	require.NoError(t, protocolOut.Close())
+
+	data, err := os.ReadFile(protocolPath)
+	require.NoError(t, err)
+
+	// The protocol stream must contain only JSON lines — none of the
+	// free text Up wrote to os.Stdout.
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 1, "expected exactly one response line, got %q", string(data))
+
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &resp), "protocol line is not JSON: %q", lines[0])
+	assert.Equal(t, "2.0", resp["jsonrpc"])
+	assert.NotContains(t, string(data), "load build definition")
+
+	// os.Stdout must be restored after serving.
+	assert.NotEqual(t, os.Stderr, os.Stdout)
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/griffithind/dcx/internal/audit"
+	"github.com/griffithind/dcx/internal/ui"
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Show the security audit log",
+	Long: `Print the append-only audit log of security-relevant actions
+(privileged containers, docker socket mounts, host networking, secret
+fetches) as JSON lines for compliance review.`,
+	RunE: runAudit,
+}
+
+func init() {
+	auditCmd.GroupID = "info"
+	rootCmd.AddCommand(auditCmd)
+}
+
+func runAudit(cmd *cobra.Command, args []string) error {
+	f, err := os.Open(audit.LogPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			ui.Println("No audit entries recorded yet.")
+			return nil
+		}
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			fmt.Print(line)
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
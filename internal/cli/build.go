@@ -7,8 +7,11 @@ import (
 )
 
 var (
-	noCache   bool
-	pullBuild bool
+	noCache         bool
+	pullBuild       bool
+	buildPlatform   string
+	buildSBOM       bool
+	buildProvenance bool
 )
 
 var buildCmd = &cobra.Command{
@@ -28,6 +31,9 @@ This command may require network access for pulling base images.`,
 func init() {
 	buildCmd.Flags().BoolVar(&noCache, "no-cache", false, "build without using cache")
 	buildCmd.Flags().BoolVar(&pullBuild, "pull", false, "force re-fetch remote features (useful when feature tags like :latest are updated)")
+	buildCmd.Flags().StringVar(&buildPlatform, "platform", "", "target platform for cross-builds (e.g. linux/arm64)")
+	buildCmd.Flags().BoolVar(&buildSBOM, "sbom", false, "attach an SPDX SBOM attestation to built images")
+	buildCmd.Flags().BoolVar(&buildProvenance, "provenance", false, "attach SLSA provenance to built images")
 	buildCmd.GroupID = "maintenance"
 	rootCmd.AddCommand(buildCmd)
 }
@@ -44,8 +50,11 @@ func runBuild(cmd *cobra.Command, args []string) error {
 
 	// Execute build
 	buildErr := cliCtx.Service.Build(cliCtx.Ctx, service.BuildOptions{
-		NoCache: noCache,
-		Pull:    pullBuild,
+		NoCache:    noCache,
+		Pull:       pullBuild,
+		Platform:   buildPlatform,
+		SBOM:       buildSBOM,
+		Provenance: buildProvenance,
 	})
 
 	// Stop spinner with appropriate message
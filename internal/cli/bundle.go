@@ -0,0 +1,255 @@
+package cli
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/griffithind/dcx/internal/common"
+	"github.com/griffithind/dcx/internal/lockfile"
+	"github.com/griffithind/dcx/internal/ui"
+	"github.com/griffithind/dcx/internal/version"
+)
+
+var exportIncludeImage bool
+
+var exportCmd = &cobra.Command{
+	Use:   "export <bundle.tar>",
+	Short: "Export the environment definition as a bundle",
+	Long: `Write a tar bundle containing everything needed to reproduce this
+environment elsewhere: devcontainer.json, the lockfile, the resolved
+configuration (for inspection), the generated compose override when
+present, and — with --include-image — the derived image via docker
+save. Import it on another machine with 'dcx import'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExport,
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import <bundle.tar>",
+	Short: "Import an environment bundle into this workspace",
+	Long: `Extract a bundle created by 'dcx export' into this workspace's
+.devcontainer directory (devcontainer.json, lockfile) and load the
+bundled derived image when present; then 'dcx up' reproduces the
+environment.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImport,
+}
+
+func init() {
+	exportCmd.Flags().BoolVar(&exportIncludeImage, "include-image", false, "bundle the derived image via docker save")
+	exportCmd.GroupID = "maintenance"
+	importCmd.GroupID = "maintenance"
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+}
+
+// bundleManifest records bundle provenance.
+type bundleManifest struct {
+	Version      string    `json:"dcxVersion"`
+	Exported     time.Time `json:"exported"`
+	WorkspaceID  string    `json:"workspaceID"`
+	ConfigHash   string    `json:"configHash"`
+	DerivedImage string    `json:"derivedImage,omitempty"`
+	HasImage     bool      `json:"hasImage"`
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	cliCtx, err := NewCLIContext()
+	if err != nil {
+		return err
+	}
+	defer cliCtx.Close()
+
+	resolved, err := cliCtx.Service.Load(cliCtx.Ctx)
+	if err != nil {
+		return err
+	}
+
+	out, err := os.Create(args[0])
+	if err != nil {
+		return err
+	}
+	defer func() { _ = out.Close() }()
+
+	tw := tar.NewWriter(out)
+	defer func() { _ = tw.Close() }()
+
+	addFile := func(name string, data []byte) error {
+		if err := tw.WriteHeader(&tar.Header{
+			Name: name, Mode: 0644, Size: int64(len(data)), ModTime: time.Now(),
+		}); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+
+	// Original config + lockfile.
+	configData, err := os.ReadFile(resolved.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	if err := addFile("devcontainer.json", configData); err != nil {
+		return err
+	}
+	if lockData, err := os.ReadFile(lockfile.GetPath(resolved.ConfigPath)); err == nil {
+		if err := addFile("devcontainer-lock.json", lockData); err != nil {
+			return err
+		}
+	}
+
+	// Resolved configuration, for human inspection on the other side.
+	if resolvedData, err := json.MarshalIndent(resolved, "", "  "); err == nil {
+		if err := addFile("resolved.json", resolvedData); err != nil {
+			return err
+		}
+	}
+
+	// Generated compose override, when a previous up produced one.
+	if dir := common.WorkspaceRuntimeDir(resolved.ID); dir != "" {
+		if overrideData, err := os.ReadFile(filepath.Join(dir, "override.yml")); err == nil {
+			if err := addFile("override.yml", overrideData); err != nil {
+				return err
+			}
+		}
+	}
+
+	manifest := bundleManifest{
+		Version:      version.Version,
+		Exported:     time.Now(),
+		WorkspaceID:  resolved.ID,
+		ConfigHash:   resolved.ConfigHash,
+		DerivedImage: resolved.DerivedImage,
+		HasImage:     exportIncludeImage,
+	}
+
+	// Derived image via docker save. Derived images are routinely
+	// multi-GB, so the save streams to a temp file first (the tar header
+	// needs the size up front) and is then copied into the bundle —
+	// never buffered in memory.
+	if exportIncludeImage && resolved.DerivedImage != "" {
+		ui.Printf("Saving image %s...", resolved.DerivedImage)
+		if err := addImageTar(cliCtx.Ctx, tw, resolved.DerivedImage); err != nil {
+			return err
+		}
+	}
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := addFile("manifest.json", manifestData); err != nil {
+		return err
+	}
+
+	ui.Success(fmt.Sprintf("Bundle written to %s", args[0]))
+	return nil
+}
+
+// addImageTar streams `docker save <image>` into the bundle via a temp
+// file: saved to disk, stat'd for the tar header size, then copied.
+func addImageTar(ctx context.Context, tw *tar.Writer, imageRef string) error {
+	tmpFile, err := os.CreateTemp("", "dcx-export-image-*.tar")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpFile.Name())
+	}()
+
+	saveCmd := exec.CommandContext(ctx, "docker", "save", imageRef)
+	saveCmd.Stdout = tmpFile
+	if err := saveCmd.Run(); err != nil {
+		return fmt.Errorf("docker save failed (is the derived image built?): %w", err)
+	}
+
+	info, err := tmpFile.Stat()
+	if err != nil {
+		return err
+	}
+	if _, err := tmpFile.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name: "image.tar", Mode: 0644, Size: info.Size(), ModTime: time.Now(),
+	}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, tmpFile)
+	return err
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	f, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	destDir := filepath.Join(workspacePath, ".devcontainer")
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return err
+	}
+
+	tr := tar.NewReader(f)
+	var manifest bundleManifest
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		switch header.Name {
+		case "devcontainer.json", "devcontainer-lock.json":
+			target := filepath.Join(destDir, header.Name)
+			if _, err := os.Stat(target); err == nil {
+				ui.Warning("Overwriting %s", target)
+			}
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(target, data, 0644); err != nil {
+				return err
+			}
+			ui.Printf("Extracted %s", target)
+
+		case "manifest.json":
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return err
+			}
+			_ = json.Unmarshal(data, &manifest)
+
+		case "image.tar":
+			ui.Println("Loading bundled image...")
+			loadCmd := exec.Command("docker", "load")
+			loadCmd.Stdin = tr
+			loadCmd.Stdout = os.Stdout
+			loadCmd.Stderr = os.Stderr
+			if err := loadCmd.Run(); err != nil {
+				return fmt.Errorf("docker load failed: %w", err)
+			}
+		}
+	}
+
+	if manifest.ConfigHash != "" {
+		ui.Printf("Imported bundle (config hash %s, exported by dcx %s)", manifest.ConfigHash[:12], manifest.Version)
+	}
+	ui.Success("Bundle imported; run 'dcx up' to start the environment")
+	return nil
+}
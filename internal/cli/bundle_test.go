@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeBundle builds a minimal export bundle on disk.
+func writeBundle(t *testing.T, dir string, files map[string][]byte) string {
+	t.Helper()
+	path := filepath.Join(dir, "bundle.tar")
+	out, err := os.Create(path)
+	require.NoError(t, err)
+	tw := tar.NewWriter(out)
+	for name, data := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name: name, Mode: 0644, Size: int64(len(data)), ModTime: time.Now(),
+		}))
+		_, err := tw.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, out.Close())
+	return path
+}
+
+func TestImportExtractsBundle(t *testing.T) {
+	ws := t.TempDir()
+	origWorkspace := workspacePath
+	workspacePath = ws
+	defer func() { workspacePath = origWorkspace }()
+
+	config := []byte(`{"image": "ubuntu:22.04"}`)
+	lock := []byte(`{"features": {}}`)
+	manifest, err := json.Marshal(bundleManifest{
+		Version:     "test",
+		Exported:    time.Now(),
+		WorkspaceID: "ws1",
+		ConfigHash:  "0123456789abcdef",
+	})
+	require.NoError(t, err)
+
+	bundlePath := writeBundle(t, t.TempDir(), map[string][]byte{
+		"devcontainer.json":      config,
+		"devcontainer-lock.json": lock,
+		"manifest.json":          manifest,
+		// Unknown entries must be ignored, not extracted.
+		"../escape.txt": []byte("nope"),
+	})
+
+	require.NoError(t, runImport(importCmd, []string{bundlePath}))
+
+	extracted, err := os.ReadFile(filepath.Join(ws, ".devcontainer", "devcontainer.json"))
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(config, extracted))
+
+	extractedLock, err := os.ReadFile(filepath.Join(ws, ".devcontainer", "devcontainer-lock.json"))
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(lock, extractedLock))
+
+	// The unknown path must not have escaped the workspace.
+	_, err = os.Stat(filepath.Join(ws, "..", "escape.txt"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestImportMissingBundle(t *testing.T) {
+	err := runImport(importCmd, []string{filepath.Join(t.TempDir(), "missing.tar")})
+	assert.Error(t, err)
+}
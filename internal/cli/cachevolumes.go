@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/griffithind/dcx/internal/config"
+	"github.com/griffithind/dcx/internal/container"
+	"github.com/griffithind/dcx/internal/ui"
+)
+
+var cacheVolumesCmd = &cobra.Command{
+	Use:   "cache-volumes",
+	Short: "Manage shared cache volumes",
+	Long: `Manage the shared cache volumes declared under cacheVolumes in the
+user config — named docker volumes mounted into every dcx container at
+standard paths (go module cache, cargo registry, npm cache), so
+dependencies download once across workspaces.`,
+}
+
+var cacheVolumesLsCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List configured cache volumes with sizes",
+	RunE:  runCacheVolumesLs,
+}
+
+var cacheVolumesCleanCmd = &cobra.Command{
+	Use:   "clean",
+	Short: "Remove the configured cache volumes",
+	RunE:  runCacheVolumesClean,
+}
+
+func init() {
+	cacheVolumesCmd.AddCommand(cacheVolumesLsCmd)
+	cacheVolumesCmd.AddCommand(cacheVolumesCleanCmd)
+	cacheVolumesCmd.GroupID = "maintenance"
+	rootCmd.AddCommand(cacheVolumesCmd)
+}
+
+// configuredCacheVolumes returns the sorted volume names from the user
+// config.
+func configuredCacheVolumes() []string {
+	userCfg, err := config.User()
+	if err != nil || len(userCfg.CacheVolumes) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(userCfg.CacheVolumes))
+	for name := range userCfg.CacheVolumes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func runCacheVolumesLs(cmd *cobra.Command, args []string) error {
+	names := configuredCacheVolumes()
+	if len(names) == 0 {
+		ui.Println("No cache volumes configured (cacheVolumes in the user config).")
+		return nil
+	}
+
+	docker, err := container.DockerClient()
+	if err != nil {
+		return err
+	}
+	sizes, _ := docker.VolumeSizes(cmd.Context())
+	userCfg, _ := config.User()
+
+	headers := []string{"Volume", "Mounted At", "Size"}
+	var rows [][]string
+	for _, name := range names {
+		size := sizes[name]
+		if size == "" {
+			size = ui.Dim("(not created yet)")
+		}
+		rows = append(rows, []string{name, userCfg.CacheVolumes[name], size})
+	}
+	return ui.RenderTable(headers, rows)
+}
+
+func runCacheVolumesClean(cmd *cobra.Command, args []string) error {
+	names := configuredCacheVolumes()
+	if len(names) == 0 {
+		ui.Println("No cache volumes configured.")
+		return nil
+	}
+
+	docker, err := container.DockerClient()
+	if err != nil {
+		return err
+	}
+
+	removed := 0
+	for _, name := range names {
+		if err := docker.RemoveVolume(cmd.Context(), name, false); err != nil {
+			ui.Warning("Failed to remove %s (in use?): %v", name, err)
+			continue
+		}
+		ui.Printf("Removed %s", name)
+		removed++
+	}
+	if removed > 0 {
+		ui.Success("Removed %d cache volume(s)", removed)
+	}
+	return nil
+}
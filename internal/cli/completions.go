@@ -0,0 +1,177 @@
+package cli
+
+import (
+	"context"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/griffithind/dcx/internal/container"
+	"github.com/griffithind/dcx/internal/devcontainer"
+	"github.com/griffithind/dcx/internal/lockfile"
+	"github.com/griffithind/dcx/internal/state"
+	"github.com/spf13/cobra"
+)
+
+// Dynamic completion functions. These back ValidArgsFunction and
+// RegisterFlagCompletionFunc hooks so generated bash/zsh/fish completions
+// can offer live values (workspace names, docker contexts, compose
+// services, shortcut names, feature IDs) instead of nothing.
+
+// completeWorkspaceNames completes names of dcx-managed environments.
+func completeWorkspaceNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	docker, err := container.DockerClient()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	containers, err := docker.ListContainersWithLabels(context.Background(), map[string]string{
+		state.LabelManaged: "true",
+	})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	for _, c := range containers {
+		lbls := state.ContainerLabelsFromMap(c.Labels)
+		name := lbls.WorkspaceName
+		if name == "" {
+			name = lbls.WorkspaceID
+		}
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeDockerContexts completes docker context names for --context.
+func completeDockerContexts(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	output, err := exec.Command("docker", "context", "ls", "--format", "{{.Name}}").Output()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var names []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line != "" {
+			names = append(names, line)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeComposeServices completes service names from the workspace's
+// compose files (for --service style flags).
+func completeComposeServices(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cfg, cfgPath, err := devcontainer.Load(workspacePath, configPath)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	composeFiles := cfg.GetDockerComposeFiles()
+	if len(composeFiles) == 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	composeArgs := []string{"compose"}
+	for _, f := range composeFiles {
+		composeArgs = append(composeArgs, "-f", f)
+	}
+	composeArgs = append(composeArgs, "config", "--services")
+
+	c := exec.Command("docker", composeArgs...)
+	c.Dir = configDirOf(cfgPath)
+	output, err := c.Output()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var services []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line != "" {
+			services = append(services, line)
+		}
+	}
+	return services, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeShortcuts completes shortcut names from customizations.dcx for
+// `dcx run`.
+func completeShortcuts(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	// Only the first positional is a shortcut; the rest passes through.
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+
+	cfg, _, err := devcontainer.Load(workspacePath, configPath)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	dcx := devcontainer.GetDcxCustomizations(cfg)
+	if dcx == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+	for name, sc := range dcx.Shortcuts {
+		if sc.Description != "" {
+			names = append(names, name+"\t"+sc.Description)
+		} else {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeFeatureIDs completes feature IDs, preferring the lockfile (exact
+// pinned IDs) and falling back to the devcontainer.json feature map.
+func completeFeatureIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cfg, cfgPath, err := devcontainer.Load(workspacePath, configPath)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	seen := make(map[string]bool)
+	var ids []string
+	if lf, _, err := lockfile.Load(cfgPath); err == nil && lf != nil {
+		for id := range lf.Features {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	for id := range cfg.Features {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids, cobra.ShellCompDirectiveNoFileComp
+}
+
+// configDirOf returns the directory containing devcontainer.json.
+func configDirOf(cfgPath string) string {
+	if idx := strings.LastIndexByte(cfgPath, '/'); idx >= 0 {
+		return cfgPath[:idx]
+	}
+	return "."
+}
+
+// registerDynamicCompletions wires the dynamic completion functions onto
+// commands and flags. Called from root.go's init() after the global flags
+// exist (file-level init() ordering would otherwise run this first and
+// silently fail to find the flags).
+func registerDynamicCompletions() {
+	_ = rootCmd.RegisterFlagCompletionFunc("context", completeDockerContexts)
+
+	// Shortcut completion for `dcx run <shortcut>`.
+	runCmd.ValidArgsFunction = completeShortcuts
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/griffithind/dcx/internal/common"
+	"github.com/griffithind/dcx/internal/devcontainer"
+	"github.com/griffithind/dcx/internal/ui"
+)
+
+var composeArgsExportEnv bool
+
+var composeArgsCmd = &cobra.Command{
+	Use:   "compose-args",
+	Short: "Print docker compose flags for this workspace's project",
+	Long: `Print the -p/-f flags that target the dcx-managed compose project,
+including the generated override, so raw docker compose commands work
+against it without reconstructing flags by hand:
+
+  docker compose $(dcx compose-args) logs -f db
+
+With --export-env, a COMPOSE_FILE/COMPOSE_PROJECT_NAME env file is
+written to the workspace runtime dir instead:
+
+  set -a; source $(dcx compose-args --export-env); set +a
+  docker compose ps`,
+	RunE: runComposeArgs,
+}
+
+func init() {
+	composeArgsCmd.Flags().BoolVar(&composeArgsExportEnv, "export-env", false, "write a COMPOSE_FILE/COMPOSE_PROJECT_NAME env file and print its path")
+	composeArgsCmd.GroupID = "info"
+	rootCmd.AddCommand(composeArgsCmd)
+}
+
+func runComposeArgs(cmd *cobra.Command, args []string) error {
+	cliCtx, err := NewCLIContext()
+	if err != nil {
+		return err
+	}
+	defer cliCtx.Close()
+
+	resolved, err := cliCtx.Service.Load(cliCtx.Ctx)
+	if err != nil {
+		return err
+	}
+
+	plan, ok := resolved.Plan.(*devcontainer.ComposePlan)
+	if !ok {
+		return fmt.Errorf("not a compose-based devcontainer")
+	}
+
+	files := append([]string{}, plan.Files...)
+	// Include the generated override when a previous up left one behind.
+	if dir := common.WorkspaceRuntimeDir(resolved.ID); dir != "" {
+		override := filepath.Join(dir, "override.yml")
+		if _, err := os.Stat(override); err == nil {
+			files = append(files, override)
+		}
+	}
+
+	if composeArgsExportEnv {
+		return writeComposeEnvFile(resolved.ID, plan.ProjectName, files)
+	}
+
+	parts := []string{"-p", plan.ProjectName}
+	for _, f := range files {
+		parts = append(parts, "-f", f)
+	}
+	fmt.Println(strings.Join(parts, " "))
+	return nil
+}
+
+// writeComposeEnvFile writes COMPOSE_FILE/COMPOSE_PROJECT_NAME into the
+// workspace runtime dir and prints the file's path.
+func writeComposeEnvFile(workspaceID, projectName string, files []string) error {
+	dir := common.WorkspaceRuntimeDir(workspaceID)
+	if dir == "" {
+		return fmt.Errorf("cannot resolve runtime directory")
+	}
+
+	content := fmt.Sprintf("COMPOSE_PROJECT_NAME=%s\nCOMPOSE_FILE=%s\n",
+		projectName, strings.Join(files, string(os.PathListSeparator)))
+
+	path := filepath.Join(dir, "compose.env")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		return err
+	}
+
+	fmt.Println(path)
+	ui.Printf("%s", ui.Dim("Usage: set -a; source "+path+"; set +a"))
+	return nil
+}
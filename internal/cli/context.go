@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/griffithind/dcx/internal/container"
+	dcxerrors "github.com/griffithind/dcx/internal/errors"
 	"github.com/griffithind/dcx/internal/service"
 	"github.com/griffithind/dcx/internal/state"
 )
@@ -33,7 +34,9 @@ func NewCLIContext() (*CLIContext, error) {
 	// Initialize Docker client (uses singleton)
 	docker, err := container.DockerClient()
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to Docker: %w", err)
+		return nil, dcxerrors.New(dcxerrors.CategoryDocker, dcxerrors.CodeDockerConnect, "cannot connect to Docker").
+			WithCause(err).
+			WithHint("Is the Docker daemon running? Run 'dcx doctor' to diagnose")
 	}
 
 	// Create service
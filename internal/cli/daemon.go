@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/griffithind/dcx/internal/container"
+	"github.com/griffithind/dcx/internal/daemon"
+)
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Run the dcx background daemon (dcxd)",
+	Long: `Run the optional dcx background daemon.
+
+The daemon keeps a cross-workspace state cache, performs idle detection
+(when idleTimeout is set in the user config), and serves queries on a
+unix socket. The CLI works fully without it; when it is running,
+commands like 'dcx list' answer from the cache instead of querying
+Docker.
+
+Runs in the foreground; use your service manager (launchd, systemd
+--user) to keep it running in the background.`,
+	RunE: runDaemon,
+}
+
+func init() {
+	daemonCmd.GroupID = "utilities"
+	rootCmd.AddCommand(daemonCmd)
+}
+
+func runDaemon(cmd *cobra.Command, args []string) error {
+	docker, err := container.DockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to Docker: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	return daemon.NewServer(docker).Run(ctx)
+}
@@ -7,9 +7,11 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/griffithind/dcx/internal/container"
 	"github.com/griffithind/dcx/internal/devcontainer"
+	"github.com/griffithind/dcx/internal/hostvm"
 	"github.com/griffithind/dcx/internal/selinux"
 	"github.com/griffithind/dcx/internal/ui"
 	"github.com/spf13/cobra"
@@ -79,6 +81,9 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 		if runtime.GOOS == "linux" {
 			systemResults = append(systemResults, checkSELinux())
 		}
+		if runtime.GOOS == "darwin" {
+			systemResults = append(systemResults, checkContainerVM(ctx))
+		}
 
 		for _, r := range systemResults {
 			if !r.OK {
@@ -114,7 +119,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 				checkResult = ui.CheckResultFail
 			}
 			ui.Println(ui.FormatCheck(checkResult, fmt.Sprintf("%s: %s", r.Name, r.Message)))
-			if !r.OK && r.Hint != "" {
+			if r.Hint != "" {
 				ui.Printf("    %s", ui.Dim(r.Hint))
 			}
 		}
@@ -134,7 +139,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 				checkResult = ui.CheckResultFail
 			}
 			ui.Println(ui.FormatCheck(checkResult, fmt.Sprintf("%s: %s", r.Name, r.Message)))
-			if !r.OK && r.Hint != "" {
+			if r.Hint != "" {
 				ui.Printf("    %s", ui.Dim(r.Hint))
 			}
 		}
@@ -234,6 +239,86 @@ func runConfigChecks(ctx context.Context, cfgPath string) []CheckResult {
 	// Validate features
 	results = append(results, validateFeatures(cfg)...)
 
+	// Validate GPU capability against hostRequirements.gpu
+	results = append(results, validateGPURequirements(resolved)...)
+
+	// Inner docker daemon health for docker-in-docker configs
+	results = append(results, validateInnerDocker(ctx, cfg)...)
+
+	return results
+}
+
+// validateInnerDocker probes the inner dockerd for configs using the
+// docker-in-docker feature, when the container is running — surfacing a
+// dead inner daemon here instead of as hook failures.
+func validateInnerDocker(ctx context.Context, cfg *devcontainer.DevContainerConfig) []CheckResult {
+	hasDinD := false
+	for ref := range cfg.Features {
+		if strings.Contains(ref, "docker-in-docker") {
+			hasDinD = true
+			break
+		}
+	}
+	if !hasDinD {
+		return nil
+	}
+
+	cliCtx, err := NewCLIContext()
+	if err != nil {
+		return nil
+	}
+	defer cliCtx.Close()
+
+	_, containerInfo, err := cliCtx.GetState()
+	if err != nil || containerInfo == nil || !containerInfo.Running {
+		return []CheckResult{{
+			Name:    "Inner Docker",
+			OK:      true,
+			Message: "docker-in-docker configured (container not running, skipped)",
+		}}
+	}
+
+	if err := cliCtx.Docker.ExecInContainer(ctx, containerInfo.Name, []string{"docker", "info"}); err != nil {
+		return []CheckResult{{
+			Name:    "Inner Docker",
+			OK:      false,
+			Message: "inner docker daemon is not responding",
+			Hint:    "Check 'dcx exec -- sudo cat /var/log/dockerd.log' or recreate with --recreate",
+		}}
+	}
+	return []CheckResult{{
+		Name:    "Inner Docker",
+		OK:      true,
+		Message: "inner docker daemon is healthy",
+	}}
+}
+
+// validateGPURequirements reports whether the host can satisfy
+// hostRequirements.gpu, naming the detected GPU stacks (nvidia, rocm, dri)
+// so capability mismatches are visible before `dcx up` fails.
+func validateGPURequirements(resolved *devcontainer.ResolvedDevContainer) []CheckResult {
+	var results []CheckResult
+
+	if resolved.GPURequirements == nil || !resolved.GPURequirements.Enabled {
+		return results
+	}
+
+	vendors := devcontainer.DetectGPUVendors()
+	if len(vendors) == 0 {
+		results = append(results, CheckResult{
+			Name:    "GPU",
+			OK:      false,
+			Message: "required by hostRequirements.gpu but no GPU stack detected",
+			Hint:    "Install the NVIDIA container toolkit, ROCm (/dev/kfd), or ensure /dev/dri exists",
+		})
+		return results
+	}
+
+	results = append(results, CheckResult{
+		Name:    "GPU",
+		OK:      true,
+		Message: fmt.Sprintf("detected: %s", strings.Join(vendors, ", ")),
+	})
 	return results
 }
 
@@ -412,6 +497,17 @@ func checkDocker(ctx context.Context) CheckResult {
 		}
 	}
 
+	// Windows containers are unsupported — flag it here before an up
+	// fails with the capability matrix.
+	if info, err := client.Info(ctx); err == nil && info.OSType == "windows" {
+		return CheckResult{
+			Name:    "Docker",
+			OK:      false,
+			Message: fmt.Sprintf("version %s (Windows containers)", version),
+			Hint:    "dcx requires Linux containers; switch the daemon mode (Docker Desktop: 'Switch to Linux containers')",
+		}
+	}
+
 	return CheckResult{
 		Name:    "Docker",
 		OK:      true,
@@ -450,6 +546,33 @@ func checkCompose() CheckResult {
 	}
 }
 
+// checkContainerVM identifies the VM flavor backing the daemon on macOS
+// (Docker Desktop, Colima, Lima, Rancher Desktop) and surfaces
+// flavor-specific guidance. All flavors are supported, so this check never
+// fails — it exists to explain quirks before they show up as mount or
+// networking errors.
+func checkContainerVM(ctx context.Context) CheckResult {
+	info := hostvm.Detect(ctx)
+
+	message := string(info.Kind)
+	if info.Kind == hostvm.KindUnknown {
+		message = "could not identify VM flavor"
+	}
+
+	hints := info.Guidance()
+	hint := ""
+	if len(hints) > 0 {
+		hint = strings.Join(hints, "; ")
+	}
+
+	return CheckResult{
+		Name:    "Container VM",
+		OK:      true,
+		Message: message,
+		Hint:    hint,
+	}
+}
+
 func checkSELinux() CheckResult {
 	mode, err := selinux.GetMode()
 	if err != nil {
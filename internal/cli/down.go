@@ -8,6 +8,7 @@ import (
 var (
 	removeVolumes bool
 	removeOrphans bool
+	downAll       bool
 )
 
 var downCmd = &cobra.Command{
@@ -23,9 +24,22 @@ managed by dcx. Optionally removes volumes and orphan containers.`,
 func init() {
 	downCmd.Flags().BoolVar(&removeVolumes, "volumes", false, "remove named volumes")
 	downCmd.Flags().BoolVar(&removeOrphans, "remove-orphans", false, "remove containers not defined in compose file")
+	downCmd.Flags().BoolVar(&downAll, "all", false, "tear down every workspace listed in dcx-workspaces.yaml")
 }
 
 func runDown(cmd *cobra.Command, args []string) error {
+	// Fleet mode: operate over the workspace manifest.
+	if downAll {
+		var extraArgs []string
+		if removeVolumes {
+			extraArgs = append(extraArgs, "--volumes")
+		}
+		if removeOrphans {
+			extraArgs = append(extraArgs, "--remove-orphans")
+		}
+		return runFleet("down", extraArgs)
+	}
+
 	cliCtx, err := NewCLIContext()
 	if err != nil {
 		return err
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var envFormat string
+
+var envCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Print shell-exportable connection variables",
+	Long: `Print shell-exportable variables describing the running
+devcontainer, for scripting:
+
+  eval "$(dcx env)"
+  docker exec -it "$DCX_CONTAINER_ID" sh
+
+Variables: DCX_CONTAINER_ID, DCX_CONTAINER_NAME, DCX_SSH_HOST,
+DCX_WORKSPACE_FOLDER, and DCX_PORT_<containerPort> for each published
+port. With --format docker, also emits a DCX_EXEC helper:
+
+  eval "$(dcx env --format docker)"
+  $DCX_EXEC make test`,
+	RunE: runEnv,
+}
+
+func init() {
+	envCmd.Flags().StringVar(&envFormat, "format", "sh", "output format: sh or docker")
+	envCmd.GroupID = "info"
+	rootCmd.AddCommand(envCmd)
+}
+
+func runEnv(cmd *cobra.Command, args []string) error {
+	cliCtx, err := NewCLIContext()
+	if err != nil {
+		return err
+	}
+	defer cliCtx.Close()
+
+	containerInfo, err := RequireRunningContainer(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	workspaceFolder := ""
+	if resolved, err := cliCtx.Service.Load(cliCtx.Ctx); err == nil {
+		workspaceFolder = resolved.WorkspaceFolder
+	}
+
+	exportVar := func(key, value string) {
+		fmt.Printf("export %s=%q\n", key, value)
+	}
+
+	exportVar("DCX_CONTAINER_ID", containerInfo.ID)
+	exportVar("DCX_CONTAINER_NAME", containerInfo.Name)
+	exportVar("DCX_SSH_HOST", cliCtx.Identifiers.SSHHost)
+	if workspaceFolder != "" {
+		exportVar("DCX_WORKSPACE_FOLDER", workspaceFolder)
+	}
+
+	// Published ports, one variable per container port.
+	if mappings, err := cliCtx.Docker.ContainerPortMappings(cliCtx.Ctx, containerInfo.Name); err == nil {
+		for _, m := range mappings {
+			if m.HostPort > 0 {
+				exportVar(fmt.Sprintf("DCX_PORT_%d", m.ContainerPort), fmt.Sprintf("%d", m.HostPort))
+			}
+		}
+	}
+
+	switch envFormat {
+	case "sh":
+	case "docker":
+		workdirFlag := ""
+		if workspaceFolder != "" {
+			workdirFlag = fmt.Sprintf(" -w %s", workspaceFolder)
+		}
+		exportVar("DCX_EXEC", fmt.Sprintf("docker exec -it%s %s", workdirFlag, containerInfo.Name))
+	default:
+		return fmt.Errorf("unknown format %q (supported: sh, docker)", envFormat)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/griffithind/dcx/internal/events"
+	"github.com/griffithind/dcx/internal/ui"
+)
+
+var eventsFollow bool
+
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Show dcx lifecycle events",
+	Long: `Show lifecycle events emitted by dcx (container created, hooks
+finished, ports forwarded, state changed).
+
+Events are appended to ~/.dcx/events.jsonl; --follow tails the log.
+Additional sinks (webhook URL, exec'd script) can be configured under
+'events:' in the user config.`,
+	RunE: runEvents,
+}
+
+func init() {
+	eventsCmd.Flags().BoolVarP(&eventsFollow, "follow", "f", false, "follow new events")
+	eventsCmd.GroupID = "info"
+	rootCmd.AddCommand(eventsCmd)
+}
+
+func runEvents(cmd *cobra.Command, args []string) error {
+	path := events.LogPath()
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			ui.Println("No events recorded yet.")
+			return nil
+		}
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			fmt.Print(line)
+		}
+		if err == io.EOF {
+			if !eventsFollow {
+				return nil
+			}
+			time.Sleep(time.Second)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -4,11 +4,15 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/griffithind/dcx/internal/container"
 	"github.com/griffithind/dcx/internal/devcontainer"
 	sshexec "github.com/griffithind/dcx/internal/ssh/exec"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
+var execService string
+
 var execCmd = &cobra.Command{
 	Use:   "exec -- <command> [args...]",
 	Short: "Run a command in the container",
@@ -44,6 +48,30 @@ func runExec(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Secondary compose services have no dcx-agent, so exec goes through
+	// plain docker exec instead of the SSH path.
+	if execService != "" && execService != containerInfo.PrimaryService {
+		target, err := resolveServiceContainer(cliCtx, containerInfo, execService)
+		if err != nil {
+			return err
+		}
+		exitCode, err := container.Exec(cliCtx.Ctx, container.ExecConfig{
+			ContainerID: target.ID,
+			Cmd:         args,
+			Stdin:       os.Stdin,
+			Stdout:      os.Stdout,
+			Stderr:      os.Stderr,
+			TTY:         term.IsTerminal(int(os.Stdin.Fd())),
+		})
+		if err != nil {
+			return fmt.Errorf("exec failed: %w", err)
+		}
+		if exitCode != 0 {
+			os.Exit(exitCode)
+		}
+		return nil
+	}
+
 	// Load config
 	cfg, _, _ := devcontainer.Load(cliCtx.WorkspacePath(), cliCtx.ConfigPath())
 
@@ -66,6 +94,8 @@ func runExec(cmd *cobra.Command, args []string) error {
 }
 
 func init() {
+	execCmd.Flags().StringVar(&execService, "service", "", "target a specific compose service")
+	_ = execCmd.RegisterFlagCompletionFunc("service", completeComposeServices)
 	execCmd.GroupID = "execution"
 	rootCmd.AddCommand(execCmd)
 }
@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/griffithind/dcx/internal/container"
+	"github.com/griffithind/dcx/internal/devcontainer"
+	"github.com/griffithind/dcx/internal/ui"
+)
+
+var explainCmd = &cobra.Command{
+	Use:   "explain <key>",
+	Short: "Show where an effective setting comes from",
+	Long: `Show the merge chain behind an effective configuration value.
+
+Configuration merges image metadata layers first (in order), then the
+local devcontainer.json on top. 'dcx explain remoteUser' lists every
+source that sets the key and which one wins — resolving "why is my
+user root?" without reading the merge code.
+
+Examples:
+  dcx explain remoteUser
+  dcx explain containerEnv
+  dcx explain overrideCommand`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExplain,
+}
+
+func init() {
+	explainCmd.GroupID = "info"
+	rootCmd.AddCommand(explainCmd)
+}
+
+// explainSource is one layer contributing (or not) to a setting.
+type explainSource struct {
+	Name  string
+	Value interface{}
+	Set   bool
+}
+
+func runExplain(cmd *cobra.Command, args []string) error {
+	key := args[0]
+
+	cliCtx, err := NewCLIContext()
+	if err != nil {
+		return err
+	}
+	defer cliCtx.Close()
+
+	cfg, _, err := devcontainer.Load(cliCtx.WorkspacePath(), cliCtx.ConfigPath())
+	if err != nil {
+		return err
+	}
+
+	sources := []explainSource{builtinDefaultFor(key, cfg, cliCtx.WorkspacePath())}
+
+	// Image metadata layers, in merge order (earlier layers first).
+	if cfg.Image != "" {
+		if labels, err := container.MustDocker().GetImageLabels(cliCtx.Ctx, cfg.Image); err == nil {
+			if label := labels[devcontainer.DevcontainerMetadataLabel]; label != "" {
+				if layers, err := devcontainer.ParseImageMetadata(label); err == nil {
+					for i := range layers {
+						value, set := configValue(&layers[i], key)
+						sources = append(sources, explainSource{
+							Name:  fmt.Sprintf("image metadata layer %d", i+1),
+							Value: value,
+							Set:   set,
+						})
+					}
+				}
+			}
+		}
+	}
+
+	// Features contributing to mergeable keys.
+	if resolved, err := cliCtx.Service.Load(cliCtx.Ctx); err == nil {
+		for _, f := range resolved.Features {
+			if f.Metadata == nil {
+				continue
+			}
+			// Features only contribute a handful of mergeable keys;
+			// containerEnv is the one users chase most often.
+			if key == "containerEnv" && len(f.Metadata.ContainerEnv) > 0 {
+				sources = append(sources, explainSource{
+					Name:  "feature " + f.ID,
+					Value: f.Metadata.ContainerEnv,
+					Set:   true,
+				})
+			}
+		}
+	}
+
+	// Local config wins last.
+	value, set := configValue(cfg, key)
+	sources = append(sources, explainSource{Name: "devcontainer.json", Value: value, Set: set})
+
+	ui.Println(ui.Bold("Merge chain for " + key + " (later sources win)"))
+	var effective *explainSource
+	for i := range sources {
+		src := sources[i]
+		if !src.Set {
+			ui.Printf("  %s: %s", src.Name, ui.Dim("(not set)"))
+			continue
+		}
+		ui.Printf("  %s: %s", src.Name, renderValue(src.Value))
+		effective = &sources[i]
+	}
+
+	ui.Println("")
+	if effective != nil {
+		ui.Printf("%s", ui.FormatLabel("Effective", fmt.Sprintf("%s (from %s)", renderValue(effective.Value), effective.Name)))
+	} else {
+		ui.Printf("%s", ui.FormatLabel("Effective", ui.Dim("unset")))
+	}
+	return nil
+}
+
+// configValue extracts a top-level key from a config via its JSON shape.
+func configValue(cfg *devcontainer.DevContainerConfig, key string) (interface{}, bool) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, false
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, false
+	}
+	value, ok := m[key]
+	return value, ok
+}
+
+// builtinDefaultFor reports dcx's built-in default for the keys that have
+// one; everything else starts unset.
+func builtinDefaultFor(key string, cfg *devcontainer.DevContainerConfig, workspacePath string) explainSource {
+	src := explainSource{Name: "dcx default"}
+	switch key {
+	case "workspaceFolder":
+		src.Value = devcontainer.DetermineContainerWorkspaceFolder(&devcontainer.DevContainerConfig{DockerComposeFile: cfg.DockerComposeFile}, workspacePath)
+		src.Set = true
+	case "overrideCommand":
+		src.Value = !cfg.IsComposePlan()
+		src.Set = true
+	case "containerUser", "remoteUser":
+		src.Value = "root"
+		src.Set = true
+	}
+	return src
+}
+
+// renderValue renders a value compactly for the chain listing.
+func renderValue(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
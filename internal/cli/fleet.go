@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/griffithind/dcx/internal/ui"
+)
+
+// Fleet operation over a workspace manifest: `dcx up --all` /
+// `dcx down --all` read dcx-workspaces.yaml and run the operation across
+// every listed workspace with bounded parallelism, aggregating results.
+// Each workspace runs as a dcx subprocess for full isolation (locks,
+// state, and flag globals are per-invocation).
+
+// fleetManifestName is the manifest file looked up in the workspace root.
+const fleetManifestName = "dcx-workspaces.yaml"
+
+// fleetManifest is the parsed dcx-workspaces.yaml.
+type fleetManifest struct {
+	// Workspaces lists workspace folders, relative to the manifest.
+	Workspaces []string `yaml:"workspaces"`
+
+	// Parallelism bounds concurrent operations (default 4).
+	Parallelism int `yaml:"parallelism,omitempty"`
+}
+
+// fleetResult is one workspace's outcome.
+type fleetResult struct {
+	Workspace string
+	Duration  time.Duration
+	Err       error
+	Output    string
+}
+
+// loadFleetManifest reads the manifest from the workspace root.
+func loadFleetManifest() (*fleetManifest, error) {
+	path := filepath.Join(workspacePath, fleetManifestName)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("no %s found in %s (required for --all)", fleetManifestName, workspacePath)
+	}
+
+	var manifest fleetManifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if len(manifest.Workspaces) == 0 {
+		return nil, fmt.Errorf("%s lists no workspaces", path)
+	}
+	if manifest.Parallelism <= 0 {
+		manifest.Parallelism = 4
+	}
+	return &manifest, nil
+}
+
+// runFleet executes one dcx operation across every manifest workspace
+// with bounded parallelism and prints an aggregated result table.
+// Returns an error when any workspace failed.
+func runFleet(operation string, extraArgs []string) error {
+	manifest, err := loadFleetManifest()
+	if err != nil {
+		return err
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	ui.Printf("Running %s across %d workspace(s) (parallelism %d)", operation, len(manifest.Workspaces), manifest.Parallelism)
+
+	results := make([]fleetResult, len(manifest.Workspaces))
+	sem := make(chan struct{}, manifest.Parallelism)
+	var wg sync.WaitGroup
+
+	for i, workspace := range manifest.Workspaces {
+		wg.Add(1)
+		go func(i int, workspace string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			target := workspace
+			if !filepath.IsAbs(target) {
+				target = filepath.Join(workspacePath, workspace)
+			}
+
+			args := append([]string{operation, "--workspace", target}, extraArgs...)
+			cmd := exec.Command(self, args...)
+			var output bytes.Buffer
+			cmd.Stdout = &output
+			cmd.Stderr = &output
+
+			start := time.Now()
+			err := cmd.Run()
+			results[i] = fleetResult{
+				Workspace: workspace,
+				Duration:  time.Since(start),
+				Err:       err,
+				Output:    output.String(),
+			}
+		}(i, workspace)
+	}
+	wg.Wait()
+
+	failures := 0
+	headers := []string{"Workspace", "Result", "Duration"}
+	var rows [][]string
+	for _, result := range results {
+		if result.Err != nil {
+			failures++
+		}
+		rows = append(rows, []string{
+			result.Workspace,
+			ui.OutcomeColor(result.Err == nil),
+			result.Duration.Round(time.Second).String(),
+		})
+	}
+	if err := ui.RenderTable(headers, rows); err != nil {
+		return err
+	}
+
+	// Failed workspaces get their captured output, so the error isn't
+	// buried in interleaved parallel logs.
+	for _, result := range results {
+		if result.Err != nil {
+			ui.Println("")
+			ui.Error("%s failed for %s:", operation, result.Workspace)
+			ui.Println(result.Output)
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%s failed for %d of %d workspace(s)", operation, failures, len(results))
+	}
+	ui.Success("%s completed for all %d workspace(s)", operation, len(results))
+	return nil
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFleetManifest(t *testing.T) {
+	dir := t.TempDir()
+	origWorkspace := workspacePath
+	workspacePath = dir
+	defer func() { workspacePath = origWorkspace }()
+
+	t.Run("missing manifest", func(t *testing.T) {
+		_, err := loadFleetManifest()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), fleetManifestName)
+	})
+
+	t.Run("parses workspaces and parallelism", func(t *testing.T) {
+		content := `
+workspaces:
+  - services/api
+  - services/web
+parallelism: 2
+`
+		require.NoError(t, os.WriteFile(filepath.Join(dir, fleetManifestName), []byte(content), 0644))
+
+		manifest, err := loadFleetManifest()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"services/api", "services/web"}, manifest.Workspaces)
+		assert.Equal(t, 2, manifest.Parallelism)
+	})
+
+	t.Run("defaults parallelism", func(t *testing.T) {
+		content := "workspaces:\n  - onlyone\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, fleetManifestName), []byte(content), 0644))
+
+		manifest, err := loadFleetManifest()
+		require.NoError(t, err)
+		assert.Equal(t, 4, manifest.Parallelism)
+	})
+
+	t.Run("empty workspace list", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, fleetManifestName), []byte("parallelism: 2\n"), 0644))
+
+		_, err := loadFleetManifest()
+		assert.Error(t, err)
+	})
+}
+
+func TestFleetFlags(t *testing.T) {
+	assert.NotNil(t, upCmd.Flags().Lookup("all"), "up --all should exist")
+	assert.NotNil(t, downCmd.Flags().Lookup("all"), "down --all should exist")
+}
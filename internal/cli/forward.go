@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/griffithind/dcx/internal/devcontainer"
+	sshexec "github.com/griffithind/dcx/internal/ssh/exec"
+	"github.com/griffithind/dcx/internal/ui"
+)
+
+var forwardCmd = &cobra.Command{
+	Use:   "forward",
+	Short: "Hold the configured reverse port forwards open",
+	Long: `Open the reverse port forwards declared under
+customizations.dcx.reverseForwardPorts and keep them alive until
+interrupted. Each entry exposes a host-side service as a loopback
+listener inside the container:
+
+  "5432"        host localhost:5432 -> container localhost:5432
+  "8080:5432"   host localhost:5432 -> container localhost:8080
+
+dcx ssh and dcx shell sessions carry the same forwards automatically
+for their lifetime; this command is for keeping them open without a
+shell.`,
+	RunE: runForward,
+}
+
+func init() {
+	forwardCmd.GroupID = "execution"
+	rootCmd.AddCommand(forwardCmd)
+}
+
+// reverseForwardSpecs converts reverseForwardPorts entries into
+// ForwardSpecs. "5432" binds container localhost:5432 to host
+// localhost:5432; "8080:5432" binds container localhost:8080 to host
+// localhost:5432.
+func reverseForwardSpecs(cfg *devcontainer.DevContainerConfig) ([]sshexec.ForwardSpec, error) {
+	dcx := devcontainer.GetDcxCustomizations(cfg)
+	if dcx == nil || len(dcx.ReverseForwardPorts) == 0 {
+		return nil, nil
+	}
+
+	var specs []sshexec.ForwardSpec
+	for _, entry := range dcx.ReverseForwardPorts {
+		containerPart, hostPart, found := strings.Cut(entry, ":")
+		if !found {
+			hostPart = containerPart
+		}
+		containerPort, err1 := strconv.Atoi(containerPart)
+		hostPort, err2 := strconv.Atoi(hostPart)
+		if err1 != nil || err2 != nil || containerPort <= 0 || hostPort <= 0 {
+			return nil, fmt.Errorf("invalid reverseForwardPorts entry %q", entry)
+		}
+		specs = append(specs, sshexec.ForwardSpec{
+			Reverse:    true,
+			BindAddr:   fmt.Sprintf("127.0.0.1:%d", containerPort),
+			TargetAddr: fmt.Sprintf("127.0.0.1:%d", hostPort),
+		})
+	}
+	return specs, nil
+}
+
+func runForward(cmd *cobra.Command, args []string) error {
+	cliCtx, err := NewCLIContext()
+	if err != nil {
+		return err
+	}
+	defer cliCtx.Close()
+
+	containerInfo, err := RequireRunningContainer(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	cfg, _, err := devcontainer.Load(cliCtx.WorkspacePath(), cliCtx.ConfigPath())
+	if err != nil {
+		return err
+	}
+
+	specs, err := reverseForwardSpecs(cfg)
+	if err != nil {
+		return err
+	}
+	if len(specs) == 0 {
+		return fmt.Errorf("no reverseForwardPorts configured under customizations.dcx")
+	}
+
+	for _, spec := range specs {
+		ui.Printf("Reverse forward: container %s -> host %s", spec.BindAddr, spec.TargetAddr)
+	}
+
+	// Hold the forwards open with a sleeping session; Ctrl-C tears the
+	// connection (and every listener) down.
+	_, err = sshexec.ExecInContainer(cliCtx.Ctx, sshexec.ContainerExecOptions{
+		ContainerName: containerInfo.Name,
+		WorkspaceID:   containerInfo.Labels.WorkspaceID,
+		Config:        cfg,
+		WorkspacePath: cliCtx.WorkspacePath(),
+		Command:       []string{"sleep", "infinity"},
+		Forwards:      specs,
+	})
+	return err
+}
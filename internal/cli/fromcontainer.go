@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/griffithind/dcx/internal/ui"
+)
+
+var configFromContainerCmd = &cobra.Command{
+	Use:   "from-container <name>",
+	Short: "Generate a devcontainer.json from an existing container",
+	Long: `Inspect a hand-crafted container and emit a starting
+devcontainer.json on stdout — image, bind mounts, environment, published
+ports, user, and working directory — lowering migration cost for teams
+with ad-hoc docker run scripts.
+
+The output is a starting point: review it (especially environment
+variables that came from the image rather than the run command) before
+committing.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigFromContainer,
+}
+
+func init() {
+	configCmd.AddCommand(configFromContainerCmd)
+}
+
+// inspectedContainer is the subset of docker inspect output consumed.
+type inspectedContainer struct {
+	Config struct {
+		Image      string   `json:"Image"`
+		Env        []string `json:"Env"`
+		User       string   `json:"User"`
+		WorkingDir string   `json:"WorkingDir"`
+	} `json:"Config"`
+	HostConfig struct {
+		Binds      []string `json:"Binds"`
+		Privileged bool     `json:"Privileged"`
+		CapAdd     []string `json:"CapAdd"`
+	} `json:"HostConfig"`
+	NetworkSettings struct {
+		Ports map[string][]struct {
+			HostPort string `json:"HostPort"`
+		} `json:"Ports"`
+	} `json:"NetworkSettings"`
+}
+
+func runConfigFromContainer(cmd *cobra.Command, args []string) error {
+	output, err := exec.CommandContext(cmd.Context(), "docker", "inspect", args[0]).Output()
+	if err != nil {
+		return fmt.Errorf("failed to inspect container %q: %w", args[0], err)
+	}
+
+	var inspected []inspectedContainer
+	if err := json.Unmarshal(output, &inspected); err != nil {
+		return fmt.Errorf("failed to parse inspect output: %w", err)
+	}
+	if len(inspected) == 0 {
+		return fmt.Errorf("container %q not found", args[0])
+	}
+	c := inspected[0]
+
+	cfg := map[string]interface{}{
+		"name":  args[0],
+		"image": c.Config.Image,
+	}
+
+	if c.Config.User != "" {
+		cfg["remoteUser"] = c.Config.User
+	}
+	if c.Config.WorkingDir != "" && c.Config.WorkingDir != "/" {
+		cfg["workspaceFolder"] = c.Config.WorkingDir
+	}
+
+	// Environment: skip PATH and the standard image-provided noise.
+	env := make(map[string]string)
+	for _, kv := range c.Config.Env {
+		key, value, found := strings.Cut(kv, "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "PATH", "HOSTNAME", "HOME", "TERM", "LANG", "LC_ALL":
+			continue
+		}
+		env[key] = value
+	}
+	if len(env) > 0 {
+		cfg["containerEnv"] = env
+	}
+
+	// Bind mounts (named volumes are environment-specific and omitted).
+	var mounts []string
+	for _, bind := range c.HostConfig.Binds {
+		parts := strings.SplitN(bind, ":", 3)
+		if len(parts) < 2 || !strings.HasPrefix(parts[0], "/") {
+			continue
+		}
+		mounts = append(mounts, fmt.Sprintf("source=%s,target=%s,type=bind", parts[0], parts[1]))
+	}
+	sort.Strings(mounts)
+	if len(mounts) > 0 {
+		cfg["mounts"] = mounts
+	}
+
+	// Published ports become forwardPorts.
+	var ports []interface{}
+	for spec, bindings := range c.NetworkSettings.Ports {
+		portStr, _, _ := strings.Cut(spec, "/")
+		containerPort, err := strconv.Atoi(portStr)
+		if err != nil || len(bindings) == 0 {
+			continue
+		}
+		hostPort, _ := strconv.Atoi(bindings[0].HostPort)
+		if hostPort == 0 || hostPort == containerPort {
+			ports = append(ports, containerPort)
+		} else {
+			ports = append(ports, fmt.Sprintf("%d:%d", hostPort, containerPort))
+		}
+	}
+	if len(ports) > 0 {
+		sort.Slice(ports, func(i, j int) bool {
+			return fmt.Sprint(ports[i]) < fmt.Sprint(ports[j])
+		})
+		cfg["forwardPorts"] = ports
+	}
+
+	var runArgs []string
+	if c.HostConfig.Privileged {
+		runArgs = append(runArgs, "--privileged")
+	}
+	for _, cap := range c.HostConfig.CapAdd {
+		runArgs = append(runArgs, "--cap-add="+cap)
+	}
+	if len(runArgs) > 0 {
+		cfg["runArgs"] = runArgs
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "\t")
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stdout.Write(append(data, '\n')); err != nil {
+		return err
+	}
+
+	ui.Printf("%s", ui.Dim("Review before use — image-baked env vars may not belong in containerEnv"))
+	return nil
+}
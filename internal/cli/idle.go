@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/griffithind/dcx/internal/config"
+	"github.com/griffithind/dcx/internal/container"
+	"github.com/griffithind/dcx/internal/idle"
+)
+
+var (
+	idleTimeout  time.Duration
+	idleOnce     bool
+	idleInterval time.Duration
+)
+
+var idleWatchCmd = &cobra.Command{
+	Use:   "idle-watch",
+	Short: "Stop dcx containers that have been idle too long",
+	Long: `Watch dcx-managed containers and stop those idle beyond a timeout.
+
+The dcx-agent records the last SSH session activity inside each
+container; this watcher polls that timestamp and stops containers whose
+environments have been idle longer than the timeout, saving battery and
+RAM. Containers whose devcontainer.json sets shutdownAction to "none"
+are never stopped.
+
+The default timeout comes from idleTimeout in the user config. Use
+--once for a single sweep (e.g. from cron) instead of watching.`,
+	RunE: runIdleWatch,
+}
+
+func init() {
+	idleWatchCmd.Flags().DurationVar(&idleTimeout, "timeout", 0, "stop containers idle longer than this (e.g. 30m)")
+	idleWatchCmd.Flags().DurationVar(&idleInterval, "interval", time.Minute, "poll interval")
+	idleWatchCmd.Flags().BoolVar(&idleOnce, "once", false, "perform a single sweep and exit")
+	idleWatchCmd.GroupID = "utilities"
+	rootCmd.AddCommand(idleWatchCmd)
+}
+
+func runIdleWatch(cmd *cobra.Command, args []string) error {
+	if idleTimeout == 0 {
+		if userCfg, err := config.User(); err == nil && userCfg.IdleTimeout != "" {
+			if d, err := time.ParseDuration(userCfg.IdleTimeout); err == nil {
+				idleTimeout = d
+			}
+		}
+	}
+	if idleTimeout == 0 {
+		return fmt.Errorf("no timeout configured; pass --timeout or set idleTimeout in the user config")
+	}
+
+	docker, err := container.DockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to Docker: %w", err)
+	}
+
+	ctx := context.Background()
+	for {
+		idle.Sweep(ctx, docker, idleTimeout)
+		if idleOnce {
+			return nil
+		}
+		time.Sleep(idleInterval)
+	}
+}
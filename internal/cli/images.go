@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/griffithind/dcx/internal/container"
+	"github.com/griffithind/dcx/internal/ui"
+)
+
+var imagesCmd = &cobra.Command{
+	Use:   "images",
+	Short: "List dcx-owned images",
+	Long: `List every image dcx owns — per-workspace build images and UID
+variants, shared feature layers, snapshots, and toolbox overlays — with
+age and size. Superseded build images beyond the retention count are
+pruned automatically after rebuilds; 'dcx clean' removes the rest.`,
+	RunE: runImages,
+}
+
+func init() {
+	imagesCmd.GroupID = "info"
+	rootCmd.AddCommand(imagesCmd)
+}
+
+func runImages(cmd *cobra.Command, args []string) error {
+	docker, err := container.DockerClient()
+	if err != nil {
+		return err
+	}
+
+	images, err := docker.ListDcxImages(cmd.Context())
+	if err != nil {
+		return err
+	}
+	if len(images) == 0 {
+		ui.Println("No dcx-owned images found.")
+		return nil
+	}
+
+	headers := []string{"Image", "Workspace", "Age", "Size"}
+	var rows [][]string
+	for _, img := range images {
+		workspace := img.WorkspaceID
+		if workspace == "" {
+			workspace = ui.Dim("(shared)")
+		}
+		rows = append(rows, []string{
+			img.Repository + ":" + img.Tag,
+			workspace,
+			img.CreatedSince,
+			img.Size,
+		})
+	}
+	return ui.RenderTable(headers, rows)
+}
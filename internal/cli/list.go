@@ -3,17 +3,26 @@ package cli
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/griffithind/dcx/internal/container"
+	"github.com/griffithind/dcx/internal/daemon"
+	"github.com/griffithind/dcx/internal/devcontainer"
+	"github.com/griffithind/dcx/internal/service"
 	"github.com/griffithind/dcx/internal/state"
 	"github.com/griffithind/dcx/internal/ui"
 	"github.com/spf13/cobra"
 )
 
-var listShowAll bool
+var (
+	listShowAll  bool
+	listForeign  bool
+	listDiscover bool
+)
 
 var listCmd = &cobra.Command{
 	Use:     "list",
@@ -53,6 +62,15 @@ type ContainerItem struct {
 func runListEnvironments(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 
+	if listDiscover {
+		return runListDiscover(ctx)
+	}
+
+	// Fast path: answer from the daemon's cache when dcxd is running.
+	if resp, err := daemon.Query("list"); err == nil && resp.OK {
+		return renderDaemonList(resp.Environments)
+	}
+
 	// Initialize Docker client (uses singleton)
 	docker, err := container.DockerClient()
 	if err != nil {
@@ -127,6 +145,9 @@ func runListEnvironments(cmd *cobra.Command, args []string) error {
 		if !listShowAll {
 			ui.Println(ui.Dim("Use --all to include stopped environments."))
 		}
+		if listForeign {
+			return renderForeignContainers(ctx, docker)
+		}
 		return nil
 	}
 
@@ -163,6 +184,142 @@ func runListEnvironments(cmd *cobra.Command, args []string) error {
 		})
 	}
 
+	if err := ui.RenderTable(headers, rows); err != nil {
+		return err
+	}
+
+	if listForeign {
+		return renderForeignContainers(ctx, docker)
+	}
+	return nil
+}
+
+// discoverMaxDepth bounds the monorepo scan.
+const discoverMaxDepth = 4
+
+// runListDiscover scans the workspace tree for devcontainer configs and
+// shows each with its container state, so every service in a monorepo is
+// visible with its own environment.
+func runListDiscover(ctx context.Context) error {
+	docker, err := container.DockerClient()
+	if err != nil {
+		return fmt.Errorf("failed to connect to Docker: %w", err)
+	}
+	stateMgr := state.NewStateManager(docker)
+
+	var rows [][]string
+	root := workspacePath
+	_ = filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		name := d.Name()
+		if name == ".git" || name == "node_modules" || name == "vendor" {
+			return filepath.SkipDir
+		}
+		if rel, err := filepath.Rel(root, path); err == nil && strings.Count(rel, string(filepath.Separator)) >= discoverMaxDepth {
+			return filepath.SkipDir
+		}
+
+		cfg, _, err := devcontainer.Load(path, "")
+		if err != nil {
+			return nil
+		}
+		// Only count dirs that actually carry the config (Load would
+		// also succeed for any path given a --config override).
+		if findConfigPath(path) == "" {
+			return nil
+		}
+
+		ids := devcontainer.ComputeDevContainerID(path, cfg)
+		containerState, _, _ := stateMgr.GetStateWithProject(ctx, ids.ProjectName, ids.ID)
+
+		display, _ := filepath.Rel(root, path)
+		if display == "." {
+			display = path
+		}
+		rows = append(rows, []string{
+			ids.ProjectName,
+			formatListState(string(containerState)),
+			ui.Code(display),
+		})
+
+		// Nested configs under an already-found one are separate
+		// workspaces; keep walking.
+		return nil
+	})
+
+	if len(rows) == 0 {
+		ui.Println("No devcontainer configurations found under " + root)
+		return nil
+	}
+	return ui.RenderTable([]string{"Name", "State", "Path"}, rows)
+}
+
+// renderForeignContainers lists containers created by VS Code Remote
+// Containers / devcontainers-cli (devcontainer.local_folder label),
+// adoptable via `dcx adopt --from vscode`.
+func renderForeignContainers(ctx context.Context, docker *container.Docker) error {
+	foreign, err := docker.ListContainersWithLabels(ctx, map[string]string{})
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, c := range foreign {
+		folder, ok := c.Labels[service.VSCodeLocalFolderLabel]
+		if !ok {
+			continue
+		}
+		if c.Labels[state.LabelManaged] == "true" {
+			continue
+		}
+		rows = append(rows, []string{c.Name, formatListState(c.State), ui.Code(folder)})
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	ui.Println("")
+	ui.Println(ui.Bold("Foreign devcontainers (adopt with 'dcx adopt --from vscode')"))
+	return ui.RenderTable([]string{"Name", "State", "Workspace"}, rows)
+}
+
+// renderDaemonList renders the daemon's cached environment list in the
+// same shape as the direct Docker path.
+func renderDaemonList(envs []daemon.Environment) error {
+	var shown []daemon.Environment
+	for _, env := range envs {
+		if listShowAll || env.State == "running" {
+			shown = append(shown, env)
+		}
+	}
+	if len(shown) == 0 {
+		ui.Println("No dcx-managed environments found.")
+		if !listShowAll {
+			ui.Println(ui.Dim("Use --all to include stopped environments."))
+		}
+		return nil
+	}
+
+	headers := []string{"Name", "State", "Containers", "Workspace"}
+	var rows [][]string
+	for _, env := range shown {
+		identifier := env.WorkspaceID
+		if env.WorkspaceName != "" {
+			identifier = env.WorkspaceName
+		}
+		workspace := env.WorkspacePath
+		if len(workspace) > 50 {
+			workspace = "..." + workspace[len(workspace)-47:]
+		}
+		rows = append(rows, []string{
+			identifier,
+			formatListState(env.State),
+			env.ContainerName + "*",
+			ui.Code(workspace),
+		})
+	}
 	return ui.RenderTable(headers, rows)
 }
 
@@ -173,6 +330,8 @@ func formatListState(s string) string {
 
 func init() {
 	listCmd.Flags().BoolVar(&listShowAll, "all", false, "show all environments (including stopped)")
+	listCmd.Flags().BoolVar(&listForeign, "foreign", false, "also list devcontainers created by VS Code / devcontainers-cli")
+	listCmd.Flags().BoolVar(&listDiscover, "discover", false, "scan the workspace tree for devcontainer configs and show their states")
 	listCmd.GroupID = "info"
 	rootCmd.AddCommand(listCmd)
 }
@@ -1,11 +1,14 @@
 package cli
 
 import (
+	"bufio"
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/griffithind/dcx/internal/container"
+	"github.com/griffithind/dcx/internal/logging"
 	"github.com/spf13/cobra"
 )
 
@@ -13,6 +16,8 @@ var (
 	logsFollow     bool
 	logsTail       string
 	logsTimestamps bool
+	logsService    string
+	logsSelf       bool
 )
 
 var logsCmd = &cobra.Command{
@@ -32,6 +37,11 @@ Examples:
 }
 
 func runLogs(cmd *cobra.Command, args []string) error {
+	// dcx's own log file instead of container logs
+	if logsSelf {
+		return showSelfLogs()
+	}
+
 	// Initialize CLI context
 	cliCtx, err := NewCLIContext()
 	if err != nil {
@@ -45,6 +55,12 @@ func runLogs(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Target a specific compose service when requested
+	containerInfo, err = resolveServiceContainer(cliCtx, containerInfo, logsService)
+	if err != nil {
+		return err
+	}
+
 	// Get logs from container
 	opts := container.LogsOptions{
 		Follow:     logsFollow,
@@ -67,10 +83,45 @@ func runLogs(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// showSelfLogs prints dcx's own structured log, optionally following it.
+func showSelfLogs() error {
+	path := logging.Path()
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			fmt.Println("No dcx log recorded yet.")
+			return nil
+		}
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			fmt.Print(line)
+		}
+		if err == io.EOF {
+			if !logsFollow {
+				return nil
+			}
+			time.Sleep(time.Second)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
 func init() {
 	logsCmd.Flags().BoolVarP(&logsFollow, "follow", "f", false, "follow log output")
 	logsCmd.Flags().StringVar(&logsTail, "tail", "100", "number of lines to show from the end (use 'all' for all logs)")
 	logsCmd.Flags().BoolVarP(&logsTimestamps, "timestamps", "t", false, "show timestamps")
+	logsCmd.Flags().StringVar(&logsService, "service", "", "target a specific compose service")
+	logsCmd.Flags().BoolVar(&logsSelf, "self", false, "show dcx's own log instead of container logs")
+	_ = logsCmd.RegisterFlagCompletionFunc("service", completeComposeServices)
 	logsCmd.GroupID = "info"
 	rootCmd.AddCommand(logsCmd)
 }
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tidwall/jsonc"
+
+	"github.com/griffithind/dcx/internal/ui"
+)
+
+// persistOverrides merges one-off --mount/--env/--port values into
+// devcontainer.json. The file is rewritten as plain indented JSON, so
+// JSONC comments are lost — warned about when present.
+func persistOverrides(mounts, env, ports []string) error {
+	cfgPath := configPath
+	if cfgPath == "" {
+		cfgPath = findConfigPath(workspacePath)
+	}
+	if cfgPath == "" {
+		return fmt.Errorf("no devcontainer.json found in %s", workspacePath)
+	}
+
+	data, err := os.ReadFile(cfgPath)
+	if err != nil {
+		return err
+	}
+	if strings.Contains(string(data), "//") || strings.Contains(string(data), "/*") {
+		ui.Warning("devcontainer.json comments will be dropped by --save")
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(jsonc.ToJSON(data), &m); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", cfgPath, err)
+	}
+
+	appendUnique := func(key string, values []string) {
+		if len(values) == 0 {
+			return
+		}
+		existing, _ := m[key].([]interface{})
+		seen := make(map[string]bool, len(existing))
+		for _, v := range existing {
+			if s, ok := v.(string); ok {
+				seen[s] = true
+			}
+		}
+		for _, v := range values {
+			if !seen[v] {
+				existing = append(existing, v)
+				seen[v] = true
+			}
+		}
+		m[key] = existing
+	}
+
+	appendUnique("mounts", mounts)
+	appendUnique("forwardPorts", ports)
+
+	if len(env) > 0 {
+		containerEnv, _ := m["containerEnv"].(map[string]interface{})
+		if containerEnv == nil {
+			containerEnv = make(map[string]interface{})
+		}
+		for _, kv := range env {
+			if key, value, found := strings.Cut(kv, "="); found {
+				containerEnv[key] = value
+			}
+		}
+		m["containerEnv"] = containerEnv
+	}
+
+	out, err := json.MarshalIndent(m, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(cfgPath, append(out, '\n'), 0644)
+}
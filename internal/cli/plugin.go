@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/griffithind/dcx/internal/container"
+	"github.com/griffithind/dcx/internal/service"
+	"github.com/griffithind/dcx/internal/state"
+)
+
+// External subcommand plugins, git/kubectl style: an unknown first
+// argument resolves to a dcx-<name> binary on PATH and is exec'd with
+// workspace context in the environment, so company-specific workflows
+// can layer on top of dcx without changes here.
+
+// lookupPlugin returns the path of the plugin binary backing an unknown
+// subcommand, or "".
+func lookupPlugin(name string) string {
+	if name == "" || strings.HasPrefix(name, "-") {
+		return ""
+	}
+	path, err := exec.LookPath("dcx-" + name)
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// runPlugin executes the plugin with the remaining arguments and the
+// workspace context env vars:
+//
+//	DCX_WORKSPACE      workspace path
+//	DCX_CONFIG_PATH    resolved devcontainer.json path (when loadable)
+//	DCX_WORKSPACE_ID   workspace identifier
+//	DCX_SSH_HOST       SSH host alias
+//	DCX_CONTAINER_ID   primary container ID (when one exists)
+//	DCX_CONTAINER_NAME primary container name (when one exists)
+//
+// Returns the plugin's exit error (if any) for the caller to propagate.
+func runPlugin(pluginPath string, args []string) error {
+	env := append(os.Environ(), "DCX_WORKSPACE="+workspacePath)
+
+	svc := service.NewDevContainerService(workspacePath, configPath, verbose)
+	defer svc.Close()
+
+	if ids, err := svc.GetIdentifiers(); err == nil {
+		env = append(env,
+			"DCX_WORKSPACE_ID="+ids.WorkspaceID,
+			"DCX_SSH_HOST="+ids.SSHHost,
+		)
+
+		// Container context is best-effort; plugins run fine without a
+		// container (or without Docker at all).
+		if _, err := container.DockerClient(); err == nil {
+			var containerInfo *state.ContainerInfo
+			if _, containerInfo, err = svc.GetStateManager().GetStateWithProject(context.Background(), ids.ProjectName, ids.WorkspaceID); err == nil && containerInfo != nil {
+				env = append(env,
+					"DCX_CONTAINER_ID="+containerInfo.ID,
+					"DCX_CONTAINER_NAME="+containerInfo.Name,
+				)
+			}
+		}
+	}
+	if cfgPath := configPath; cfgPath != "" {
+		env = append(env, "DCX_CONFIG_PATH="+cfgPath)
+	}
+
+	cmd := exec.Command(pluginPath, args...)
+	cmd.Env = env
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/griffithind/dcx/internal/ui"
+)
+
+var portsJSON bool
+
+var portsCmd = &cobra.Command{
+	Use:   "ports",
+	Short: "List the container's published ports",
+	Long: `List the ports published for the devcontainer, including
+Docker-assigned ephemeral host ports from "0:<port>" forwardPorts
+entries. Use --json for machine-readable output.`,
+	RunE: runPorts,
+}
+
+func init() {
+	portsCmd.Flags().BoolVar(&portsJSON, "json", false, "output as JSON")
+	portsCmd.GroupID = "info"
+	rootCmd.AddCommand(portsCmd)
+}
+
+func runPorts(cmd *cobra.Command, args []string) error {
+	cliCtx, err := NewCLIContext()
+	if err != nil {
+		return err
+	}
+	defer cliCtx.Close()
+
+	containerInfo, err := RequireRunningContainer(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	mappings, err := cliCtx.Docker.ContainerPortMappings(cliCtx.Ctx, containerInfo.Name)
+	if err != nil {
+		return err
+	}
+	sort.Slice(mappings, func(i, j int) bool {
+		return mappings[i].ContainerPort < mappings[j].ContainerPort
+	})
+
+	if portsJSON {
+		return json.NewEncoder(os.Stdout).Encode(mappings)
+	}
+
+	if len(mappings) == 0 {
+		ui.Println("No published ports.")
+		return nil
+	}
+
+	headers := []string{"Container", "Host"}
+	var rows [][]string
+	for _, m := range mappings {
+		containerSide := fmt.Sprintf("%d/%s", m.ContainerPort, m.Protocol)
+		hostSide := fmt.Sprintf("%s:%d", m.HostIP, m.HostPort)
+		rows = append(rows, []string{containerSide, hostSide})
+	}
+	return ui.RenderTable(headers, rows)
+}
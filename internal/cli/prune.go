@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/griffithind/dcx/internal/common"
+	"github.com/griffithind/dcx/internal/state"
+	"github.com/griffithind/dcx/internal/ui"
+)
+
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove leftover runtime files",
+	Long: `Remove generated runtime files (compose overrides, build-secret
+files) left under ~/.dcx/runtime by crashed or interrupted runs.
+
+Runtime directories belonging to workspaces that still have containers
+are kept; everything else is removed.`,
+	RunE: runPrune,
+}
+
+func init() {
+	pruneCmd.GroupID = "maintenance"
+	rootCmd.AddCommand(pruneCmd)
+}
+
+func runPrune(cmd *cobra.Command, args []string) error {
+	base := common.RuntimeDir()
+	if base == "" {
+		return nil
+	}
+	entries, err := os.ReadDir(base)
+	if err != nil {
+		if os.IsNotExist(err) {
+			ui.Println("Nothing to prune")
+			return nil
+		}
+		return err
+	}
+
+	// Keep runtime dirs for workspaces that still have containers.
+	live := make(map[string]bool)
+	cliCtx, err := NewCLIContext()
+	if err == nil {
+		defer cliCtx.Close()
+		containers, err := cliCtx.Docker.ListContainersWithLabels(cliCtx.Ctx, map[string]string{
+			state.LabelManaged: "true",
+		})
+		if err == nil {
+			for _, c := range containers {
+				lbls := state.ContainerLabelsFromMap(c.Labels)
+				if lbls.WorkspaceID != "" {
+					live[lbls.WorkspaceID] = true
+				}
+			}
+		}
+	}
+
+	removed := 0
+	for _, entry := range entries {
+		if !entry.IsDir() || live[entry.Name()] {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(base, entry.Name())); err != nil {
+			ui.Warning("Failed to remove %s: %v", entry.Name(), err)
+			continue
+		}
+		removed++
+	}
+
+	if removed > 0 {
+		ui.Success("Pruned %d runtime director(ies)", removed)
+	} else {
+		ui.Println("Nothing to prune")
+	}
+	return nil
+}
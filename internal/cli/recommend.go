@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/griffithind/dcx/internal/detect"
+	"github.com/griffithind/dcx/internal/devcontainer"
+	"github.com/griffithind/dcx/internal/ui"
+)
+
+var recommendCmd = &cobra.Command{
+	Use:   "recommend",
+	Short: "Suggest features and images for this project",
+	Long: `Scan the workspace for known toolchains (go.mod, package.json,
+pyproject.toml, Cargo.toml, Gemfile, pom.xml, compose files) and print
+the recommended devcontainer features — with detected versions where
+possible — as a config snippet to merge into devcontainer.json.`,
+	RunE: runRecommend,
+}
+
+func init() {
+	recommendCmd.GroupID = "utilities"
+	rootCmd.AddCommand(recommendCmd)
+}
+
+func runRecommend(cmd *cobra.Command, args []string) error {
+	recs := detect.Scan(workspacePath)
+	if len(recs) == 0 {
+		ui.Println("No known toolchains detected in this workspace.")
+		return nil
+	}
+
+	// Don't re-recommend features the config already has.
+	existing := map[string]bool{}
+	if cfg, _, err := devcontainer.Load(workspacePath, configPath); err == nil {
+		for ref := range cfg.Features {
+			existing[ref] = true
+		}
+	}
+
+	var missing []detect.Recommendation
+	ui.Println(ui.Bold("Detected toolchains"))
+	for _, rec := range recs {
+		status := ""
+		if existing[rec.FeatureRef] {
+			status = ui.Dim(" (already configured)")
+		} else {
+			missing = append(missing, rec)
+		}
+		ui.Printf("  %s (%s) -> %s%s", rec.Tool, rec.Evidence, rec.FeatureRef, status)
+	}
+
+	if len(missing) == 0 {
+		ui.Println("")
+		ui.Success("All detected toolchains are already configured")
+		return nil
+	}
+
+	snippet, err := detect.FeatureSnippet(missing)
+	if err != nil {
+		return err
+	}
+	ui.Println("")
+	ui.Println(ui.Bold("Suggested addition to devcontainer.json"))
+	ui.Println(snippet)
+	return nil
+}
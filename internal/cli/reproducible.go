@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/griffithind/dcx/internal/build"
+	"github.com/griffithind/dcx/internal/service"
+	"github.com/griffithind/dcx/internal/ui"
+)
+
+var verifyReproducibleCmd = &cobra.Command{
+	Use:   "verify-reproducible",
+	Short: "Rebuild from the lockfile and compare with the current image",
+	Long: `Rebuild the derived image from the lockfile under a temporary tag
+(bypassing the build cache) and compare its content digest with the
+image currently in use.
+
+Matching digests mean the environment is reproducible from the
+lockfile; a mismatch reports drift — typically unpinned apt packages or
+network-dependent feature installs — for teams that need hermetic
+environments. The temporary image is removed afterwards.`,
+	RunE: runVerifyReproducible,
+}
+
+func init() {
+	verifyReproducibleCmd.GroupID = "maintenance"
+	rootCmd.AddCommand(verifyReproducibleCmd)
+}
+
+func runVerifyReproducible(cmd *cobra.Command, args []string) error {
+	cliCtx, err := NewCLIContext()
+	if err != nil {
+		return err
+	}
+	defer cliCtx.Close()
+
+	// Resolve strictly from the lockfile.
+	resolved, err := cliCtx.Service.LoadWithOptions(cliCtx.Ctx, service.LoadOptions{UseLockfile: true})
+	if err != nil {
+		return err
+	}
+	if len(resolved.Features) == 0 {
+		ui.Println("No features configured; nothing to verify.")
+		return nil
+	}
+	if resolved.DerivedImage == "" {
+		return fmt.Errorf("no derived image tag for this workspace; run 'dcx up' first")
+	}
+
+	docker := cliCtx.Docker
+	currentDigest, err := docker.GetImageID(cliCtx.Ctx, resolved.DerivedImage)
+	if err != nil {
+		return fmt.Errorf("current derived image not found (%s); run 'dcx up' first", resolved.DerivedImage)
+	}
+
+	if resolved.BaseImage == "" {
+		return fmt.Errorf("verify-reproducible currently supports image-based plans only")
+	}
+
+	tempTag := fmt.Sprintf("dcx-verify:%d", time.Now().Unix())
+	ui.Printf("Rebuilding from lockfile as %s (no cache)...", tempTag)
+
+	builder := build.NewCLIBuilder()
+	_, err = builder.BuildWithFeatures(cliCtx.Ctx, build.FeatureBuildOptions{
+		BaseImage:     resolved.BaseImage,
+		Tag:           tempTag,
+		Features:      resolved.Features,
+		RemoteUser:    resolved.RemoteUser,
+		ContainerUser: resolved.ContainerUser,
+		Rebuild:       true, // bypass every cache so drift can surface
+		Progress:      os.Stdout,
+		LocalConfig:   resolved.RawConfig,
+	})
+	if err != nil {
+		return fmt.Errorf("rebuild failed: %w", err)
+	}
+	defer func() {
+		_ = exec.Command("docker", "rmi", tempTag).Run()
+	}()
+
+	rebuiltDigest, err := docker.GetImageID(cliCtx.Ctx, tempTag)
+	if err != nil {
+		return fmt.Errorf("failed to inspect rebuilt image: %w", err)
+	}
+
+	ui.Printf("%s", ui.FormatLabel("Current", currentDigest))
+	ui.Printf("%s", ui.FormatLabel("Rebuilt", rebuiltDigest))
+
+	if currentDigest == rebuiltDigest {
+		ui.Success("Environment is reproducible from the lockfile")
+		return nil
+	}
+
+	ui.Error("Image content drifted between builds")
+	ui.Printf("%s", ui.Dim("Common causes: unpinned apt/npm packages in feature installs, features fetching 'latest' artifacts, or timestamps embedded by install scripts"))
+	return fmt.Errorf("environment is not reproducible")
+}
@@ -13,6 +13,7 @@ import (
 var (
 	restartForce   bool
 	restartRebuild bool
+	restartService string
 )
 
 var restartCmd = &cobra.Command{
@@ -33,6 +34,8 @@ Use --rebuild to perform a full rebuild instead of just restart.`,
 func init() {
 	restartCmd.Flags().BoolVarP(&restartForce, "force", "f", false, "force restart even if shutdownAction is 'none'")
 	restartCmd.Flags().BoolVar(&restartRebuild, "rebuild", false, "perform full rebuild instead of restart")
+	restartCmd.Flags().StringVar(&restartService, "service", "", "restart a specific compose service only")
+	_ = restartCmd.RegisterFlagCompletionFunc("service", completeComposeServices)
 	restartCmd.GroupID = "lifecycle"
 	rootCmd.AddCommand(restartCmd)
 }
@@ -57,6 +60,24 @@ func runRestart(cmd *cobra.Command, args []string) error {
 
 	containerInfo := result.ContainerInfo
 
+	// A specific compose service restarts independently of the rest.
+	if restartService != "" {
+		target, err := resolveServiceContainer(cliCtx, containerInfo, restartService)
+		if err != nil {
+			return err
+		}
+		if target.Running {
+			if err := cliCtx.Docker.StopContainer(cliCtx.Ctx, target.ID, nil); err != nil {
+				return fmt.Errorf("failed to stop service: %w", err)
+			}
+		}
+		if err := cliCtx.Docker.StartContainer(cliCtx.Ctx, target.ID); err != nil {
+			return fmt.Errorf("failed to start service: %w", err)
+		}
+		ui.Success(fmt.Sprintf("Service %s restarted", restartService))
+		return nil
+	}
+
 	// Check shutdownAction setting if not forcing
 	if !restartForce {
 		cfg, _, loadErr := devcontainer.Load(cliCtx.WorkspacePath(), cliCtx.ConfigPath())
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/griffithind/dcx/internal/ui"
+)
+
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Restore the environment preserved before the last recreate",
+	Long: `Restore the container preserved before the last recreate.
+
+When a recreate replaces a single-container environment, the old
+container is kept stopped under a -prev name until the new one passes
+its lifecycle hooks. If the new environment is broken, rollback removes
+it and restores the preserved container under its original name.`,
+	RunE: runRollback,
+}
+
+func init() {
+	rollbackCmd.GroupID = "lifecycle"
+	rootCmd.AddCommand(rollbackCmd)
+}
+
+func runRollback(cmd *cobra.Command, args []string) error {
+	cliCtx, err := NewCLIContext()
+	if err != nil {
+		return err
+	}
+	defer cliCtx.Close()
+
+	if err := cliCtx.Service.Rollback(cliCtx.Ctx); err != nil {
+		return err
+	}
+
+	ui.Success("Environment rolled back")
+	return nil
+}
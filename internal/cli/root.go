@@ -3,10 +3,20 @@ package cli
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
+	"os/exec"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/griffithind/dcx/internal/common"
+	"github.com/griffithind/dcx/internal/config"
+	"github.com/griffithind/dcx/internal/container"
+	"github.com/griffithind/dcx/internal/devcontainer"
+	"github.com/griffithind/dcx/internal/logging"
+	"github.com/griffithind/dcx/internal/metrics"
+	"github.com/griffithind/dcx/internal/service"
 	"github.com/griffithind/dcx/internal/ui"
 	"github.com/griffithind/dcx/internal/version"
 )
@@ -15,9 +25,18 @@ import (
 var (
 	workspacePath string
 	configPath    string
+	dockerContext string
+	envFiles      []string
+	noInject      bool
+	strictSpec    bool
+	debugFlag     bool
 	noColor       bool
 	quiet         bool
-	verbose       bool
+	verboseCount  int
+
+	// verbose preserves the boolean view used throughout the CLI; any
+	// -v level implies it.
+	verbose bool
 )
 
 // rootCmd represents the base command when called without any subcommands.
@@ -32,13 +51,52 @@ requiring the @devcontainers/cli. Container state is tracked using labels,
 enabling offline-safe operations for start/stop/exec commands.`,
 	Version: version.Version,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		// Initialize workspace path if not provided
+		// Initialize workspace path if not provided; from a project
+		// subdirectory, walk up to the nearest devcontainer config.
 		if workspacePath == "" {
-			var err error
-			workspacePath, err = os.Getwd()
+			cwd, err := os.Getwd()
 			if err != nil {
 				return fmt.Errorf("failed to get current directory: %w", err)
 			}
+			workspacePath = devcontainer.DiscoverWorkspaceRoot(cwd)
+		}
+
+		// Strict-spec mode strips dcx conveniences: no .env loading, no
+		// user-level injection, no extends (rejected at load time), so
+		// behavior matches the reference implementation for teams that
+		// also use the official CLI.
+		if strictSpec {
+			noInject = true
+			devcontainer.SetStrictSpec(true)
+		}
+
+		// Make .env variables (--env-file > .devcontainer/.env >
+		// workspace .env) visible to ${localEnv} substitution and
+		// compose interpolation. Exported shell variables always win.
+		if !strictSpec {
+			for k, v := range devcontainer.LoadWorkspaceEnv(workspacePath, envFiles) {
+				if _, exists := os.LookupEnv(k); !exists {
+					_ = os.Setenv(k, v)
+				}
+			}
+		}
+
+		// User-level defaults (~/.config/dcx/config.yaml). CLI flags win.
+		userCfg, err := config.User()
+		if err != nil {
+			ui.Warning("Ignoring user config: %v", err)
+		}
+		if dockerContext == "" {
+			dockerContext = userCfg.DockerContext
+		}
+
+		// Select the docker context before any docker/compose invocation.
+		container.SetDockerContext(dockerContext)
+
+		service.SetInjectionDisabled(noInject)
+
+		if debugFlag {
+			common.SetDebugOutput(common.OpenDebugTarget())
 		}
 		return nil
 	},
@@ -50,21 +108,68 @@ func Execute() error {
 	// Parse flags early to configure UI before command execution.
 	// This ensures --no-color and --quiet affect output even for invalid commands.
 	_ = rootCmd.ParseFlags(os.Args[1:])
+	verbose = verboseCount > 0
 	initUI()
 
+	// Route slog to the persistent state log (best-effort) at the level
+	// the -v count selects.
+	logLevel := slog.LevelInfo
+	if verboseCount >= 2 {
+		logLevel = slog.LevelDebug
+	}
+	logging.Setup(logLevel)
+
+	// -vvv additionally traces every docker/compose invocation.
+	if verboseCount >= 3 {
+		common.SetDebugOutput(common.OpenDebugTarget())
+	}
+
+	// Unknown subcommands fall through to dcx-<name> plugins on PATH.
+	if len(os.Args) > 1 {
+		if _, _, err := rootCmd.Find(os.Args[1:2]); err != nil {
+			if pluginPath := lookupPlugin(os.Args[1]); pluginPath != "" {
+				pluginErr := runPlugin(pluginPath, os.Args[2:])
+				if exitErr, ok := pluginErr.(*exec.ExitError); ok {
+					os.Exit(exitErr.ExitCode())
+				}
+				return pluginErr
+			}
+		}
+	}
+
+	start := time.Now()
 	err := rootCmd.Execute()
 	if err != nil {
 		ui.PrintError(err)
 	}
+
+	// Opt-in usage metrics: command name, duration, error category only.
+	command := ""
+	if calledCmd, _, cmdErr := rootCmd.Find(os.Args[1:]); cmdErr == nil && calledCmd != nil {
+		command = calledCmd.Name()
+	}
+	metrics.Report(metrics.Record{
+		Time:          time.Now(),
+		Command:       command,
+		DurationMS:    time.Since(start).Milliseconds(),
+		ErrorCategory: metrics.Categorize(err),
+		Version:       version.Version,
+	})
+
 	return err
 }
 
 // initUI configures the UI system based on parsed flags.
 func initUI() {
 	verbosity := ui.VerbosityNormal
-	if quiet {
+	switch {
+	case quiet:
 		verbosity = ui.VerbosityQuiet
-	} else if verbose {
+	case verboseCount >= 3:
+		verbosity = ui.VerbosityTrace
+	case verboseCount >= 2:
+		verbosity = ui.VerbosityDebug
+	case verboseCount == 1:
 		verbosity = ui.VerbosityVerbose
 	}
 
@@ -80,11 +185,16 @@ func init() {
 	// Global flags
 	rootCmd.PersistentFlags().StringVarP(&workspacePath, "workspace", "w", "", "workspace directory (default: current directory)")
 	rootCmd.PersistentFlags().StringVarP(&configPath, "config", "c", "", "path to devcontainer.json (default: auto-detect)")
+	rootCmd.PersistentFlags().StringVar(&dockerContext, "context", "", "docker context to use (default: current docker context)")
+	rootCmd.PersistentFlags().BoolVar(&noInject, "no-inject", false, "disable user-level default feature/mount/env injection")
+	rootCmd.PersistentFlags().BoolVar(&strictSpec, "strict-spec", false, "disable dcx extensions and follow devcontainer reference semantics")
+	rootCmd.PersistentFlags().StringArrayVar(&envFiles, "env-file", nil, "extra .env file(s) for ${localEnv} substitution and compose interpolation")
 
 	// Output flags
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable colored output")
 	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "minimal output (errors only)")
-	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
+	rootCmd.PersistentFlags().CountVarP(&verboseCount, "verbose", "v", "increase verbosity (-v progress detail, -vv debug logs, -vvv docker invocations)")
+	rootCmd.PersistentFlags().BoolVar(&debugFlag, "debug", false, "log every docker/compose invocation with duration and exit status (to stderr, or $DCX_DEBUG_LOG)")
 
 	// Configure Cobra to use UI-aware writers
 	rootCmd.SetOut(ui.NewCobraOutWriter())
@@ -98,6 +208,9 @@ func init() {
 	rootCmd.AddGroup(&cobra.Group{ID: "maintenance", Title: "Build & Maintenance:"})
 	rootCmd.AddGroup(&cobra.Group{ID: "utilities", Title: "Utilities:"})
 
+	// Dynamic shell completions (after flags and commands exist)
+	registerDynamicCompletions()
+
 	// Lifecycle commands
 	upCmd.GroupID = "lifecycle"
 	stopCmd.GroupID = "lifecycle"
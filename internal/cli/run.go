@@ -11,7 +11,10 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var runList bool
+var (
+	runList      bool
+	runEphemeral bool
+)
 
 var runCmd = &cobra.Command{
 	Use:   "run [shortcut] [args...]",
@@ -48,6 +51,7 @@ Use --list to see all available shortcuts.`,
 
 func init() {
 	runCmd.Flags().BoolVarP(&runList, "list", "l", false, "list available shortcuts")
+	runCmd.Flags().BoolVar(&runEphemeral, "rm", false, "run the command in a throwaway container (removed afterwards, persistent environment untouched)")
 	// Stop parsing flags after the shortcut name so args like --version pass through
 	runCmd.Flags().SetInterspersed(false)
 	runCmd.GroupID = "execution"
@@ -55,6 +59,13 @@ func init() {
 }
 
 func runRunCommand(cmd *cobra.Command, args []string) error {
+	// Ephemeral mode: one command in a throwaway container built from
+	// the resolved config (cached derived images reused), then removed
+	// along with its anonymous volumes.
+	if runEphemeral {
+		return runEphemeralCommand(args)
+	}
+
 	// Load devcontainer.json for shortcuts
 	cfg, _, err := devcontainer.Load(workspacePath, configPath)
 	if err != nil {
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/griffithind/dcx/internal/container"
+	"github.com/griffithind/dcx/internal/ui"
+	"golang.org/x/term"
+)
+
+// runEphemeralCommand implements `dcx run --rm -- <command>`: a temporary
+// container from the resolved configuration (reusing cached derived
+// images) runs one command, then docker's --rm removes it together with
+// its anonymous volumes. The persistent environment is never touched.
+func runEphemeralCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no command specified; usage: dcx run --rm -- <command> [args...]")
+	}
+
+	cliCtx, err := NewCLIContext()
+	if err != nil {
+		return err
+	}
+	defer cliCtx.Close()
+
+	resolved, err := cliCtx.Service.Load(cliCtx.Ctx)
+	if err != nil {
+		return err
+	}
+
+	runtime, err := container.NewUnifiedRuntime(resolved)
+	if err != nil {
+		return err
+	}
+
+	image, err := runtime.FinalImage(cliCtx.Ctx, container.UpOptions{})
+	if err != nil {
+		return err
+	}
+
+	runArgs := []string{"run", "--rm"}
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		runArgs = append(runArgs, "-it")
+	} else {
+		runArgs = append(runArgs, "-i")
+	}
+	if resolved.LocalRoot != "" && resolved.WorkspaceFolder != "" {
+		runArgs = append(runArgs, "-v", resolved.LocalRoot+":"+resolved.WorkspaceFolder, "-w", resolved.WorkspaceFolder)
+	}
+	if resolved.EffectiveUser != "" {
+		runArgs = append(runArgs, "-u", resolved.EffectiveUser)
+	}
+	for k, v := range resolved.ContainerEnv {
+		runArgs = append(runArgs, "-e", k+"="+v)
+	}
+	runArgs = append(runArgs, image)
+	runArgs = append(runArgs, args...)
+
+	ui.Printf("Running in ephemeral container from %s", image)
+	dockerCmd := exec.CommandContext(cliCtx.Ctx, "docker", runArgs...)
+	dockerCmd.Stdin = os.Stdin
+	dockerCmd.Stdout = os.Stdout
+	dockerCmd.Stderr = os.Stderr
+	if err := dockerCmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			os.Exit(exitErr.ExitCode())
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/griffithind/dcx/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var sbomCmd = &cobra.Command{
+	Use:   "sbom",
+	Short: "Inspect what features and packages are in the environment",
+	Long: `Inspect the software bill of materials of the devcontainer environment.
+
+Lists the features installed into the derived image (from container
+labels) and, when the image was built with 'dcx build --sbom', dumps the
+attached SPDX SBOM attestation via 'docker buildx imagetools inspect'.
+
+Requires an existing container (run 'dcx up' first).`,
+	RunE: runSBOM,
+}
+
+func init() {
+	sbomCmd.GroupID = "info"
+	rootCmd.AddCommand(sbomCmd)
+}
+
+func runSBOM(cmd *cobra.Command, args []string) error {
+	cliCtx, err := NewCLIContext()
+	if err != nil {
+		return err
+	}
+	defer cliCtx.Close()
+
+	containerInfo, err := RequireExistingContainer(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	labels := containerInfo.Labels
+
+	ui.Printf("%s", ui.FormatLabel("Workspace", labels.WorkspaceName))
+	if labels.BaseImage != "" {
+		ui.Printf("%s", ui.FormatLabel("Base Image", labels.BaseImage))
+	}
+	if labels.DerivedImage != "" {
+		ui.Printf("%s", ui.FormatLabel("Derived Image", labels.DerivedImage))
+	}
+
+	if len(labels.FeaturesInstalled) > 0 {
+		ui.Println("")
+		ui.Println(ui.Bold("Installed Features"))
+		for _, f := range labels.FeaturesInstalled {
+			ui.Printf("  - %s", f)
+		}
+	} else {
+		ui.Println("")
+		ui.Println(ui.Dim("No features installed"))
+	}
+
+	// If the image carries an SBOM attestation, dump it. This only works
+	// for images that were built with --sbom and live in a registry (the
+	// attestation travels with the manifest, not the local image store).
+	image := labels.DerivedImage
+	if image == "" {
+		image = labels.BaseImage
+	}
+	if image != "" {
+		ui.Println("")
+		if err := dumpSBOMAttestation(image); err != nil {
+			ui.Printf("%s", ui.Dim(fmt.Sprintf("No SBOM attestation available for %s (build with 'dcx build --sbom' and push to a registry)", image)))
+		}
+	}
+
+	return nil
+}
+
+// dumpSBOMAttestation prints the SPDX SBOM attached to an image manifest,
+// if one exists. Errors (no attestation, local-only image) are returned for
+// the caller to soften into a hint.
+func dumpSBOMAttestation(imageRef string) error {
+	cmd := exec.Command("docker", "buildx", "imagetools", "inspect", imageRef, "--format", "{{ json .SBOM }}")
+	output, err := cmd.Output()
+	if err != nil {
+		return err
+	}
+	text := strings.TrimSpace(string(output))
+	if text == "" || text == "null" {
+		return fmt.Errorf("no SBOM attestation on %s", imageRef)
+	}
+	ui.Println(ui.Bold("SBOM Attestation"))
+	_, _ = os.Stdout.WriteString(text + "\n")
+	return nil
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/griffithind/dcx/internal/state"
+)
+
+// Docker compose's own container labels, used to locate a specific
+// service's container within the workspace's compose project.
+const (
+	composeProjectLabel = "com.docker.compose.project"
+	composeServiceLabel = "com.docker.compose.service"
+)
+
+// resolveServiceContainer returns the container backing the named compose
+// service in this workspace's project. An empty service name returns the
+// primary container unchanged, so callers can thread the --service flag
+// through without special-casing.
+func resolveServiceContainer(cliCtx *CLIContext, containerInfo *state.ContainerInfo, service string) (*state.ContainerInfo, error) {
+	if service == "" {
+		return containerInfo, nil
+	}
+
+	if containerInfo.IsSingleContainer() {
+		return nil, fmt.Errorf("--service requires a compose-based devcontainer")
+	}
+
+	project := containerInfo.GetComposeProject(cliCtx.Identifiers.ProjectName)
+	containers, err := cliCtx.Docker.ListContainersWithLabels(cliCtx.Ctx, map[string]string{
+		composeProjectLabel: project,
+		composeServiceLabel: service,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service containers: %w", err)
+	}
+	if len(containers) == 0 {
+		return nil, fmt.Errorf("no container found for service %q in project %q", service, project)
+	}
+
+	c := containers[0]
+	return &state.ContainerInfo{
+		ID:             c.ID,
+		Name:           c.Name,
+		Status:         c.State,
+		Running:        c.Running,
+		ComposeProject: project,
+		PrimaryService: service,
+		Labels:         state.ContainerLabelsFromMap(c.Labels),
+	}, nil
+}
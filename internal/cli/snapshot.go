@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/griffithind/dcx/internal/container"
+	"github.com/griffithind/dcx/internal/ui"
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Snapshot and restore the environment's container",
+	Long: `Snapshot and restore the devcontainer's filesystem state.
+
+'snapshot create' commits the running container to a local image;
+'snapshot restore' replaces the container with one created from a
+snapshot — handy before risky dependency upgrades inside the container.
+
+Snapshots capture the container filesystem, not volume contents.`,
+}
+
+var snapshotCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Commit the running container as a named snapshot",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSnapshotCreate,
+}
+
+var snapshotRestoreCmd = &cobra.Command{
+	Use:   "restore <name>",
+	Short: "Replace the container with a snapshot",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSnapshotRestore,
+}
+
+var snapshotListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List snapshots for this workspace",
+	Args:  cobra.NoArgs,
+	RunE:  runSnapshotList,
+}
+
+func init() {
+	snapshotCmd.GroupID = "maintenance"
+	snapshotCmd.AddCommand(snapshotCreateCmd)
+	snapshotCmd.AddCommand(snapshotRestoreCmd)
+	snapshotCmd.AddCommand(snapshotListCmd)
+	rootCmd.AddCommand(snapshotCmd)
+}
+
+func runSnapshotCreate(cmd *cobra.Command, args []string) error {
+	cliCtx, err := NewCLIContext()
+	if err != nil {
+		return err
+	}
+	defer cliCtx.Close()
+
+	imageRef, err := cliCtx.Service.SnapshotCreate(cliCtx.Ctx, args[0])
+	if err != nil {
+		return err
+	}
+	ui.Success(fmt.Sprintf("Snapshot created: %s", imageRef))
+	return nil
+}
+
+func runSnapshotRestore(cmd *cobra.Command, args []string) error {
+	cliCtx, err := NewCLIContext()
+	if err != nil {
+		return err
+	}
+	defer cliCtx.Close()
+
+	if err := cliCtx.Service.SnapshotRestore(cliCtx.Ctx, args[0]); err != nil {
+		return err
+	}
+	ui.Success("Snapshot restored")
+	return nil
+}
+
+func runSnapshotList(cmd *cobra.Command, args []string) error {
+	cliCtx, err := NewCLIContext()
+	if err != nil {
+		return err
+	}
+	defer cliCtx.Close()
+
+	repo := "dcx-snapshot/" + cliCtx.Identifiers.WorkspaceID
+	images, err := listSnapshotImages(cliCtx.Ctx, repo)
+	if err != nil {
+		return err
+	}
+	if len(images) == 0 {
+		ui.Println("No snapshots found for this workspace.")
+		return nil
+	}
+
+	headers := []string{"Name", "Size"}
+	var rows [][]string
+	for _, img := range images {
+		rows = append(rows, []string{img[0], img[1]})
+	}
+	return ui.RenderTable(headers, rows)
+}
+
+// listSnapshotImages returns [tag, size] pairs for snapshot images in repo.
+func listSnapshotImages(ctx context.Context, repo string) ([][2]string, error) {
+	output, err := container.MustDocker().ListImageTags(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	var result [][2]string
+	for _, line := range output {
+		parts := strings.SplitN(line, "\t", 2)
+		if len(parts) == 2 {
+			result = append(result, [2]string{parts[0], parts[1]})
+		}
+	}
+	return result, nil
+}
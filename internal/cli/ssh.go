@@ -4,28 +4,47 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
 	"syscall"
 
 	containerPkg "github.com/griffithind/dcx/internal/container"
+	"github.com/griffithind/dcx/internal/devcontainer"
 	"github.com/griffithind/dcx/internal/service"
+	sshexec "github.com/griffithind/dcx/internal/ssh/exec"
 	"github.com/griffithind/dcx/internal/ui"
 	"github.com/spf13/cobra"
 )
 
 var sshCmd = &cobra.Command{
-	Use:   "ssh",
+	Use:   "ssh [command...]",
 	Short: "SSH into the container",
-	Long: `SSH into the devcontainer for the current workspace.
+	Long: `Connect to the devcontainer over SSH using dcx's built-in client —
+no ssh binary or ~/.ssh/config required. With a command, runs it and
+exits; without one, opens an interactive shell.
 
-With no flags, prints the ssh command to use. With --connect, execs ssh
-directly so the running process becomes the ssh session.`,
+Port forwards use OpenSSH syntax:
+  dcx ssh -L 8080:localhost:80          # host :8080 -> container :80
+  dcx ssh -R 5432:localhost:5432        # container :5432 -> host :5432
+
+--print shows the equivalent ssh command for external tools; --connect
+execs the system ssh client against the generated host entry.`,
 	RunE: runSSH,
+	Args: cobra.ArbitraryArgs,
 }
 
-var sshConnect bool
+var (
+	sshConnect        bool
+	sshPrint          bool
+	sshLocalForwards  []string
+	sshRemoteForwards []string
+)
 
 func init() {
-	sshCmd.Flags().BoolVar(&sshConnect, "connect", false, "Exec ssh directly instead of printing the command")
+	sshCmd.Flags().BoolVar(&sshConnect, "connect", false, "exec the system ssh client instead of the built-in one")
+	sshCmd.Flags().BoolVar(&sshPrint, "print", false, "print the ssh command instead of connecting")
+	sshCmd.Flags().StringArrayVarP(&sshLocalForwards, "local-forward", "L", nil, "forward a local port into the container ([bind:]port:host:hostport)")
+	sshCmd.Flags().StringArrayVarP(&sshRemoteForwards, "remote-forward", "R", nil, "forward a container port to the host ([bind:]port:host:hostport)")
+	sshCmd.Flags().SetInterspersed(false)
 	sshCmd.GroupID = "utilities"
 	rootCmd.AddCommand(sshCmd)
 }
@@ -44,6 +63,11 @@ func runSSH(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get identifiers: %w", err)
 	}
 
+	if sshPrint {
+		ui.Printf("ssh %s", ids.SSHHost)
+		return nil
+	}
+
 	if sshConnect {
 		sshPath, err := exec.LookPath("ssh")
 		if err != nil {
@@ -52,6 +76,71 @@ func runSSH(cmd *cobra.Command, args []string) error {
 		return syscall.Exec(sshPath, []string{"ssh", ids.SSHHost}, os.Environ())
 	}
 
-	ui.Printf("ssh %s", ids.SSHHost)
+	forwards, err := parseForwardSpecs(sshLocalForwards, false)
+	if err != nil {
+		return err
+	}
+	remoteForwards, err := parseForwardSpecs(sshRemoteForwards, true)
+	if err != nil {
+		return err
+	}
+	forwards = append(forwards, remoteForwards...)
+
+	cliCtx, err := NewCLIContext()
+	if err != nil {
+		return err
+	}
+	defer cliCtx.Close()
+
+	containerInfo, err := RequireRunningContainer(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	cfg, _, _ := devcontainer.Load(cliCtx.WorkspacePath(), cliCtx.ConfigPath())
+
+	// Configured reverse forwards ride along for the session's lifetime.
+	if specs, err := reverseForwardSpecs(cfg); err == nil {
+		forwards = append(forwards, specs...)
+	}
+
+	exitCode, err := sshexec.ExecInContainer(cliCtx.Ctx, sshexec.ContainerExecOptions{
+		ContainerName: containerInfo.Name,
+		WorkspaceID:   containerInfo.Labels.WorkspaceID,
+		Config:        cfg,
+		WorkspacePath: cliCtx.WorkspacePath(),
+		Command:       args,
+		Forwards:      forwards,
+	})
+	if err != nil {
+		return fmt.Errorf("ssh failed: %w", err)
+	}
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
 	return nil
 }
+
+// parseForwardSpecs parses OpenSSH-style forward specs:
+// "[bindAddr:]listenPort:targetHost:targetPort".
+func parseForwardSpecs(specs []string, reverse bool) ([]sshexec.ForwardSpec, error) {
+	var result []sshexec.ForwardSpec
+	for _, spec := range specs {
+		parts := strings.Split(spec, ":")
+		var bindAddr, listenPort, targetHost, targetPort string
+		switch len(parts) {
+		case 3:
+			bindAddr, listenPort, targetHost, targetPort = "127.0.0.1", parts[0], parts[1], parts[2]
+		case 4:
+			bindAddr, listenPort, targetHost, targetPort = parts[0], parts[1], parts[2], parts[3]
+		default:
+			return nil, fmt.Errorf("invalid forward spec %q (want [bind:]port:host:hostport)", spec)
+		}
+		result = append(result, sshexec.ForwardSpec{
+			Reverse:    reverse,
+			BindAddr:   bindAddr + ":" + listenPort,
+			TargetAddr: targetHost + ":" + targetPort,
+		})
+	}
+	return result, nil
+}
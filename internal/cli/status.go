@@ -5,13 +5,17 @@ import (
 	"strings"
 
 	"github.com/griffithind/dcx/internal/devcontainer"
+	"github.com/griffithind/dcx/internal/service"
 	"github.com/griffithind/dcx/internal/ssh/hostconfig"
 	"github.com/griffithind/dcx/internal/state"
 	"github.com/griffithind/dcx/internal/ui"
 	"github.com/spf13/cobra"
 )
 
-var statusDetailed bool
+var (
+	statusDetailed bool
+	statusTimings  bool
+)
 
 var statusCmd = &cobra.Command{
 	Use:   "status",
@@ -29,6 +33,7 @@ This is an offline-safe command that does not require network access.`,
 
 func init() {
 	statusCmd.Flags().BoolVarP(&statusDetailed, "detailed", "d", false, "show detailed environment information")
+	statusCmd.Flags().BoolVar(&statusTimings, "timings", false, "show historical up timing breakdowns")
 }
 
 func runStatus(cmd *cobra.Command, args []string) error {
@@ -94,11 +99,38 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		ui.Printf("%s", ui.FormatLabel("SSH", ui.Dim("not configured (run 'dcx up' to configure)")))
 	}
 
+	// GPU decision recorded at create time (optional GPU requirements)
+	if containerInfo != nil && containerInfo.Labels.GPUDecision != "" {
+		gpuText := "enabled"
+		if containerInfo.Labels.GPUDecision == "unavailable" {
+			gpuText = "requested (optional), not available on this host"
+		}
+		ui.Printf("%s", ui.FormatLabel("GPU", gpuText))
+	}
+
 	// Show shortcuts count
 	if dcxCustom != nil && len(dcxCustom.Shortcuts) > 0 {
 		ui.Printf("%s", ui.FormatLabel("Shortcuts", fmt.Sprintf("%d defined (use 'dcx run --list' to view)", len(dcxCustom.Shortcuts))))
 	}
 
+	// Per-service state for compose plans with a managed subset
+	if containerInfo != nil && containerInfo.ComposeProject != "" && cfg != nil && len(cfg.RunServices) > 0 {
+		managed := append([]string{cfg.Service}, cfg.RunServices...)
+		ui.Println("")
+		ui.Println(ui.Bold("Managed Services"))
+		for _, svc := range managed {
+			containers, err := cliCtx.Docker.ListContainersWithLabels(cliCtx.Ctx, map[string]string{
+				composeProjectLabel: containerInfo.ComposeProject,
+				composeServiceLabel: svc,
+			})
+			svcState := "absent"
+			if err == nil && len(containers) > 0 {
+				svcState = containers[0].State
+			}
+			ui.Printf("  %s", ui.FormatLabel(svc, ui.StateColor(svcState)))
+		}
+	}
+
 	// Container details
 	if containerInfo != nil {
 		ui.Println("")
@@ -122,6 +154,19 @@ func runStatus(cmd *cobra.Command, args []string) error {
 					ui.Printf("  %s", ui.FormatLabel("Started", fullContainer.StartedAt))
 				}
 				ui.Printf("  %s", ui.FormatLabel("Running", fmt.Sprintf("%t", containerInfo.Running)))
+				if cpus, memory, err := cliCtx.Docker.ContainerResourceLimits(cliCtx.Ctx, containerInfo.ID); err == nil && (cpus > 0 || memory > 0) {
+					limits := ""
+					if cpus > 0 {
+						limits = fmt.Sprintf("%.1f cpus", cpus)
+					}
+					if memory > 0 {
+						if limits != "" {
+							limits += ", "
+						}
+						limits += fmt.Sprintf("%d MB memory", memory/(1024*1024))
+					}
+					ui.Printf("  %s", ui.FormatLabel("Limits", limits))
+				}
 			}
 		}
 	}
@@ -165,6 +210,25 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Timing history from past `dcx up` runs
+	if statusTimings {
+		records, err := service.LoadTimings(ids.WorkspaceID, 10)
+		if err == nil && len(records) > 0 {
+			ui.Println("")
+			ui.Println(ui.Bold("Startup Timings (last 10)"))
+			for _, r := range records {
+				line := fmt.Sprintf("  %s  total %.1fs", r.Time.Format("2006-01-02 15:04"), r.Total)
+				for _, p := range r.Phases {
+					line += fmt.Sprintf("  %s %.1fs", p.Name, p.Seconds)
+				}
+				ui.Println(line)
+			}
+		} else {
+			ui.Println("")
+			ui.Println(ui.Dim("No timing history recorded yet."))
+		}
+	}
+
 	// Detailed mode: show labels
 	if statusDetailed && containerInfo != nil {
 		labelMap := containerInfo.Labels.ToMap()
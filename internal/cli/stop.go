@@ -10,7 +10,10 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var stopForce bool
+var (
+	stopForce   bool
+	stopService string
+)
 
 var stopCmd = &cobra.Command{
 	Use:   "stop",
@@ -43,9 +46,26 @@ func runStop(cmd *cobra.Command, args []string) error {
 	currentState := result.State
 	containerInfo := result.ContainerInfo
 
+	// A specific compose service can be stopped regardless of overall state.
+	if stopService != "" {
+		target, err := resolveServiceContainer(cliCtx, containerInfo, stopService)
+		if err != nil {
+			return err
+		}
+		if !target.Running {
+			ui.Printf("Service %s is already stopped", stopService)
+			return nil
+		}
+		if err := cliCtx.Docker.StopContainer(cliCtx.Ctx, target.ID, nil); err != nil {
+			return fmt.Errorf("failed to stop service: %w", err)
+		}
+		ui.Success(fmt.Sprintf("Service %s stopped", stopService))
+		return nil
+	}
+
 	switch currentState {
 	case state.StateAbsent:
-		ui.Println("No devcontainer found")
+		ui.Println(ui.T(ui.MsgNoDevcontainer))
 		return nil
 
 	case state.StateCreated:
@@ -78,7 +98,7 @@ func runStop(cmd *cobra.Command, args []string) error {
 				return fmt.Errorf("failed to stop containers: %w", err)
 			}
 		}
-		ui.Success("Devcontainer stopped")
+		ui.Success("%s", ui.T(ui.MsgStopStopped))
 		return nil
 
 	default:
@@ -88,4 +108,6 @@ func runStop(cmd *cobra.Command, args []string) error {
 
 func init() {
 	stopCmd.Flags().BoolVarP(&stopForce, "force", "f", false, "force stop even if shutdownAction is 'none'")
+	stopCmd.Flags().StringVar(&stopService, "service", "", "stop a specific compose service only")
+	_ = stopCmd.RegisterFlagCompletionFunc("service", completeComposeServices)
 }
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/griffithind/dcx/internal/devcontainer"
+	"github.com/griffithind/dcx/internal/service"
+	sshexec "github.com/griffithind/dcx/internal/ssh/exec"
+	"github.com/griffithind/dcx/internal/state"
+	"github.com/griffithind/dcx/internal/ui"
+)
+
+var testRemove bool
+
+var testCmd = &cobra.Command{
+	Use:   "test [-- command...]",
+	Short: "Run the project's checks in the container",
+	Long: `Ensure the environment is up, run the configured test command
+(customizations.dcx.testCommand, or an explicit command after --) as the
+remote user, stream the output, and exit with the command's code — a
+one-liner for CI:
+
+  dcx test
+  dcx test -- make check
+
+With --rm the environment is torn down afterwards, leaving nothing
+behind on ephemeral runners.`,
+	RunE: runTest,
+	Args: cobra.ArbitraryArgs,
+}
+
+func init() {
+	testCmd.Flags().BoolVar(&testRemove, "rm", false, "remove the environment after the run")
+	testCmd.Flags().SetInterspersed(false)
+	testCmd.GroupID = "execution"
+	rootCmd.AddCommand(testCmd)
+}
+
+func runTest(cmd *cobra.Command, args []string) error {
+	cliCtx, err := NewCLIContext()
+	if err != nil {
+		return err
+	}
+	defer cliCtx.Close()
+
+	cfg, _, err := devcontainer.Load(cliCtx.WorkspacePath(), cliCtx.ConfigPath())
+	if err != nil {
+		return err
+	}
+
+	var command []string
+	if len(args) > 0 {
+		command = args
+	} else if dcx := devcontainer.GetDcxCustomizations(cfg); dcx != nil && dcx.TestCommand != "" {
+		command = []string{"sh", "-c", dcx.TestCommand}
+	}
+	if len(command) == 0 {
+		return fmt.Errorf("no test command: set customizations.dcx.testCommand or pass one after --")
+	}
+
+	// Ensure the environment is up.
+	currentState, _, err := cliCtx.GetState()
+	if err != nil {
+		return err
+	}
+	if currentState != state.StateRunning {
+		ui.Println("Environment not running; bringing it up...")
+		if err := cliCtx.Service.Up(cliCtx.Ctx, service.UpOptions{}); err != nil {
+			return err
+		}
+	}
+
+	containerInfo, err := RequireRunningContainer(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	exitCode, execErr := sshexec.ExecInContainer(cliCtx.Ctx, sshexec.ContainerExecOptions{
+		ContainerName: containerInfo.Name,
+		WorkspaceID:   containerInfo.Labels.WorkspaceID,
+		Config:        cfg,
+		WorkspacePath: cliCtx.WorkspacePath(),
+		Command:       command,
+	})
+
+	if testRemove {
+		ui.Println("Removing environment (--rm)...")
+		if err := cliCtx.Service.DownWithIDs(cliCtx.Ctx, cliCtx.Identifiers.ProjectName, cliCtx.Identifiers.WorkspaceID, service.DownOptions{RemoveVolumes: true}); err != nil {
+			ui.Warning("Failed to remove environment: %v", err)
+		}
+	}
+
+	if execErr != nil {
+		return fmt.Errorf("test run failed: %w", execErr)
+	}
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+
+	"github.com/griffithind/dcx/internal/build"
+	"github.com/griffithind/dcx/internal/features"
+	"github.com/griffithind/dcx/internal/ui"
+)
+
+var toolboxCmd = &cobra.Command{
+	Use:   "toolbox <feature-ref> [-- command...]",
+	Short: "Run a throwaway container with an extra feature layered on",
+	Long: `Overlay an extra feature on the environment's image and run a
+throwaway container from it, for one-off debugging tools without
+touching the team's config.
+
+The toolbox container shares the devcontainer's network namespace (so
+localhost reaches its services) and mounts the same workspace; it is
+removed on exit. The derived toolbox image is cached per (image,
+feature) so repeat invocations are fast.
+
+Examples:
+  dcx toolbox ghcr.io/devcontainers/features/go:1
+  dcx toolbox ghcr.io/devcontainers-contrib/features/curl-apt-get -- curl -v localhost:3000`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runToolbox,
+}
+
+func init() {
+	toolboxCmd.Flags().SetInterspersed(false)
+	toolboxCmd.GroupID = "execution"
+	rootCmd.AddCommand(toolboxCmd)
+}
+
+// toolboxImageTag returns the throwaway image tag, cached per
+// (base image, feature) pair so repeat invocations reuse the build.
+func toolboxImageTag(baseImage, featureRef string) string {
+	tagHash := sha256.Sum256([]byte(baseImage + "\x00" + featureRef))
+	return fmt.Sprintf("dcx-toolbox:%x", tagHash[:6])
+}
+
+func runToolbox(cmd *cobra.Command, args []string) error {
+	featureRef := args[0]
+	command := args[1:]
+
+	cliCtx, err := NewCLIContext()
+	if err != nil {
+		return err
+	}
+	defer cliCtx.Close()
+
+	containerInfo, err := RequireRunningContainer(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	details, err := cliCtx.Docker.InspectContainer(cliCtx.Ctx, containerInfo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to inspect container: %w", err)
+	}
+	baseImage := details.Image
+
+	// Resolve the requested feature against the workspace's config dir
+	// (so local feature paths work too).
+	resolved, err := cliCtx.Service.Load(cliCtx.Ctx)
+	if err != nil {
+		return err
+	}
+	mgr, err := features.NewManager(resolved.ConfigDir)
+	if err != nil {
+		return fmt.Errorf("failed to create feature manager: %w", err)
+	}
+	feats, err := mgr.ResolveAll(cliCtx.Ctx, map[string]interface{}{featureRef: map[string]interface{}{}}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to resolve feature %s: %w", featureRef, err)
+	}
+
+	toolboxTag := toolboxImageTag(baseImage, featureRef)
+
+	builder := build.NewCLIBuilder()
+	image, err := builder.BuildWithFeatures(cliCtx.Ctx, build.FeatureBuildOptions{
+		BaseImage:     baseImage,
+		Tag:           toolboxTag,
+		Features:      feats,
+		RemoteUser:    resolved.RemoteUser,
+		ContainerUser: resolved.ContainerUser,
+		Progress:      os.Stdout,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build toolbox image: %w", err)
+	}
+
+	// Share the devcontainer's network namespace and workspace, remove
+	// on exit.
+	runArgs := []string{"run", "--rm", "-it",
+		"--network", "container:" + containerInfo.Name,
+	}
+	if resolved.LocalRoot != "" && resolved.WorkspaceFolder != "" {
+		runArgs = append(runArgs, "-v", resolved.LocalRoot+":"+resolved.WorkspaceFolder, "-w", resolved.WorkspaceFolder)
+	}
+	if resolved.EffectiveUser != "" {
+		runArgs = append(runArgs, "-u", resolved.EffectiveUser)
+	}
+	runArgs = append(runArgs, image)
+	if len(command) > 0 {
+		runArgs = append(runArgs, command...)
+	} else {
+		runArgs = append(runArgs, "sh")
+	}
+
+	ui.Printf("Starting toolbox from %s", image)
+	dockerCmd := exec.CommandContext(cliCtx.Ctx, "docker", runArgs...)
+	dockerCmd.Stdin = os.Stdin
+	dockerCmd.Stdout = os.Stdout
+	dockerCmd.Stderr = os.Stderr
+	if err := dockerCmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			os.Exit(exitErr.ExitCode())
+		}
+		return err
+	}
+	return nil
+}
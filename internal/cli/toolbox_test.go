@@ -0,0 +1,19 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToolboxImageTag(t *testing.T) {
+	base := toolboxImageTag("sha256:abc", "ghcr.io/devcontainers/features/go:1")
+
+	assert.True(t, strings.HasPrefix(base, "dcx-toolbox:"))
+	// Cached per (image, feature): same inputs, same tag.
+	assert.Equal(t, base, toolboxImageTag("sha256:abc", "ghcr.io/devcontainers/features/go:1"))
+	// Either input changing produces a different tag.
+	assert.NotEqual(t, base, toolboxImageTag("sha256:other", "ghcr.io/devcontainers/features/go:1"))
+	assert.NotEqual(t, base, toolboxImageTag("sha256:abc", "ghcr.io/devcontainers/features/node:1"))
+}
@@ -2,7 +2,10 @@ package cli
 
 import (
 	"fmt"
+	"path/filepath"
 
+	"github.com/griffithind/dcx/internal/config"
+	"github.com/griffithind/dcx/internal/devcontainer"
 	"github.com/griffithind/dcx/internal/service"
 	"github.com/griffithind/dcx/internal/state"
 	"github.com/griffithind/dcx/internal/ui"
@@ -10,10 +13,22 @@ import (
 )
 
 var (
-	recreate bool
-	rebuild  bool
-	pull     bool
-	hostsArg string
+	recreate              bool
+	rebuild               bool
+	pull                  bool
+	hostsArg              string
+	noRecreateOnEnvChange bool
+	waitForLock           bool
+	remapPorts            bool
+	upDryRun              bool
+	upStrict              bool
+	upMounts              []string
+	upEnv                 []string
+	upPorts               []string
+	upSave                bool
+	limitResources        bool
+	isolatedUserns        bool
+	upAll                 bool
 )
 
 var upCmd = &cobra.Command{
@@ -27,8 +42,12 @@ This command is smart about what it needs to do:
 - Use --rebuild to force image rebuild
 - Use --recreate to force container recreation
 
-Lifecycle hooks run as appropriate based on the action taken.`,
+Lifecycle hooks run as appropriate based on the action taken.
+
+An optional path argument targets another workspace (e.g. a monorepo
+subproject): dcx up services/api.`,
 	RunE: runUp,
+	Args: cobra.MaximumNArgs(1),
 }
 
 func init() {
@@ -36,20 +55,90 @@ func init() {
 	upCmd.Flags().BoolVar(&rebuild, "rebuild", false, "force rebuild images")
 	upCmd.Flags().BoolVar(&pull, "pull", false, "force re-fetch remote features (useful when feature tags like :latest are updated)")
 	upCmd.Flags().StringVar(&hostsArg, "hosts", "", "widen SSH access beyond loopback (e.g. --hosts=10.0.0.0/24 or --hosts=any)")
+	upCmd.Flags().BoolVar(&noRecreateOnEnvChange, "no-recreate-on-env-change", false, "apply containerEnv/remoteEnv changes in place instead of recreating")
+	upCmd.Flags().BoolVar(&waitForLock, "wait", false, "wait for other dcx operations on this workspace to finish")
+	upCmd.Flags().BoolVar(&remapPorts, "remap-ports", false, "remap conflicting host ports to ephemeral ports instead of failing")
+	upCmd.Flags().BoolVar(&upDryRun, "dry-run", false, "print what would be done without touching Docker")
+	upCmd.Flags().BoolVar(&upStrict, "strict", false, "error on configuration properties dcx ignores instead of warning")
+	upCmd.Flags().StringArrayVar(&upMounts, "mount", nil, "one-off extra mount (source=...,target=...,type=...)")
+	upCmd.Flags().StringArrayVar(&upEnv, "env", nil, "one-off extra container env (KEY=VALUE)")
+	upCmd.Flags().StringArrayVar(&upPorts, "port", nil, "one-off extra port forward (e.g. 9000:9000)")
+	upCmd.Flags().BoolVar(&upSave, "save", false, "persist --mount/--env/--port overrides into devcontainer.json")
+	upCmd.Flags().BoolVar(&limitResources, "limit-resources", false, "enforce hostRequirements.cpus/memory as container limits")
+	upCmd.Flags().BoolVar(&isolatedUserns, "isolated-userns", false, "prefer user-namespace isolation over the UID-rewrite image layer")
+	upCmd.Flags().BoolVar(&upAll, "all", false, "bring up every workspace listed in dcx-workspaces.yaml")
 }
 
 func runUp(cmd *cobra.Command, args []string) error {
+	// Fleet mode: operate over the workspace manifest.
+	if upAll {
+		return runFleet("up", nil)
+	}
+
+	// Positional workspace argument (monorepo subprojects).
+	if len(args) == 1 {
+		target := args[0]
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(workspacePath, target)
+		}
+		workspacePath = devcontainer.DiscoverWorkspaceRoot(target)
+	}
+
 	hosts, err := parseHostsSpec(hostsArg)
 	if err != nil {
 		return fmt.Errorf("invalid --hosts: %w", err)
 	}
 
+	// User-level SSH defaults apply only when --hosts was not given.
+	if hostsArg == "" {
+		if userCfg, err := config.User(); err == nil {
+			if userCfg.SSH.BindHost != "" {
+				hosts.BindHost = userCfg.SSH.BindHost
+			}
+			if len(userCfg.SSH.AllowedCIDRs) > 0 {
+				hosts.CIDRs = append(hosts.CIDRs, userCfg.SSH.AllowedCIDRs...)
+			}
+		}
+	}
+
+	// Surface schema problems (unknown keys, type errors) before doing
+	// any Docker work; warnings only, so working configs keep working.
+	validateConfigPreUp()
+
+	// --save persists the one-off overrides into devcontainer.json before
+	// the up, so they participate in hashing like any other config.
+	if upSave && (len(upMounts) > 0 || len(upEnv) > 0 || len(upPorts) > 0) {
+		if err := persistOverrides(upMounts, upEnv, upPorts); err != nil {
+			return fmt.Errorf("failed to save overrides: %w", err)
+		}
+		ui.Printf("Overrides saved to devcontainer.json")
+	}
+
+	// --strict refuses configs that rely on properties dcx ignores.
+	if upStrict {
+		if cfg, _, err := devcontainer.Load(workspacePath, configPath); err == nil {
+			if ignored := devcontainer.UnsupportedProperties(cfg); len(ignored) > 0 {
+				for _, msg := range ignored {
+					ui.Error("ignored property: %s", msg)
+				}
+				return fmt.Errorf("%d ignored propert(ies) in strict mode", len(ignored))
+			}
+		}
+	}
+
 	cliCtx, err := NewCLIContext()
 	if err != nil {
 		return err
 	}
 	defer cliCtx.Close()
 
+	if upDryRun {
+		return cliCtx.Service.DryRun(cliCtx.Ctx, service.UpOptions{
+			Recreate: recreate,
+			Rebuild:  rebuild,
+		})
+	}
+
 	// Check if we can do a quick start (smart detection)
 	// Skip smart detection if --rebuild or --recreate or --pull are specified
 	if !rebuild && !recreate && !pull {
@@ -58,16 +147,16 @@ func runUp(cmd *cobra.Command, args []string) error {
 			switch plan.Action {
 			case state.PlanActionNone:
 				// Already running, nothing to do
-				ui.Success("Devcontainer is already running")
+				ui.Success("%s", ui.T(ui.MsgUpAlreadyRunning))
 				return nil
 
 			case state.PlanActionStart:
 				// Containers exist but stopped, just start them (offline-safe)
-				ui.Printf("Devcontainer exists and is up to date, starting...")
+				ui.Printf("%s", ui.T(ui.MsgUpStarting))
 				if err := cliCtx.Service.QuickStart(cliCtx.Ctx, plan.ContainerInfo, cliCtx.Identifiers.ProjectName, cliCtx.Identifiers.WorkspaceID); err != nil {
 					return err
 				}
-				ui.Success("Devcontainer started")
+				ui.Success("%s", ui.T(ui.MsgUpStarted))
 				return nil
 
 				// For CREATE, RECREATE, REBUILD - continue to full up
@@ -77,15 +166,33 @@ func runUp(cmd *cobra.Command, args []string) error {
 
 	// Full up sequence required
 	if err := cliCtx.Service.Up(cliCtx.Ctx, service.UpOptions{
-		Recreate:        recreate,
-		Rebuild:         rebuild,
-		Pull:            pull,
-		SSHBindHost:     hosts.BindHost,
-		SSHAllowedCIDRs: hosts.CIDRs,
+		Recreate:              recreate,
+		Rebuild:               rebuild,
+		Pull:                  pull,
+		SSHBindHost:           hosts.BindHost,
+		SSHAllowedCIDRs:       hosts.CIDRs,
+		NoRecreateOnEnvChange: noRecreateOnEnvChange,
+		WaitForLock:           waitForLock,
+		RemapPorts:            remapPorts,
+		ApplyResourceLimits:   limitResources,
+		IsolatedUserns:        isolatedUserns,
+		ExtraMounts:           upMounts,
+		ExtraEnv:              upEnv,
+		ExtraPorts:            upPorts,
 	}); err != nil {
 		return err
 	}
 
-	ui.Success("Devcontainer started successfully")
+	// Consolidated notice for properties dcx accepted but did not apply.
+	if cfg, _, err := devcontainer.Load(workspacePath, configPath); err == nil {
+		if ignored := devcontainer.UnsupportedProperties(cfg); len(ignored) > 0 {
+			ui.Warning("Ignored %d configuration propert(ies):", len(ignored))
+			for _, msg := range ignored {
+				ui.Warning("  - %s", msg)
+			}
+		}
+	}
+
+	ui.Success("%s", ui.T(ui.MsgUpSuccess))
 	return nil
 }
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/griffithind/dcx/internal/devcontainer"
+	"github.com/griffithind/dcx/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate devcontainer.json against the schema",
+	Long: `Validate the devcontainer.json configuration.
+
+Checks the configuration against the devcontainer schema and reports
+unknown keys, type errors, and invalid enum values with line/column
+positions in the original JSONC source, plus the structural checks that
+'dcx up' performs.
+
+Exits non-zero when errors are found; unknown keys alone are warnings.`,
+	RunE: runValidateConfig,
+}
+
+func init() {
+	validateCmd.GroupID = "utilities"
+	rootCmd.AddCommand(validateCmd)
+}
+
+func runValidateConfig(cmd *cobra.Command, args []string) error {
+	cfgPath := configPath
+	if cfgPath == "" {
+		cfgPath = findConfigPath(workspacePath)
+	}
+	if cfgPath == "" {
+		return fmt.Errorf("no devcontainer.json found in %s", workspacePath)
+	}
+
+	data, err := os.ReadFile(cfgPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", cfgPath, err)
+	}
+
+	issues := devcontainer.ValidateSchema(data)
+	printSchemaIssues(cfgPath, issues)
+
+	// Structural validation (requires a parseable config).
+	if !devcontainer.HasSchemaErrors(issues) {
+		cfg, err := devcontainer.ParseFile(cfgPath)
+		if err != nil {
+			return err
+		}
+		if err := devcontainer.Validate(cfg); err != nil {
+			ui.Error("%v", err)
+			return fmt.Errorf("validation failed")
+		}
+	}
+
+	if devcontainer.HasSchemaErrors(issues) {
+		return fmt.Errorf("validation failed")
+	}
+
+	ui.Success("Configuration is valid")
+	return nil
+}
+
+// printSchemaIssues renders schema findings with their source positions.
+func printSchemaIssues(cfgPath string, issues []devcontainer.SchemaIssue) {
+	for _, issue := range issues {
+		if issue.Severity == devcontainer.SchemaError {
+			ui.Error("%s: %s", cfgPath, issue.String())
+		} else {
+			ui.Warning("%s: %s", cfgPath, issue.String())
+		}
+	}
+}
+
+// validateConfigPreUp runs the schema pass before 'dcx up', surfacing
+// findings as warnings without blocking the up (working configurations
+// with tool-specific keys remain usable).
+func validateConfigPreUp() {
+	cfgPath := configPath
+	if cfgPath == "" {
+		cfgPath = findConfigPath(workspacePath)
+	}
+	if cfgPath == "" {
+		return
+	}
+	data, err := os.ReadFile(cfgPath)
+	if err != nil {
+		return
+	}
+	for _, issue := range devcontainer.ValidateSchema(data) {
+		ui.Warning("%s: %s", cfgPath, issue.String())
+	}
+}
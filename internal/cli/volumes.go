@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/griffithind/dcx/internal/container"
+	"github.com/griffithind/dcx/internal/ui"
+)
+
+var volumesForce bool
+
+var volumesCmd = &cobra.Command{
+	Use:   "volumes",
+	Short: "List volumes associated with this workspace",
+	Long: `List the named volumes associated with the workspace: volumes
+mounted by the primary container (workspace and feature-created volumes)
+plus the compose project's volumes, with sizes.
+
+Use 'dcx volumes rm <name>...' to remove specific volumes instead of the
+all-or-nothing 'dcx down -v'.`,
+	RunE: runVolumesList,
+}
+
+var volumesRmCmd = &cobra.Command{
+	Use:   "rm <name>...",
+	Short: "Remove specific workspace volumes",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runVolumesRm,
+}
+
+func init() {
+	volumesRmCmd.Flags().BoolVarP(&volumesForce, "force", "f", false, "force removal (volume may be in use)")
+	volumesCmd.AddCommand(volumesRmCmd)
+	volumesCmd.GroupID = "maintenance"
+	rootCmd.AddCommand(volumesCmd)
+}
+
+// workspaceVolumes collects the named volumes belonging to the workspace.
+func workspaceVolumes(cliCtx *CLIContext) ([]container.VolumeInfo, error) {
+	seen := make(map[string]bool)
+	var volumes []container.VolumeInfo
+
+	// Volumes mounted by the primary container.
+	if _, containerInfo, err := cliCtx.GetState(); err == nil && containerInfo != nil {
+		names, err := cliCtx.Docker.ContainerVolumeNames(cliCtx.Ctx, containerInfo.ID)
+		if err == nil {
+			for _, name := range names {
+				if !seen[name] {
+					seen[name] = true
+					volumes = append(volumes, container.VolumeInfo{Name: name})
+				}
+			}
+		}
+
+		// Compose project volumes (covers services beyond the primary).
+		if containerInfo.ComposeProject != "" {
+			list, err := cliCtx.Docker.ListVolumesWithLabel(cliCtx.Ctx, "com.docker.compose.project="+containerInfo.ComposeProject)
+			if err == nil {
+				for _, v := range list {
+					if !seen[v.Name] {
+						seen[v.Name] = true
+						volumes = append(volumes, v)
+					}
+				}
+			}
+		}
+	}
+
+	// Fill in sizes in one pass.
+	if sizes, err := cliCtx.Docker.VolumeSizes(cliCtx.Ctx); err == nil {
+		for i := range volumes {
+			if size, ok := sizes[volumes[i].Name]; ok {
+				volumes[i].Size = size
+			}
+		}
+	}
+
+	sort.Slice(volumes, func(i, j int) bool { return volumes[i].Name < volumes[j].Name })
+	return volumes, nil
+}
+
+func runVolumesList(cmd *cobra.Command, args []string) error {
+	cliCtx, err := NewCLIContext()
+	if err != nil {
+		return err
+	}
+	defer cliCtx.Close()
+
+	volumes, err := workspaceVolumes(cliCtx)
+	if err != nil {
+		return err
+	}
+	if len(volumes) == 0 {
+		ui.Println("No volumes associated with this workspace.")
+		return nil
+	}
+
+	headers := []string{"Name", "Size"}
+	var rows [][]string
+	for _, v := range volumes {
+		size := v.Size
+		if size == "" {
+			size = "-"
+		}
+		rows = append(rows, []string{v.Name, size})
+	}
+	return ui.RenderTable(headers, rows)
+}
+
+func runVolumesRm(cmd *cobra.Command, args []string) error {
+	cliCtx, err := NewCLIContext()
+	if err != nil {
+		return err
+	}
+	defer cliCtx.Close()
+
+	for _, name := range args {
+		if err := cliCtx.Docker.RemoveVolume(cliCtx.Ctx, name, volumesForce); err != nil {
+			return err
+		}
+		ui.Printf("Removed volume %s", name)
+	}
+	return nil
+}
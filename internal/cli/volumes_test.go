@@ -0,0 +1,18 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVolumesCommandWiring(t *testing.T) {
+	// rm is registered as a subcommand with its force flag; the
+	// docker-bound listing itself is covered by e2e.
+	rm, _, err := volumesCmd.Find([]string{"rm"})
+	assert.NoError(t, err)
+	assert.Equal(t, "rm", rm.Name())
+	assert.NotNil(t, rm.Flags().Lookup("force"))
+
+	assert.NotNil(t, rootCmd.Commands())
+}
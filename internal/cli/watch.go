@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/griffithind/dcx/internal/common"
+	"github.com/griffithind/dcx/internal/devcontainer"
+	sshexec "github.com/griffithind/dcx/internal/ssh/exec"
+	"github.com/griffithind/dcx/internal/ui"
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch [-- command...]",
+	Short: "Watch the workspace and re-run a command on changes",
+	Long: `Run the dcx-agent's file watcher against the container workspace,
+re-running a command whenever matching files change — a built-in
+"watch and rebuild" loop.
+
+The command defaults to updateContentCommand from devcontainer.json; an
+explicit command after -- overrides it. Globs come from
+customizations.dcx.watch.globs (empty = everything except VCS and
+dependency directories).`,
+	RunE: runWatch,
+	Args: cobra.ArbitraryArgs,
+}
+
+func init() {
+	watchCmd.Flags().SetInterspersed(false)
+	watchCmd.GroupID = "execution"
+	rootCmd.AddCommand(watchCmd)
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	cliCtx, err := NewCLIContext()
+	if err != nil {
+		return err
+	}
+	defer cliCtx.Close()
+
+	containerInfo, err := RequireRunningContainer(cliCtx)
+	if err != nil {
+		return err
+	}
+
+	cfg, _, err := devcontainer.Load(cliCtx.WorkspacePath(), cliCtx.ConfigPath())
+	if err != nil {
+		return err
+	}
+
+	var globs []string
+	watchCommand := strings.Join(args, " ")
+	if dcx := devcontainer.GetDcxCustomizations(cfg); dcx != nil && dcx.Watch != nil {
+		globs = dcx.Watch.Globs
+		if watchCommand == "" {
+			watchCommand = dcx.Watch.Command
+		}
+	}
+	if watchCommand == "" {
+		watchCommand = lifecycleCommandString(cfg.UpdateContentCommand)
+	}
+	if watchCommand == "" {
+		return fmt.Errorf("nothing to run: no command given, no watch.command, and no updateContentCommand")
+	}
+
+	workDir := devcontainer.DetermineContainerWorkspaceFolder(cfg, cliCtx.WorkspacePath())
+
+	agentArgs := []string{common.AgentBinaryPath, "watch", "--dir", workDir}
+	if len(globs) > 0 {
+		agentArgs = append(agentArgs, "--globs", strings.Join(globs, ","))
+	}
+	agentArgs = append(agentArgs, "sh", "-c", watchCommand)
+
+	ui.Printf("Watching %s (command: %s)", workDir, watchCommand)
+
+	_, err = sshexec.ExecInContainer(cliCtx.Ctx, sshexec.ContainerExecOptions{
+		ContainerName: containerInfo.Name,
+		WorkspaceID:   containerInfo.Labels.WorkspaceID,
+		Config:        cfg,
+		WorkspacePath: cliCtx.WorkspacePath(),
+		Command:       agentArgs,
+	})
+	return err
+}
+
+// lifecycleCommandString renders a lifecycle command value (string or
+// argv array) as a single shell command. Map-form commands aren't
+// watchable as one unit and yield "".
+func lifecycleCommandString(v interface{}) string {
+	switch cmd := v.(type) {
+	case string:
+		return cmd
+	case []interface{}:
+		var parts []string
+		for _, item := range cmd {
+			if s, ok := item.(string); ok {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, " ")
+	}
+	return ""
+}
@@ -0,0 +1,93 @@
+package common
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Debug logging for underlying docker/compose invocations, driven by the
+// global --debug flag. Each instrumented call site logs the full command
+// line (secrets redacted), duration, and exit status, making runtime
+// issues reproducible from the log alone.
+
+var (
+	debugMu     sync.Mutex
+	debugWriter io.Writer
+)
+
+// SetDebugOutput enables command debug logging to w (nil disables). The
+// CLI passes os.Stderr, or a file when DCX_DEBUG_LOG points at one.
+func SetDebugOutput(w io.Writer) {
+	debugMu.Lock()
+	debugWriter = w
+	debugMu.Unlock()
+}
+
+// DebugEnabled reports whether command debug logging is active.
+func DebugEnabled() bool {
+	debugMu.Lock()
+	defer debugMu.Unlock()
+	return debugWriter != nil
+}
+
+// secretValuePattern matches env-style assignments whose key smells like a
+// credential. The value is redacted, the key kept.
+var secretValuePattern = regexp.MustCompile(`(?i)^((?:[A-Z0-9_]*)(?:SECRET|TOKEN|PASSWORD|PASSWD|APIKEY|API_KEY|CREDENTIAL)[A-Z0-9_]*)=.*$`)
+
+// DebugCommand logs the start of a command invocation and returns a
+// completion func to call with the command's error. No-op (returning a
+// no-op func) when debug logging is off.
+func DebugCommand(name string, args []string) func(err error) {
+	debugMu.Lock()
+	w := debugWriter
+	debugMu.Unlock()
+	if w == nil {
+		return func(error) {}
+	}
+
+	line := name + " " + strings.Join(redactArgs(args), " ")
+	start := time.Now()
+	fmt.Fprintf(w, "[debug] + %s\n", line)
+
+	return func(err error) {
+		status := "ok"
+		if err != nil {
+			status = err.Error()
+		}
+		fmt.Fprintf(w, "[debug] - %s (%.2fs, %s)\n", line, time.Since(start).Seconds(), status)
+	}
+}
+
+// redactArgs masks values that look like credentials: env assignments with
+// secret-ish keys (after -e/--env or standalone) and BuildKit --secret
+// sources.
+func redactArgs(args []string) []string {
+	redacted := make([]string, len(args))
+	for i, arg := range args {
+		switch {
+		case secretValuePattern.MatchString(arg):
+			redacted[i] = secretValuePattern.ReplaceAllString(arg, "$1=***")
+		case i > 0 && (args[i-1] == "--secret"):
+			redacted[i] = "***"
+		default:
+			redacted[i] = arg
+		}
+	}
+	return redacted
+}
+
+// OpenDebugTarget resolves where --debug output goes: the file named by
+// DCX_DEBUG_LOG (appended), or stderr.
+func OpenDebugTarget() io.Writer {
+	if path := os.Getenv("DCX_DEBUG_LOG"); path != "" {
+		if f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600); err == nil {
+			return f
+		}
+	}
+	return os.Stderr
+}
@@ -0,0 +1,56 @@
+package common
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRedactArgs(t *testing.T) {
+	args := []string{
+		"run",
+		"-e", "API_TOKEN=hunter2",
+		"-e", "PLAIN=value",
+		"--secret", "id=gh,src=/tmp/secret",
+		"MY_PASSWORD=oops",
+	}
+
+	redacted := redactArgs(args)
+
+	joined := strings.Join(redacted, " ")
+	if strings.Contains(joined, "hunter2") || strings.Contains(joined, "oops") {
+		t.Errorf("credential values leaked: %s", joined)
+	}
+	if !strings.Contains(joined, "API_TOKEN=***") || !strings.Contains(joined, "MY_PASSWORD=***") {
+		t.Errorf("expected masked assignments, got %s", joined)
+	}
+	if !strings.Contains(joined, "PLAIN=value") {
+		t.Errorf("non-credential values must pass through: %s", joined)
+	}
+	if !strings.Contains(joined, "--secret ***") {
+		t.Errorf("--secret source should be masked: %s", joined)
+	}
+}
+
+func TestDebugCommandDisabledByDefault(t *testing.T) {
+	SetDebugOutput(nil)
+	done := DebugCommand("docker", []string{"ps"})
+	done(nil) // must be a no-op, not a panic
+}
+
+func TestDebugCommandLogsStartAndFinish(t *testing.T) {
+	var buf bytes.Buffer
+	SetDebugOutput(&buf)
+	defer SetDebugOutput(nil)
+
+	done := DebugCommand("docker", []string{"ps", "-a"})
+	done(nil)
+
+	out := buf.String()
+	if !strings.Contains(out, "+ docker ps -a") || !strings.Contains(out, "- docker ps -a") {
+		t.Errorf("expected start and finish lines, got %q", out)
+	}
+	if !strings.Contains(out, "ok") {
+		t.Errorf("expected ok status, got %q", out)
+	}
+}
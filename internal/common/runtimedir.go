@@ -0,0 +1,33 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// RuntimeDir returns the host-side directory holding dcx's generated
+// runtime artifacts (compose overrides, build-secret files), one
+// subdirectory per workspace. Files here have deterministic names so
+// out-of-band `docker compose` invocations can reuse them; `dcx prune`
+// clears leftovers from crashed runs.
+func RuntimeDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".dcx", "runtime")
+}
+
+// WorkspaceRuntimeDir returns (and creates) the runtime directory for a
+// workspace. Empty string when the home directory can't be resolved.
+func WorkspaceRuntimeDir(workspaceID string) string {
+	base := RuntimeDir()
+	if base == "" || workspaceID == "" {
+		return ""
+	}
+	dir := filepath.Join(base, workspaceID)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return ""
+	}
+	return dir
+}
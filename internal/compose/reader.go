@@ -0,0 +1,202 @@
+// Package compose provides native reading of docker compose files for
+// lookups that previously shelled out to `docker compose config` — a
+// full CLI invocation and a source of env-interpolation differences.
+// Writing (up/build/down) still goes through the compose CLI.
+package compose
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Project is the merged view of one or more compose files.
+type Project struct {
+	// Name is the explicit top-level project name, if any file sets one
+	// (last file wins, matching compose merge behavior).
+	Name string
+
+	// Services maps service names to their merged definitions.
+	Services map[string]Service
+}
+
+// Service is the subset of a compose service definition dcx reads.
+type Service struct {
+	Image    string
+	Build    *Build
+	EnvFiles []string
+	Profiles []string
+}
+
+// Build is a service's build definition.
+type Build struct {
+	Context    string
+	Dockerfile string
+}
+
+// rawProject mirrors the YAML shape.
+type rawProject struct {
+	Name     string                `yaml:"name"`
+	Services map[string]rawService `yaml:"services"`
+}
+
+type rawService struct {
+	Image    string      `yaml:"image"`
+	Build    rawBuild    `yaml:"build"`
+	EnvFile  interface{} `yaml:"env_file"` // string or []string
+	Profiles []string    `yaml:"profiles"`
+}
+
+// rawBuild accepts both the string and object build forms.
+type rawBuild struct {
+	Context    string `yaml:"context"`
+	Dockerfile string `yaml:"dockerfile"`
+	set        bool
+}
+
+func (b *rawBuild) UnmarshalYAML(value *yaml.Node) error {
+	b.set = true
+	if value.Kind == yaml.ScalarNode {
+		b.Context = value.Value
+		return nil
+	}
+	type plain struct {
+		Context    string `yaml:"context"`
+		Dockerfile string `yaml:"dockerfile"`
+	}
+	var p plain
+	if err := value.Decode(&p); err != nil {
+		return err
+	}
+	b.Context = p.Context
+	b.Dockerfile = p.Dockerfile
+	return nil
+}
+
+// Load reads and merges compose files in order (later files override
+// scalar fields per service), applying compose-style ${VAR} /
+// ${VAR:-default} interpolation from the process environment — which
+// includes any .env files dcx loaded, matching what `docker compose`
+// itself would see.
+func Load(files []string) (*Project, error) {
+	project := &Project{Services: make(map[string]Service)}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read compose file %s: %w", file, err)
+		}
+
+		interpolated, err := Interpolate(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("interpolate compose file %s: %w", file, err)
+		}
+
+		var raw rawProject
+		if err := yaml.Unmarshal([]byte(interpolated), &raw); err != nil {
+			return nil, fmt.Errorf("parse compose file %s: %w", file, err)
+		}
+
+		if raw.Name != "" {
+			project.Name = raw.Name
+		}
+		for name, rawSvc := range raw.Services {
+			svc := project.Services[name]
+			if rawSvc.Image != "" {
+				svc.Image = rawSvc.Image
+			}
+			if rawSvc.Build.set {
+				svc.Build = &Build{Context: rawSvc.Build.Context, Dockerfile: rawSvc.Build.Dockerfile}
+			}
+			if envFiles := envFileList(rawSvc.EnvFile); len(envFiles) > 0 {
+				svc.EnvFiles = envFiles
+			}
+			if len(rawSvc.Profiles) > 0 {
+				svc.Profiles = rawSvc.Profiles
+			}
+			project.Services[name] = svc
+		}
+	}
+
+	return project, nil
+}
+
+// interpolationPattern matches the braced interpolation forms: ${VAR},
+// ${VAR:-default}, ${VAR-default}, ${VAR:?error}, and ${VAR?error}.
+// Groups: 1 = variable name, 2 = operator, 3 = fallback/error message.
+var interpolationPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?:(:?[-?])([^}]*))?\}`)
+
+// bareVarPattern matches the unbraced $VAR form.
+var bareVarPattern = regexp.MustCompile(`\$([A-Za-z_][A-Za-z0-9_]*)`)
+
+// Interpolate applies compose-style variable interpolation against the
+// process environment: braced forms including the :-/- defaults and the
+// :?/? required-variable errors, plus unbraced $VAR. $$ escapes a
+// literal dollar. A failed required variable returns an error carrying
+// the configured message, matching `docker compose config`.
+func Interpolate(content string) (string, error) {
+	content = strings.ReplaceAll(content, "$$", "\x00dollar\x00")
+
+	var requiredErr error
+	content = interpolationPattern.ReplaceAllStringFunc(content, func(match string) string {
+		groups := interpolationPattern.FindStringSubmatch(match)
+		name, op, operand := groups[1], groups[2], groups[3]
+		value, set := os.LookupEnv(name)
+		switch op {
+		case ":-":
+			if value == "" {
+				return operand
+			}
+		case "-":
+			if !set {
+				return operand
+			}
+		case ":?":
+			if value == "" && requiredErr == nil {
+				requiredErr = requiredVariableError(name, operand)
+			}
+		case "?":
+			if !set && requiredErr == nil {
+				requiredErr = requiredVariableError(name, operand)
+			}
+		}
+		return value
+	})
+	if requiredErr != nil {
+		return "", requiredErr
+	}
+
+	content = bareVarPattern.ReplaceAllStringFunc(content, func(match string) string {
+		return os.Getenv(match[1:])
+	})
+
+	return strings.ReplaceAll(content, "\x00dollar\x00", "$"), nil
+}
+
+// requiredVariableError formats a :?/? failure like compose does.
+func requiredVariableError(name, message string) error {
+	if message == "" {
+		return fmt.Errorf("required variable %s is missing a value", name)
+	}
+	return fmt.Errorf("required variable %s is missing a value: %s", name, message)
+}
+
+// envFileList normalizes the env_file field (string or list).
+func envFileList(v interface{}) []string {
+	switch value := v.(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		var result []string
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
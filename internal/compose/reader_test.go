@@ -0,0 +1,97 @@
+package compose
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadMergesFiles(t *testing.T) {
+	dir := t.TempDir()
+	base := writeFile(t, dir, "compose.yml", `
+name: myproj
+services:
+  app:
+    build: ./app
+    env_file: .env.app
+  db:
+    image: postgres:16
+    profiles: ["storage"]
+`)
+	override := writeFile(t, dir, "compose.override.yml", `
+services:
+  app:
+    image: registry.example.com/app:dev
+`)
+
+	project, err := Load([]string{base, override})
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	if project.Name != "myproj" {
+		t.Errorf("name = %q", project.Name)
+	}
+	app := project.Services["app"]
+	if app.Image != "registry.example.com/app:dev" {
+		t.Errorf("override image not applied: %q", app.Image)
+	}
+	if app.Build == nil || app.Build.Context != "./app" {
+		t.Errorf("build not parsed: %+v", app.Build)
+	}
+	if len(app.EnvFiles) != 1 || app.EnvFiles[0] != ".env.app" {
+		t.Errorf("env_file = %v", app.EnvFiles)
+	}
+	db := project.Services["db"]
+	if db.Image != "postgres:16" || len(db.Profiles) != 1 {
+		t.Errorf("db = %+v", db)
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	t.Setenv("COMPOSE_TEST_TAG", "v2")
+	tests := []struct{ in, want string }{
+		{"image: app:${COMPOSE_TEST_TAG}", "image: app:v2"},
+		{"image: app:$COMPOSE_TEST_TAG", "image: app:v2"},
+		{"image: app:${COMPOSE_TEST_MISSING:-latest}", "image: app:latest"},
+		{"image: app:${COMPOSE_TEST_MISSING-fallback}", "image: app:fallback"},
+		{"image: app:${COMPOSE_TEST_TAG:?must be set}", "image: app:v2"},
+		{"literal: $$HOME", "literal: $HOME"},
+	}
+	for _, tt := range tests {
+		got, err := Interpolate(tt.in)
+		if err != nil {
+			t.Errorf("Interpolate(%q) returned error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Interpolate(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestInterpolateRequiredVariables(t *testing.T) {
+	if _, err := Interpolate("image: ${COMPOSE_TEST_MISSING:?database image required}"); err == nil {
+		t.Error("expected error for unset :? variable")
+	} else if !strings.Contains(err.Error(), "database image required") {
+		t.Errorf("error should carry the configured message, got: %v", err)
+	}
+
+	t.Setenv("COMPOSE_TEST_EMPTY", "")
+	if _, err := Interpolate("image: ${COMPOSE_TEST_EMPTY:?empty not allowed}"); err == nil {
+		t.Error(":? must also reject set-but-empty values")
+	}
+	if _, err := Interpolate("image: ${COMPOSE_TEST_EMPTY?unset only}"); err != nil {
+		t.Errorf("? accepts set-but-empty values, got: %v", err)
+	}
+}
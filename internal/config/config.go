@@ -0,0 +1,187 @@
+// Package config loads the per-user dcx configuration file.
+//
+// The file lives at ~/.config/dcx/config.yaml (overridable via DCX_CONFIG)
+// and holds machine-level defaults that don't belong in a project's
+// devcontainer.json. Precedence is: CLI flag > workspace configuration
+// (devcontainer.json / customizations.dcx) > user config > built-in
+// default. Each consumer applies that ordering itself; this package only
+// parses and caches the file.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// UserConfig is the parsed ~/.config/dcx/config.yaml.
+type UserConfig struct {
+	// DockerContext selects the docker context for all invocations,
+	// overridden by the --context flag.
+	DockerContext string `yaml:"dockerContext,omitempty"`
+
+	// RegistryMirrors rewrites feature/image registry hosts, tried in
+	// order before the original reference.
+	RegistryMirrors []string `yaml:"registryMirrors,omitempty"`
+
+	// DefaultMounts are mounts injected into every environment, in
+	// devcontainer.json mount string syntax
+	// ("source=...,target=...,type=...").
+	DefaultMounts []string `yaml:"defaultMounts,omitempty"`
+
+	// DefaultFeatures are features injected into every environment,
+	// keyed by feature reference with their option maps.
+	DefaultFeatures map[string]map[string]interface{} `yaml:"defaultFeatures,omitempty"`
+
+	// DefaultEnv are container environment variables injected into every
+	// environment (workspace values win).
+	DefaultEnv map[string]string `yaml:"defaultEnv,omitempty"`
+
+	// SecurityOpt entries (e.g. "seccomp=/path/profile.json",
+	// "apparmor=docker-default") applied to every container dcx
+	// creates, merged before the workspace's own securityOpt.
+	SecurityOpt []string `yaml:"securityOpt,omitempty"`
+
+	// AutoCaches mounts standard dependency-cache volumes automatically
+	// for toolchains detected in the environment (go, node, rust,
+	// python) — dcx-cache-<tool> volumes at the tools' default paths.
+	AutoCaches bool `yaml:"autoCaches,omitempty"`
+
+	// CacheVolumes maps named docker volumes to container paths mounted
+	// into every dcx container (e.g. dcx-gomod: /go/pkg/mod), sharing
+	// dependency caches across workspaces.
+	CacheVolumes map[string]string `yaml:"cacheVolumes,omitempty"`
+
+	// ImageRetention is how many superseded build images to keep per
+	// workspace after a successful rebuild (default 2; minimum 1).
+	ImageRetention int `yaml:"imageRetention,omitempty"`
+
+	// Policy is the org policy file location (path or http(s) URL)
+	// evaluated on every up.
+	Policy string `yaml:"policy,omitempty"`
+
+	// IdleTimeout is the default duration for `dcx idle-watch` (e.g.
+	// "30m"). Empty means the watcher requires an explicit --timeout.
+	IdleTimeout string `yaml:"idleTimeout,omitempty"`
+
+	// Telemetry enables anonymous usage metrics. Nil or false means off
+	// (opt-in only).
+	Telemetry *bool `yaml:"telemetry,omitempty"`
+
+	// TelemetryEndpoint optionally receives each metrics record as a
+	// JSON POST (team-internal rollout tracking). Only used when
+	// Telemetry is true.
+	TelemetryEndpoint string `yaml:"telemetryEndpoint,omitempty"`
+
+	// SSH holds defaults for the SSH integration.
+	SSH SSHDefaults `yaml:"ssh,omitempty"`
+
+	// Events configures lifecycle event sinks beyond the local log.
+	Events EventSinks `yaml:"events,omitempty"`
+}
+
+// EventSinks are optional delivery targets for lifecycle events.
+type EventSinks struct {
+	// Webhook receives each event as a JSON POST.
+	Webhook string `yaml:"webhook,omitempty"`
+
+	// ExecHook is a shell command run per event (event JSON on stdin,
+	// DCX_EVENT_* in the environment).
+	ExecHook string `yaml:"execHook,omitempty"`
+}
+
+// SSHDefaults holds user-level defaults for SSH access, overridden by
+// per-invocation flags like `dcx up --hosts`.
+type SSHDefaults struct {
+	// BindHost is the host interface the agent SSH port is published on.
+	// Empty means 127.0.0.1.
+	BindHost string `yaml:"bindHost,omitempty"`
+
+	// AllowedCIDRs widen the agent's connection allowlist beyond loopback.
+	AllowedCIDRs []string `yaml:"allowedCIDRs,omitempty"`
+
+	// ControlMaster enables connection multiplexing in generated host
+	// blocks (ControlMaster auto + ControlPath/ControlPersist).
+	ControlMaster bool `yaml:"controlMaster,omitempty"`
+
+	// ServerAliveInterval (seconds) keeps idle connections alive; 0
+	// omits the option.
+	ServerAliveInterval int `yaml:"serverAliveInterval,omitempty"`
+
+	// ForwardAgent overrides agent forwarding in generated host blocks
+	// (default true).
+	ForwardAgent *bool `yaml:"forwardAgent,omitempty"`
+
+	// IdentityFile overrides the advertised identity file.
+	IdentityFile string `yaml:"identityFile,omitempty"`
+
+	// ForwardEnv lists host environment variables forwarded into exec
+	// and shell sessions (in addition to the TERM/LANG/COLORTERM
+	// defaults). Whitelist only — the full host env never leaks.
+	ForwardEnv []string `yaml:"forwardEnv,omitempty"`
+
+	// AuthorizedKeyFiles lists public key files installed into the
+	// remoteUser's ~/.ssh/authorized_keys inside the container (globs
+	// allowed). Default: ~/.ssh/id_*.pub plus SSH-agent identities.
+	AuthorizedKeyFiles []string `yaml:"authorizedKeyFiles,omitempty"`
+}
+
+var (
+	userConfig *UserConfig
+	userOnce   sync.Once
+	userErr    error
+)
+
+// Path returns the user config file location: $DCX_CONFIG if set,
+// otherwise ~/.config/dcx/config.yaml (respecting XDG_CONFIG_HOME).
+func Path() string {
+	if p := os.Getenv("DCX_CONFIG"); p != "" {
+		return p
+	}
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "dcx", "config.yaml")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "dcx", "config.yaml")
+}
+
+// User returns the cached user configuration. A missing file yields an
+// empty config; a malformed file yields an error (surfaced once, then the
+// empty config is used).
+func User() (*UserConfig, error) {
+	userOnce.Do(func() {
+		userConfig, userErr = load(Path())
+	})
+	return userConfig, userErr
+}
+
+// load parses the config file at path. Missing files are not an error.
+func load(path string) (*UserConfig, error) {
+	cfg := &UserConfig{}
+	if path == "" {
+		return cfg, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return cfg, fmt.Errorf("read user config: %w", err)
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return &UserConfig{}, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// TelemetryEnabled reports whether the user opted in to anonymous usage
+// metrics. Defaults to false.
+func (c *UserConfig) TelemetryEnabled() bool {
+	return c.Telemetry != nil && *c.Telemetry
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadMissingFile(t *testing.T) {
+	cfg, err := load(filepath.Join(t.TempDir(), "config.yaml"))
+	if err != nil {
+		t.Fatalf("missing file should not error: %v", err)
+	}
+	if cfg.DockerContext != "" || len(cfg.DefaultMounts) != 0 {
+		t.Errorf("expected empty config, got %+v", cfg)
+	}
+	if cfg.TelemetryEnabled() {
+		t.Error("telemetry should default to off")
+	}
+}
+
+func TestLoadFullConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+dockerContext: colima
+registryMirrors:
+  - mirror.example.com
+defaultMounts:
+  - source=${localEnv:HOME}/.gitconfig,target=/home/vscode/.gitconfig,type=bind
+defaultFeatures:
+  ghcr.io/devcontainers/features/common-utils:2:
+    installZsh: true
+telemetry: true
+ssh:
+  bindHost: 0.0.0.0
+  allowedCIDRs:
+    - 10.0.0.0/24
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := load(path)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	if cfg.DockerContext != "colima" {
+		t.Errorf("dockerContext = %q, want colima", cfg.DockerContext)
+	}
+	if len(cfg.RegistryMirrors) != 1 || cfg.RegistryMirrors[0] != "mirror.example.com" {
+		t.Errorf("registryMirrors = %v", cfg.RegistryMirrors)
+	}
+	if len(cfg.DefaultMounts) != 1 {
+		t.Errorf("defaultMounts = %v", cfg.DefaultMounts)
+	}
+	opts, ok := cfg.DefaultFeatures["ghcr.io/devcontainers/features/common-utils:2"]
+	if !ok || opts["installZsh"] != true {
+		t.Errorf("defaultFeatures = %v", cfg.DefaultFeatures)
+	}
+	if !cfg.TelemetryEnabled() {
+		t.Error("telemetry should be enabled")
+	}
+	if cfg.SSH.BindHost != "0.0.0.0" || len(cfg.SSH.AllowedCIDRs) != 1 {
+		t.Errorf("ssh defaults = %+v", cfg.SSH)
+	}
+}
+
+func TestLoadMalformed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("dockerContext: [unclosed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := load(path); err == nil {
+		t.Error("expected parse error for malformed yaml")
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"os"
 	"os/exec"
+
+	"github.com/griffithind/dcx/internal/common"
 )
 
 // Compose provides operations for Docker Compose projects.
@@ -69,12 +71,16 @@ func (c *Compose) baseArgs() []string {
 
 // run executes a compose command.
 func (c *Compose) run(ctx context.Context, args []string) error {
-	cmd := exec.CommandContext(ctx, "docker", append([]string{"compose"}, args...)...)
+	fullArgs := append([]string{"compose"}, args...)
+	cmd := exec.CommandContext(ctx, "docker", fullArgs...)
 	if c.configDir != "" {
 		cmd.Dir = c.configDir
 	}
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	cmd.Stdin = os.Stdin
-	return cmd.Run()
+	done := common.DebugCommand("docker", fullArgs)
+	err := cmd.Run()
+	done(err)
+	return err
 }
@@ -8,15 +8,20 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/griffithind/dcx/internal/common"
 	"github.com/griffithind/dcx/internal/devcontainer"
+	dcxerrors "github.com/griffithind/dcx/internal/errors"
+	"github.com/griffithind/dcx/internal/hostvm"
 	"github.com/griffithind/dcx/internal/state"
 )
 
@@ -49,11 +54,15 @@ func DockerClient() (*Docker, error) {
 	return docker, dockerErr
 }
 
-// MustDocker returns the singleton Docker instance, panicking if Docker is not accessible.
+// MustDocker returns the singleton Docker instance. When Docker is not
+// accessible it prints a friendly diagnosis pointing at `dcx doctor` and
+// exits with the docker-unreachable code — callers treat Docker as an
+// environmental precondition, and a panic trace helps nobody here.
 func MustDocker() *Docker {
 	d, err := DockerClient()
 	if err != nil {
-		panic(fmt.Sprintf("docker not accessible: %v", err))
+		fmt.Fprintf(os.Stderr, "Error: cannot connect to Docker: %v\n\nHint: Is the Docker daemon running? Run 'dcx doctor' to diagnose.\n", err)
+		os.Exit(dcxerrors.ExitDockerUnreachable)
 	}
 	return d
 }
@@ -80,6 +89,10 @@ type SystemInfo struct {
 	MemTotal     uint64 // Total memory available to Docker in bytes
 	OSType       string // Operating system type (linux, windows)
 	Architecture string // Architecture (x86_64, arm64, etc.)
+	Rootless     bool   // Daemon runs rootless (inside a user namespace)
+	UsernsRemap  bool   // Daemon has userns-remap enabled
+	CDIEnabled   bool   // Daemon has CDI spec directories configured
+	HasNvidiaRT  bool   // Daemon has the nvidia runtime (required for --gpus)
 }
 
 // Info returns system-wide information about Docker.
@@ -93,21 +106,62 @@ func (d *Docker) Info(ctx context.Context) (*SystemInfo, error) {
 	}
 
 	var info struct {
-		NCPU         int    `json:"NCPU"`
-		MemTotal     int64  `json:"MemTotal"`
-		OSType       string `json:"OSType"`
-		Architecture string `json:"Architecture"`
+		NCPU            int      `json:"NCPU"`
+		MemTotal        int64    `json:"MemTotal"`
+		OSType          string   `json:"OSType"`
+		Architecture    string   `json:"Architecture"`
+		SecurityOptions []string `json:"SecurityOptions"`
+		CDISpecDirs     []string `json:"CDISpecDirs"`
+		Runtimes        map[string]struct {
+			Path string `json:"path"`
+		} `json:"Runtimes"`
 	}
 	if err := json.Unmarshal(output, &info); err != nil {
 		return nil, fmt.Errorf("failed to parse Docker info: %w", err)
 	}
 
-	return &SystemInfo{
+	result := &SystemInfo{
 		NCPU:         info.NCPU,
 		MemTotal:     uint64(info.MemTotal),
 		OSType:       info.OSType,
 		Architecture: info.Architecture,
-	}, nil
+	}
+	for _, opt := range info.SecurityOptions {
+		switch {
+		case strings.HasPrefix(opt, "name=rootless"):
+			result.Rootless = true
+		case strings.HasPrefix(opt, "name=userns"):
+			result.UsernsRemap = true
+		}
+	}
+	result.CDIEnabled = len(info.CDISpecDirs) > 0
+	_, result.HasNvidiaRT = info.Runtimes["nvidia"]
+	return result, nil
+}
+
+// DataRootFree returns the free bytes on the filesystem holding the
+// daemon's data root. Only meaningful when the daemon runs on this host
+// (Linux); on macOS the data root lives inside the VM and 0 is returned.
+func (d *Docker) DataRootFree(ctx context.Context) (uint64, error) {
+	if runtime.GOOS != "linux" {
+		return 0, nil
+	}
+	cmd := exec.CommandContext(ctx, "docker", "info", "--format", "{{.DockerRootDir}}")
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get docker data root: %w", err)
+	}
+	root := strings.TrimSpace(string(output))
+	if root == "" {
+		return 0, nil
+	}
+
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(root, &stat); err != nil {
+		// The data root may not be visible from this mount namespace.
+		return 0, nil
+	}
+	return stat.Bavail * uint64(stat.Bsize), nil
 }
 
 // ListContainersWithLabels returns containers matching label filters.
@@ -260,6 +314,105 @@ func (d *Docker) PortMapping(ctx context.Context, containerName string, containe
 	return port, nil
 }
 
+// ImageUserID resolves a username to its UID/GID inside an image by
+// running a throwaway container. Relatively expensive; callers should
+// probe only when the result changes a build decision.
+func (d *Docker) ImageUserID(ctx context.Context, imageRef, username string) (uid, gid int, err error) {
+	cmd := exec.CommandContext(ctx, "docker", "run", "--rm", "--entrypoint", "",
+		imageRef, "sh", "-c", fmt.Sprintf("id -u %s && id -g %s", username, username))
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to resolve user %q in image: %w", username, err)
+	}
+	if _, err := fmt.Sscanf(strings.TrimSpace(string(output)), "%d\n%d", &uid, &gid); err != nil {
+		return 0, 0, fmt.Errorf("unexpected id output: %w", err)
+	}
+	return uid, gid, nil
+}
+
+// ContainerResourceLimits returns the CPU (fractional cores) and memory
+// (bytes) limits configured on a container; zero means unlimited.
+func (d *Docker) ContainerResourceLimits(ctx context.Context, containerID string) (cpus float64, memory int64, err error) {
+	cmd := exec.CommandContext(ctx, "docker", "inspect", "--format", "{{.HostConfig.NanoCpus}} {{.HostConfig.Memory}}", containerID)
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to inspect resource limits: %w", err)
+	}
+	var nanoCPUs int64
+	if _, err := fmt.Sscanf(strings.TrimSpace(string(output)), "%d %d", &nanoCPUs, &memory); err != nil {
+		return 0, 0, fmt.Errorf("failed to parse resource limits: %w", err)
+	}
+	return float64(nanoCPUs) / 1e9, memory, nil
+}
+
+// ContainerEnv returns the container's environment (Config.Env) as a map.
+func (d *Docker) ContainerEnv(ctx context.Context, containerName string) (map[string]string, error) {
+	cmd := exec.CommandContext(ctx, "docker", "inspect", "--format", "{{json .Config.Env}}", containerName)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect container env: %w", err)
+	}
+
+	var envList []string
+	if err := json.Unmarshal(output, &envList); err != nil {
+		return nil, fmt.Errorf("failed to parse container env: %w", err)
+	}
+
+	env := make(map[string]string, len(envList))
+	for _, kv := range envList {
+		if parts := strings.SplitN(kv, "=", 2); len(parts) == 2 {
+			env[parts[0]] = parts[1]
+		}
+	}
+	return env, nil
+}
+
+// PortMappingInfo is one published port on a running container.
+type PortMappingInfo struct {
+	ContainerPort int
+	Protocol      string
+	HostIP        string
+	HostPort      int
+}
+
+// ContainerPortMappings returns every published port mapping on a
+// container from its NetworkSettings.
+func (d *Docker) ContainerPortMappings(ctx context.Context, containerName string) ([]PortMappingInfo, error) {
+	cmd := exec.CommandContext(ctx, "docker", "inspect", "--format", "{{json .NetworkSettings.Ports}}", containerName)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect container ports: %w", err)
+	}
+
+	// Shape: {"80/tcp":[{"HostIp":"0.0.0.0","HostPort":"32768"}], ...}
+	var ports map[string][]struct {
+		HostIP   string `json:"HostIp"`
+		HostPort string `json:"HostPort"`
+	}
+	if err := json.Unmarshal(output, &ports); err != nil {
+		return nil, fmt.Errorf("failed to parse port mappings: %w", err)
+	}
+
+	var result []PortMappingInfo
+	for spec, bindings := range ports {
+		portStr, proto, _ := strings.Cut(spec, "/")
+		containerPort, err := strconv.Atoi(portStr)
+		if err != nil {
+			continue
+		}
+		for _, b := range bindings {
+			hostPort, _ := strconv.Atoi(b.HostPort)
+			result = append(result, PortMappingInfo{
+				ContainerPort: containerPort,
+				Protocol:      proto,
+				HostIP:        b.HostIP,
+				HostPort:      hostPort,
+			})
+		}
+	}
+	return result, nil
+}
+
 // ImageExists checks if an image exists locally.
 func (d *Docker) ImageExists(ctx context.Context, imageRef string) (bool, error) {
 	cmd := exec.CommandContext(ctx, "docker", "image", "inspect", imageRef)
@@ -361,6 +514,96 @@ func (d *Docker) RemoveContainer(ctx context.Context, containerID string, force,
 	return nil
 }
 
+// NetworkExists checks whether a docker network exists.
+func (d *Docker) NetworkExists(ctx context.Context, name string) bool {
+	cmd := exec.CommandContext(ctx, "docker", "network", "inspect", name)
+	return cmd.Run() == nil
+}
+
+// CreateNetwork creates a bridge network with the given labels.
+func (d *Docker) CreateNetwork(ctx context.Context, name string, labels map[string]string) error {
+	args := []string{"network", "create"}
+	for k, v := range labels {
+		args = append(args, "--label", fmt.Sprintf("%s=%s", k, v))
+	}
+	args = append(args, name)
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to create network: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// ConnectNetwork attaches a container to a network with an alias.
+func (d *Docker) ConnectNetwork(ctx context.Context, network, containerName, alias string) error {
+	args := []string{"network", "connect"}
+	if alias != "" {
+		args = append(args, "--alias", alias)
+	}
+	args = append(args, network, containerName)
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		msg := strings.TrimSpace(string(output))
+		if strings.Contains(msg, "already exists") {
+			return nil
+		}
+		return fmt.Errorf("failed to connect network: %s", msg)
+	}
+	return nil
+}
+
+// ListNetworksWithLabel returns network names matching a label filter.
+func (d *Docker) ListNetworksWithLabel(ctx context.Context, labelFilter string) ([]string, error) {
+	cmd := exec.CommandContext(ctx, "docker", "network", "ls", "--filter", "label="+labelFilter, "--format", "{{.Name}}")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list networks: %w", err)
+	}
+	var names []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line != "" {
+			names = append(names, line)
+		}
+	}
+	return names, nil
+}
+
+// RemoveNetwork removes a docker network.
+func (d *Docker) RemoveNetwork(ctx context.Context, name string) error {
+	cmd := exec.CommandContext(ctx, "docker", "network", "rm", name)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to remove network: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// TagImage adds a tag to an existing image.
+func (d *Docker) TagImage(ctx context.Context, source, target string) error {
+	cmd := exec.CommandContext(ctx, "docker", "tag", source, target)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to tag image: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// CommitContainer commits a container's filesystem to an image.
+func (d *Docker) CommitContainer(ctx context.Context, containerID, imageRef string) error {
+	cmd := exec.CommandContext(ctx, "docker", "commit", containerID, imageRef)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to commit container: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// RenameContainer renames a container using Docker CLI.
+func (d *Docker) RenameContainer(ctx context.Context, containerID, newName string) error {
+	cmd := exec.CommandContext(ctx, "docker", "rename", containerID, newName)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to rename container: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
 // KillContainer sends a signal to a container using Docker CLI.
 func (d *Docker) KillContainer(ctx context.Context, containerID, signal string) error {
 	args := []string{"kill"}
@@ -392,10 +635,12 @@ type CreateContainerOptions struct {
 	Init            bool
 	CapAdd      []string
 	CapDrop     []string
+	GroupAdd    []string
 	SecurityOpt []string
 	NetworkMode string
 	IpcMode         string
 	PidMode         string
+	UsernsMode      string
 	ShmSize         int64
 	Devices         []string
 	ExtraHosts      []string
@@ -405,6 +650,15 @@ type CreateContainerOptions struct {
 	Entrypoint      []string
 	Cmd             []string
 	GPURequest      string // GPU request: "all" or count like "1", "2"
+	CPULimit        string // --cpus limit (empty = unlimited)
+	MemoryLimit     string // --memory limit ("4gb"; empty = unlimited)
+	Ulimits         []string
+	DNS             []string
+	EnvFiles        []string
+	Restart         string
+	Platform        string
+	// ExtraArgs are unrecognized runArgs passed to docker run verbatim.
+	ExtraArgs []string
 }
 
 // CreateContainer creates a new container using Docker CLI.
@@ -455,6 +709,11 @@ func (d *Docker) CreateContainer(ctx context.Context, opts CreateContainerOption
 		args = append(args, "--pid", opts.PidMode)
 	}
 
+	// User namespace mode
+	if opts.UsernsMode != "" {
+		args = append(args, "--userns", opts.UsernsMode)
+	}
+
 	// Shared memory size
 	if opts.ShmSize > 0 {
 		args = append(args, "--shm-size", strconv.FormatInt(opts.ShmSize, 10))
@@ -468,6 +727,11 @@ func (d *Docker) CreateContainer(ctx context.Context, opts CreateContainerOption
 		args = append(args, "--cap-drop", cap)
 	}
 
+	// Supplementary groups (e.g. video/render for non-NVIDIA GPU access)
+	for _, g := range opts.GroupAdd {
+		args = append(args, "--group-add", g)
+	}
+
 	// Security options
 	for _, opt := range opts.SecurityOpt {
 		args = append(args, "--security-opt", opt)
@@ -497,6 +761,30 @@ func (d *Docker) CreateContainer(ctx context.Context, opts CreateContainerOption
 		}
 	}
 
+	// Resource limits (opt-in from hostRequirements, or runArgs)
+	if opts.CPULimit != "" {
+		args = append(args, "--cpus", opts.CPULimit)
+	}
+	if opts.MemoryLimit != "" {
+		args = append(args, "--memory", opts.MemoryLimit)
+	}
+
+	for _, u := range opts.Ulimits {
+		args = append(args, "--ulimit", u)
+	}
+	for _, dns := range opts.DNS {
+		args = append(args, "--dns", dns)
+	}
+	for _, f := range opts.EnvFiles {
+		args = append(args, "--env-file", f)
+	}
+	if opts.Restart != "" {
+		args = append(args, "--restart", opts.Restart)
+	}
+	if opts.Platform != "" {
+		args = append(args, "--platform", opts.Platform)
+	}
+
 	// GPU support
 	if opts.GPURequest != "" {
 		if opts.GPURequest == "all" {
@@ -515,11 +803,17 @@ func (d *Docker) CreateContainer(ctx context.Context, opts CreateContainerOption
 	//   HostPort set (or default to container) + Host -> -p <host>:<hostPort>:<container>
 	//   HostPort set (or default to container) + no Host -> -p <hostPort>:<container>
 	for _, p := range opts.Ports {
+		// "/udp" (or another protocol) rides at the end of every form;
+		// tcp is Docker's default and stays implicit.
+		proto := ""
+		if p.Protocol != "" && p.Protocol != "tcp" {
+			proto = "/" + p.Protocol
+		}
 		if p.EphemeralHostPort {
 			if p.Host != "" {
-				args = append(args, "-p", fmt.Sprintf("%s::%d", p.Host, p.ContainerPort))
+				args = append(args, "-p", fmt.Sprintf("%s::%d%s", p.Host, p.ContainerPort, proto))
 			} else {
-				args = append(args, "-p", fmt.Sprintf("::%d", p.ContainerPort))
+				args = append(args, "-p", fmt.Sprintf("::%d%s", p.ContainerPort, proto))
 			}
 			continue
 		}
@@ -528,9 +822,9 @@ func (d *Docker) CreateContainer(ctx context.Context, opts CreateContainerOption
 			hostPort = p.ContainerPort
 		}
 		if p.Host != "" {
-			args = append(args, "-p", fmt.Sprintf("%s:%d:%d", p.Host, hostPort, p.ContainerPort))
+			args = append(args, "-p", fmt.Sprintf("%s:%d:%d%s", p.Host, hostPort, p.ContainerPort, proto))
 		} else {
-			args = append(args, "-p", fmt.Sprintf("%d:%d", hostPort, p.ContainerPort))
+			args = append(args, "-p", fmt.Sprintf("%d:%d%s", hostPort, p.ContainerPort, proto))
 		}
 	}
 
@@ -563,6 +857,9 @@ func (d *Docker) CreateContainer(ctx context.Context, opts CreateContainerOption
 		args = append(args, "--entrypoint", opts.Entrypoint[0])
 	}
 
+	// Unrecognized runArgs pass through verbatim.
+	args = append(args, opts.ExtraArgs...)
+
 	// Image
 	args = append(args, opts.Image)
 
@@ -574,7 +871,9 @@ func (d *Docker) CreateContainer(ctx context.Context, opts CreateContainerOption
 	}
 
 	cmd := exec.CommandContext(ctx, "docker", args...)
+	done := common.DebugCommand("docker", args)
 	output, err := cmd.CombinedOutput()
+	done(err)
 	if err != nil {
 		return "", fmt.Errorf("failed to create container: %s", strings.TrimSpace(string(output)))
 	}
@@ -600,7 +899,10 @@ func formatMount(m *devcontainer.Mount) string {
 	if m.ReadOnly {
 		parts = append(parts, "readonly")
 	}
-	if m.Consistency != "" {
+	// Consistency flags (cached/delegated) are no-ops on virtiofs-backed
+	// VMs (Colima, Lima, Rancher Desktop, recent Docker Desktop) and newer
+	// daemons warn about them, so drop them there.
+	if m.Consistency != "" && !hostvm.Detect(context.Background()).SuppressMountConsistency() {
 		parts = append(parts, fmt.Sprintf("consistency=%s", m.Consistency))
 	}
 
@@ -678,7 +980,112 @@ func (d *Docker) BuildImage(ctx context.Context, opts ImageBuildOptions) error {
 		cmd.Stderr = io.Discard
 	}
 
-	return cmd.Run()
+	done := common.DebugCommand("docker", args)
+	err := cmd.Run()
+	done(err)
+	return err
+}
+
+// DcxImage is one dcx-owned image for listing and retention.
+type DcxImage struct {
+	ID           string
+	Repository   string
+	Tag          string
+	Size         string
+	CreatedSince string
+	WorkspaceID  string // "" for shared images (dcx-features, toolbox)
+}
+
+// ListDcxImages returns every dcx-owned image: per-workspace build
+// images (dcx/<id>), shared feature layers (dcx-features), snapshots,
+// and toolbox overlays. docker images returns newest first; the order is
+// preserved.
+func (d *Docker) ListDcxImages(ctx context.Context) ([]DcxImage, error) {
+	images, err := d.listImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []DcxImage
+	for _, img := range images {
+		workspaceID := ""
+		switch {
+		case strings.HasPrefix(img.Repository, "dcx/"):
+			workspaceID = strings.TrimPrefix(img.Repository, "dcx/")
+		case strings.HasPrefix(img.Repository, "dcx-snapshot/"):
+			workspaceID = strings.TrimPrefix(img.Repository, "dcx-snapshot/")
+		case strings.HasPrefix(img.Repository, "dcx-derived/"):
+			workspaceID = strings.TrimPrefix(img.Repository, "dcx-derived/")
+		case img.Repository == "dcx-features" || img.Repository == "dcx-toolbox":
+		default:
+			continue
+		}
+		result = append(result, DcxImage{
+			ID:           img.ID,
+			Repository:   img.Repository,
+			Tag:          img.Tag,
+			Size:         img.Size,
+			CreatedSince: img.CreatedSince,
+			WorkspaceID:  workspaceID,
+		})
+	}
+	return result, nil
+}
+
+// PruneWorkspaceImages removes a workspace's superseded build images
+// (dcx/<id> derived and -uidN variants) beyond the keep most recent,
+// never touching tags listed in protect. Returns how many were removed.
+func (d *Docker) PruneWorkspaceImages(ctx context.Context, workspaceID string, keep int, protect ...string) int {
+	if keep < 1 {
+		keep = 1
+	}
+	images, err := d.ListDcxImages(ctx)
+	if err != nil {
+		return 0
+	}
+
+	protected := make(map[string]bool, len(protect))
+	for _, ref := range protect {
+		protected[ref] = true
+	}
+
+	removed := 0
+	seen := 0
+	repo := "dcx/" + workspaceID
+	for _, img := range images {
+		if img.Repository != repo {
+			continue
+		}
+		ref := img.Repository + ":" + img.Tag
+		if protected[ref] {
+			continue
+		}
+		seen++
+		if seen <= keep {
+			continue
+		}
+		if err := d.removeImage(ctx, img.ID); err != nil {
+			continue // in use
+		}
+		removed++
+	}
+	return removed
+}
+
+// ListImageTags returns "tag\tsize" lines for every local image in the
+// given repository.
+func (d *Docker) ListImageTags(ctx context.Context, repository string) ([]string, error) {
+	images, err := d.listImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, img := range images {
+		if img.Repository == repository {
+			result = append(result, img.Tag+"\t"+img.Size)
+		}
+	}
+	return result, nil
 }
 
 // CleanupResult contains statistics about cleaned up resources.
@@ -689,10 +1096,11 @@ type CleanupResult struct {
 
 // imageInfo holds parsed image information from docker images command.
 type imageInfo struct {
-	ID         string `json:"ID"`
-	Repository string `json:"Repository"`
-	Tag        string `json:"Tag"`
-	Size       string `json:"Size"`
+	ID           string `json:"ID"`
+	Repository   string `json:"Repository"`
+	Tag          string `json:"Tag"`
+	Size         string `json:"Size"`
+	CreatedSince string `json:"CreatedSince"`
 }
 
 // listImages lists all images using docker images command.
@@ -845,6 +1253,111 @@ func (d *Docker) GetDerivedImageStats(ctx context.Context) (count int, totalSize
 	return count, totalSize, nil
 }
 
+// VolumeInfo describes a named volume associated with a workspace.
+type VolumeInfo struct {
+	Name   string
+	Driver string
+	Size   string // human-readable, "" when unknown
+}
+
+// ListVolumesWithLabel returns volumes carrying the given label filter
+// (e.g. "com.docker.compose.project=myproj"). An empty filter lists all.
+func (d *Docker) ListVolumesWithLabel(ctx context.Context, labelFilter string) ([]VolumeInfo, error) {
+	args := []string{"volume", "ls", "--format", "json"}
+	if labelFilter != "" {
+		args = append(args, "--filter", "label="+labelFilter)
+	}
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list volumes: %w", err)
+	}
+
+	var result []VolumeInfo
+	for _, line := range strings.Split(string(output), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		var v struct {
+			Name   string `json:"Name"`
+			Driver string `json:"Driver"`
+		}
+		if err := json.Unmarshal([]byte(line), &v); err != nil {
+			continue
+		}
+		result = append(result, VolumeInfo{Name: v.Name, Driver: v.Driver})
+	}
+	return result, nil
+}
+
+// VolumeSizes returns a map of volume name to human-readable size via
+// `docker system df -v`. Relatively expensive; callers should invoke it
+// once per listing.
+func (d *Docker) VolumeSizes(ctx context.Context) (map[string]string, error) {
+	cmd := exec.CommandContext(ctx, "docker", "system", "df", "-v", "--format", "json")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get volume sizes: %w", err)
+	}
+
+	var df struct {
+		Volumes []struct {
+			Name string `json:"Name"`
+			Size string `json:"Size"`
+		} `json:"Volumes"`
+	}
+	if err := json.Unmarshal(output, &df); err != nil {
+		return nil, fmt.Errorf("failed to parse docker system df output: %w", err)
+	}
+
+	sizes := make(map[string]string, len(df.Volumes))
+	for _, v := range df.Volumes {
+		sizes[v.Name] = v.Size
+	}
+	return sizes, nil
+}
+
+// ContainerVolumeNames returns the names of named volumes mounted by a
+// container (bind mounts and tmpfs are excluded).
+func (d *Docker) ContainerVolumeNames(ctx context.Context, containerID string) ([]string, error) {
+	cmd := exec.CommandContext(ctx, "docker", "inspect", "--format", "{{json .Mounts}}", containerID)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect container mounts: %w", err)
+	}
+
+	var mounts []struct {
+		Type string `json:"Type"`
+		Name string `json:"Name"`
+	}
+	if err := json.Unmarshal(output, &mounts); err != nil {
+		return nil, fmt.Errorf("failed to parse container mounts: %w", err)
+	}
+
+	var names []string
+	for _, m := range mounts {
+		if m.Type == "volume" && m.Name != "" {
+			names = append(names, m.Name)
+		}
+	}
+	return names, nil
+}
+
+// RemoveVolume removes a named volume.
+func (d *Docker) RemoveVolume(ctx context.Context, name string, force bool) error {
+	args := []string{"volume", "rm"}
+	if force {
+		args = append(args, "-f")
+	}
+	args = append(args, name)
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to remove volume: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
 // LogsOptions contains options for retrieving container logs.
 type LogsOptions struct {
 	Follow     bool
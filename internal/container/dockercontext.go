@@ -0,0 +1,29 @@
+package container
+
+import "os"
+
+// dockerContext is the docker context name selected via `dcx --context`.
+// Empty means the CLI's own selection (docker context use / DOCKER_CONTEXT).
+var dockerContext string
+
+// SetDockerContext selects the docker context used for every docker and
+// docker compose invocation. The selection is exported through the
+// DOCKER_CONTEXT environment variable, which the docker CLI honors for all
+// subcommands (including compose), so it threads through every place dcx
+// shells out without touching individual call sites.
+func SetDockerContext(name string) {
+	dockerContext = name
+	if name != "" {
+		_ = os.Setenv("DOCKER_CONTEXT", name)
+	}
+}
+
+// CurrentDockerContext returns the effective docker context name: the
+// explicit `--context` selection if set, otherwise whatever DOCKER_CONTEXT
+// the environment carries. Empty means the docker CLI default.
+func CurrentDockerContext() string {
+	if dockerContext != "" {
+		return dockerContext
+	}
+	return os.Getenv("DOCKER_CONTEXT")
+}
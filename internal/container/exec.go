@@ -5,6 +5,8 @@ import (
 	"context"
 	"io"
 	"os/exec"
+
+	"github.com/griffithind/dcx/internal/common"
 )
 
 // ExecConfig contains configuration for executing a command in a container.
@@ -64,7 +66,9 @@ func Exec(ctx context.Context, cfg ExecConfig) (int, error) {
 	cmd.Stdout = cfg.Stdout
 	cmd.Stderr = cfg.Stderr
 
+	done := common.DebugCommand("docker", args)
 	err := cmd.Run()
+	done(err)
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			return exitErr.ExitCode(), nil
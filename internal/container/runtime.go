@@ -47,6 +47,19 @@ type UpOptions struct {
 	// on. Empty means 127.0.0.1 (loopback-only); "0.0.0.0" exposes it on
 	// every host interface, gated by the agent's ConnCallback.
 	SSHBindHost string
+
+	// ApplyResourceLimits translates hostRequirements.cpus/memory into
+	// --cpus/--memory limits on the container (opt-in).
+	ApplyResourceLimits bool
+
+	// ForwardSSHAgent mounts the host SSH agent into image builds
+	// (buildSecrets entry "type=ssh").
+	ForwardSSHAgent bool
+
+	// RuntimeSecretFiles maps runtime secret names to temp files. For
+	// compose plans these become native compose secrets: entries, so the
+	// secrets exist before the service entrypoint runs.
+	RuntimeSecretFiles map[string]string
 }
 
 // DownOptions configures the Down operation.
@@ -63,6 +76,13 @@ type BuildOptions struct {
 	NoCache bool
 	// Pull pulls base images before building.
 	Pull bool
+	// Platform is the target platform (e.g. "linux/arm64") for
+	// cross-platform builds. Empty means the daemon's native platform.
+	Platform string
+	// SBOM attaches an SPDX SBOM attestation to built images.
+	SBOM bool
+	// Provenance attaches SLSA provenance to built images.
+	Provenance bool
 }
 
 // ExecOptions configures the Exec operation.
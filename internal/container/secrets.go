@@ -34,6 +34,14 @@ func DCXSecretsDir() string {
 // The owner parameter specifies the user who should own the secrets (e.g., "node", "root").
 // This is a standalone function that can be called without a runtime instance.
 func MountSecretsToContainer(ctx context.Context, containerName string, secretList []secrets.Secret, owner string) error {
+	return MountSecretsToContainerDir(ctx, containerName, secretList, owner, common.SecretsDir)
+}
+
+// MountSecretsToContainerDir mounts secrets under a specific in-container
+// directory (customizations.dcx.secretsDir; default /run/secrets). Each
+// secret may carry its own target filename, mode, and owner; defaults are
+// the secret's name, 0400, and the given owner.
+func MountSecretsToContainerDir(ctx context.Context, containerName string, secretList []secrets.Secret, owner, secretsDir string) error {
 	if len(secretList) == 0 {
 		return nil
 	}
@@ -41,6 +49,9 @@ func MountSecretsToContainer(ctx context.Context, containerName string, secretLi
 	if containerName == "" {
 		return fmt.Errorf("container name not set")
 	}
+	if secretsDir == "" {
+		secretsDir = common.SecretsDir
+	}
 
 	// Default to root if no owner specified
 	if owner == "" {
@@ -49,19 +60,19 @@ func MountSecretsToContainer(ctx context.Context, containerName string, secretLi
 
 	docker := MustDocker()
 
-	// Create /run/secrets directory if it doesn't exist
-	if err := docker.MkdirInContainer(ctx, containerName, common.SecretsDir, "root"); err != nil {
+	// Create the secrets directory if it doesn't exist
+	if err := docker.MkdirInContainer(ctx, containerName, secretsDir, "root"); err != nil {
 		return fmt.Errorf("failed to create secrets directory: %w", err)
 	}
 
 	// Set permissions (755 for directory)
-	if err := docker.ChmodInContainer(ctx, containerName, common.SecretsDir, "755", "root"); err != nil {
+	if err := docker.ChmodInContainer(ctx, containerName, secretsDir, "755", "root"); err != nil {
 		return fmt.Errorf("failed to set directory permissions: %w", err)
 	}
 
 	// Write each secret to the container
 	for _, secret := range secretList {
-		if err := writeSecretToContainer(ctx, docker, containerName, secret, owner); err != nil {
+		if err := writeSecretToContainer(ctx, docker, containerName, secret, owner, secretsDir); err != nil {
 			return fmt.Errorf("failed to write secret %q: %w", secret.Name, err)
 		}
 	}
@@ -69,10 +80,21 @@ func MountSecretsToContainer(ctx context.Context, containerName string, secretLi
 	return nil
 }
 
-// writeSecretToContainer writes a secret to the container's /run/secrets.
+// writeSecretToContainer writes a secret under the secrets directory.
 // Uses docker exec to write directly (docker cp doesn't work with tmpfs mounts).
-func writeSecretToContainer(ctx context.Context, docker *Docker, containerName string, secret secrets.Secret, owner string) error {
-	destPath := filepath.Join(common.SecretsDir, secret.Name)
+func writeSecretToContainer(ctx context.Context, docker *Docker, containerName string, secret secrets.Secret, defaultOwner, secretsDir string) error {
+	target := secret.Target
+	if target == "" {
+		target = secret.Name
+	}
+	destPath := filepath.Join(secretsDir, target)
+
+	// Per-secret targets may nest ("db/password").
+	if dir := filepath.Dir(destPath); dir != secretsDir {
+		if err := docker.MkdirInContainer(ctx, containerName, dir, "root"); err != nil {
+			return err
+		}
+	}
 
 	// Write secret content directly to container using docker exec
 	// (docker cp doesn't work with tmpfs mounts)
@@ -80,13 +102,19 @@ func writeSecretToContainer(ctx context.Context, docker *Docker, containerName s
 		return err
 	}
 
-	// Set ownership to the specified user
+	owner := secret.Owner
+	if owner == "" {
+		owner = defaultOwner
+	}
 	if err := docker.ChownInContainer(ctx, containerName, destPath, owner); err != nil {
 		return err
 	}
 
-	// Set permissions (400 for secret files - read-only by owner)
-	if err := docker.ChmodInContainer(ctx, containerName, destPath, "400", "root"); err != nil {
+	mode := secret.Mode
+	if mode == "" {
+		mode = "400"
+	}
+	if err := docker.ChmodInContainer(ctx, containerName, destPath, mode, "root"); err != nil {
 		return err
 	}
 
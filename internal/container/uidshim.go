@@ -0,0 +1,88 @@
+package container
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/griffithind/dcx/internal/common"
+	"github.com/griffithind/dcx/internal/devcontainer"
+)
+
+// uidSyncShim adjusts the remote user's UID/GID at container start
+// instead of baking a per-host image variant (uidSyncMode: entrypoint).
+// Mirrors the decision logic of the updateUID image layer: no-op when
+// IDs already match or the target UID is taken, then execs the original
+// command. Runs as the container's initial (root) user; sessions still
+// drop to the remote user via the agent.
+const uidSyncShim = `#!/bin/sh
+# Generated by dcx - UID sync entrypoint shim
+set -e
+DCX_USER="$1"; NEW_UID="$2"; NEW_GID="$3"; shift 3
+
+sync_uid() {
+    entry=$(getent passwd "$DCX_USER" 2>/dev/null) || return 0
+    OLD_UID=$(echo "$entry" | cut -d: -f3)
+    OLD_GID=$(echo "$entry" | cut -d: -f4)
+    HOME_DIR=$(echo "$entry" | cut -d: -f6)
+    [ "$OLD_UID" = "$NEW_UID" ] && [ "$OLD_GID" = "$NEW_GID" ] && return 0
+    getent passwd "$NEW_UID" >/dev/null 2>&1 && return 0
+
+    if command -v usermod >/dev/null 2>&1; then
+        getent group "$NEW_GID" >/dev/null 2>&1 || groupmod -g "$NEW_GID" "$(getent group "$OLD_GID" | cut -d: -f1)" 2>/dev/null || true
+        usermod -u "$NEW_UID" "$DCX_USER"
+    else
+        sed -i "s/^\($DCX_USER:[^:]*:\)[^:]*:[^:]*/\1$NEW_UID:$NEW_GID/" /etc/passwd
+        sed -i "s/^\([^:]*:[^:]*:\)$OLD_GID:/\1$NEW_GID:/" /etc/group
+    fi
+    chown -R "$NEW_UID:$NEW_GID" "$HOME_DIR" 2>/dev/null || true
+}
+
+sync_uid
+exec "$@"
+`
+
+// shimContainerPath is where the shim is bind-mounted in the container.
+const shimContainerPath = "/usr/local/share/dcx-uid-sync.sh"
+
+// uidSyncViaEntrypoint reports whether this environment uses the
+// entrypoint shim instead of the UID image layer.
+func (r *UnifiedRuntime) uidSyncViaEntrypoint() bool {
+	return r.resolved.ShouldUpdateUID &&
+		r.resolved.Dcx != nil && r.resolved.Dcx.UIDSyncMode == "entrypoint"
+}
+
+// applyUIDSyncShim writes the shim into the workspace runtime dir,
+// mounts it read-only, and wraps the container's entrypoint with it.
+// Called after overrideCommand handling so the wrapped command is final.
+func (r *UnifiedRuntime) applyUIDSyncShim(createOpts *CreateContainerOptions) error {
+	dir := common.WorkspaceRuntimeDir(r.resolved.ID)
+	if dir == "" {
+		return fmt.Errorf("cannot resolve runtime directory for UID sync shim")
+	}
+	shimPath := filepath.Join(dir, "uid-sync.sh")
+	if err := os.WriteFile(shimPath, []byte(uidSyncShim), 0755); err != nil {
+		return fmt.Errorf("failed to write UID sync shim: %w", err)
+	}
+
+	createOpts.Mounts = append(createOpts.Mounts, devcontainer.Mount{
+		Type:     "bind",
+		Source:   shimPath,
+		Target:   shimContainerPath,
+		ReadOnly: true,
+	})
+
+	// Wrap the existing entrypoint/command: everything after the image
+	// name comes from Entrypoint[1:] (or Cmd), so fold it all in.
+	wrapped := []string{"/bin/sh", shimContainerPath,
+		r.resolved.EffectiveUser,
+		strconv.Itoa(r.resolved.HostUID),
+		strconv.Itoa(r.resolved.HostGID),
+	}
+	wrapped = append(wrapped, createOpts.Entrypoint...)
+	wrapped = append(wrapped, createOpts.Cmd...)
+	createOpts.Entrypoint = wrapped
+	createOpts.Cmd = nil
+	return nil
+}
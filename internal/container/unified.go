@@ -2,7 +2,6 @@ package container
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -11,8 +10,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/griffithind/dcx/internal/audit"
 	"github.com/griffithind/dcx/internal/build"
 	"github.com/griffithind/dcx/internal/common"
+	"github.com/griffithind/dcx/internal/compose"
+	"github.com/griffithind/dcx/internal/config"
 	"github.com/griffithind/dcx/internal/devcontainer"
 	"github.com/griffithind/dcx/internal/features"
 	dcxssh "github.com/griffithind/dcx/internal/ssh"
@@ -32,9 +34,15 @@ type UnifiedRuntime struct {
 	containerName string
 
 	// Runtime state
-	overridePath string
-	derivedImage string
-	sshBindHost  string // "" == 127.0.0.1 (the default)
+	overridePath        string
+	derivedImage        string
+	sshBindHost         string            // "" == 127.0.0.1 (the default)
+	buildPlatform       string            // target platform for cross-builds ("" == native)
+	buildSBOM           bool              // request SBOM attestation on builds
+	buildProvenance     bool              // request SLSA provenance attestation on builds
+	applyResourceLimits bool              // translate hostRequirements into --cpus/--memory
+	runtimeSecretFiles  map[string]string // compose runtime secrets (name -> file)
+	forwardSSHAgent     bool              // mount the host SSH agent into builds
 
 	// For lightweight existing container operations
 	workspacePath  string
@@ -77,6 +85,9 @@ func (r *UnifiedRuntime) Up(ctx context.Context, opts UpOptions) error {
 	}
 
 	r.sshBindHost = opts.SSHBindHost
+	r.applyResourceLimits = opts.ApplyResourceLimits
+	r.runtimeSecretFiles = opts.RuntimeSecretFiles
+	r.forwardSSHAgent = opts.ForwardSSHAgent
 
 	hasFeatures := len(r.resolved.Features) > 0
 
@@ -122,12 +133,13 @@ func (r *UnifiedRuntime) upCompose(ctx context.Context, opts UpOptions, hasFeatu
 		return fmt.Errorf("failed to generate override: %w", err)
 	}
 
-	// Write override to temp file
-	r.overridePath, err = r.writeToTempFile(override, "dcx-override-*.yml")
+	// Write the override under the workspace runtime dir with a
+	// deterministic name so out-of-band `docker compose` runs can reuse
+	// it. Not removed on exit; `dcx prune` clears leftovers.
+	r.overridePath, err = r.writeRuntimeFile("override.yml", override)
 	if err != nil {
 		return err
 	}
-	defer func() { _ = os.Remove(r.overridePath) }()
 
 	// Build compose args
 	args := r.composeBaseArgs(plan)
@@ -148,46 +160,50 @@ func (r *UnifiedRuntime) upCompose(ctx context.Context, opts UpOptions, hasFeatu
 
 // upSingle handles single-container configurations (image or Dockerfile).
 func (r *UnifiedRuntime) upSingle(ctx context.Context, opts UpOptions, hasFeatures bool) error {
-	// Build derived image with features if needed
-	var finalImage string
-	if hasFeatures {
-		// Check if derived image is already cached before building base image
-		derivedTag := r.getDerivedImageTag()
-		if !opts.Rebuild && r.derivedImageExists(ctx, derivedTag) {
-			fmt.Printf("Using cached derived image\n")
-			finalImage = derivedTag
-			r.derivedImage = derivedTag
-		} else {
-			// Need to build - resolve base image first
-			baseImage, err := r.resolveBaseImage(ctx, opts)
+	// Dockerfile plans with features or a UID layer take the single-pass
+	// multi-stage path: one BuildKit build instead of three sequential
+	// ones, no intermediate image writes. Failures fall back to the
+	// sequential path.
+	if plan, ok := r.resolved.Plan.(*devcontainer.DockerfilePlan); ok &&
+		plan.Target == "" && (hasFeatures || r.resolved.ShouldUpdateUID) {
+		if image, err := r.buildSinglePass(ctx, opts, plan); err == nil {
+			r.derivedImage = image
+			containerID, err := r.createContainer(ctx, image)
 			if err != nil {
-				return err
+				return fmt.Errorf("failed to create container: %w", err)
 			}
-			derivedImage, err := r.buildDerivedImage(ctx, baseImage, opts.Rebuild)
-			if err != nil {
-				return fmt.Errorf("failed to build derived image with features: %w", err)
+			if err := MustDocker().StartContainer(ctx, containerID); err != nil {
+				return fmt.Errorf("failed to start container: %w", err)
 			}
-			finalImage = derivedImage
-			r.derivedImage = derivedImage
-		}
-	} else {
-		// No features - resolve base image
-		baseImage, err := r.resolveBaseImage(ctx, opts)
-		if err != nil {
-			return err
-		}
-		finalImage = baseImage
-		// Even without features, we may need to apply UID update layer
-		uidImage, err := r.applyUIDUpdateLayer(ctx, baseImage, opts.Rebuild)
-		if err != nil {
-			return fmt.Errorf("failed to apply UID update: %w", err)
-		}
-		if uidImage != baseImage {
-			finalImage = uidImage
-			r.derivedImage = uidImage
+			r.containerID = containerID
+			if err := r.setupSidecars(ctx); err != nil {
+				return fmt.Errorf("failed to start sidecars: %w", err)
+			}
+			return nil
+		} else {
+			ui.Warning("Single-pass build failed, falling back to sequential builds: %v", err)
 		}
 	}
 
+	finalImage, err := r.FinalImage(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	// A runArgs --network naming a user-defined network that doesn't
+	// exist yet is created here (labeled, so down removes it again);
+	// existing networks — including compose project networks for hybrid
+	// setups — are attached as-is.
+	if err := r.ensureRunArgsNetwork(ctx); err != nil {
+		return err
+	}
+
+	// seccomp/AppArmor profiles must exist before docker run references
+	// them — validate up front for a readable error.
+	if err := devcontainer.ValidateSecurityOpts(r.resolved.SecurityOpt); err != nil {
+		return err
+	}
+
 	// Create the container
 	containerID, err := r.createContainer(ctx, finalImage)
 	if err != nil {
@@ -200,9 +216,212 @@ func (r *UnifiedRuntime) upSingle(ctx context.Context, opts UpOptions, hasFeatur
 	}
 
 	r.containerID = containerID
+
+	// Auxiliary containers share a workspace network with the primary.
+	if err := r.setupSidecars(ctx); err != nil {
+		return fmt.Errorf("failed to start sidecars: %w", err)
+	}
+	return nil
+}
+
+// WorkspaceNetworkName returns the shared network connecting the primary
+// container and its sidecars.
+func WorkspaceNetworkName(workspaceID string) string {
+	return "dcx-" + workspaceID
+}
+
+// ensureRunArgsNetwork creates the user-defined network referenced by
+// runArgs --network when it doesn't exist. The created network carries
+// the workspace labels so `dcx down` removes it; pre-existing networks
+// (e.g. a compose project's) are left alone.
+func (r *UnifiedRuntime) ensureRunArgsNetwork(ctx context.Context) error {
+	runArgs := r.resolved.RunArgs
+	if runArgs == nil || runArgs.NetworkMode == "" {
+		return nil
+	}
+	mode := runArgs.NetworkMode
+	switch mode {
+	case "bridge", "host", "none", "default":
+		return nil
+	}
+	if strings.HasPrefix(mode, "container:") {
+		return nil
+	}
+
+	docker := MustDocker()
+	if docker.NetworkExists(ctx, mode) {
+		return nil
+	}
+	ui.Printf("Creating network %s", mode)
+	return docker.CreateNetwork(ctx, mode, map[string]string{
+		state.LabelManaged:     "true",
+		state.LabelWorkspaceID: r.resolved.ID,
+	})
+}
+
+// setupSidecars creates the workspace network, attaches the primary
+// container, and runs each declared sidecar on it with a DNS alias
+// matching its name. Sidecars carry the workspace labels (primary=false)
+// so status/list/down see them like compose side services.
+func (r *UnifiedRuntime) setupSidecars(ctx context.Context) error {
+	if len(r.resolved.Sidecars) == 0 {
+		return nil
+	}
+
+	docker := MustDocker()
+	network := WorkspaceNetworkName(r.resolved.ID)
+	if !docker.NetworkExists(ctx, network) {
+		if err := docker.CreateNetwork(ctx, network, map[string]string{
+			state.LabelManaged:     "true",
+			state.LabelWorkspaceID: r.resolved.ID,
+		}); err != nil {
+			return err
+		}
+	}
+	if err := docker.ConnectNetwork(ctx, network, r.containerName, r.resolved.ServiceName); err != nil {
+		return err
+	}
+
+	for name, sidecar := range r.resolved.Sidecars {
+		containerName := r.resolved.ServiceName + "-" + name
+		// Replace a stale sidecar from an earlier up.
+		_ = docker.RemoveContainer(ctx, containerName, true, false)
+
+		labels := state.NewContainerLabels()
+		labels.WorkspaceID = r.resolved.ID
+		labels.WorkspaceName = r.resolved.Name
+		labels.WorkspacePath = r.resolved.LocalRoot
+
+		var env []string
+		for k, v := range sidecar.Env {
+			env = append(env, fmt.Sprintf("%s=%s", k, v))
+		}
+		var ports []devcontainer.PortForward
+		for _, spec := range sidecar.Ports {
+			ports = append(ports, devcontainer.ParsePortForwards(spec)...)
+		}
+
+		ui.Printf("Starting sidecar %s (%s)", name, sidecar.Image)
+		_, err := docker.CreateContainer(ctx, CreateContainerOptions{
+			Name:        containerName,
+			Image:       sidecar.Image,
+			Labels:      labels.ToMap(),
+			Env:         env,
+			Ports:       ports,
+			NetworkMode: network,
+			Cmd:         sidecar.Command,
+		})
+		if err != nil {
+			return fmt.Errorf("sidecar %s: %w", name, err)
+		}
+		if err := docker.ConnectNetwork(ctx, network, containerName, name); err != nil {
+			ui.Warning("sidecar %s: network alias failed: %v", name, err)
+		}
+	}
 	return nil
 }
 
+// FinalImage builds (or reuses from cache) the image a container for
+// this configuration runs: base image plus feature layer plus UID layer.
+// Used by the up path and by ephemeral `dcx run --rm` containers.
+func (r *UnifiedRuntime) FinalImage(ctx context.Context, opts UpOptions) (string, error) {
+	hasFeatures := len(r.resolved.Features) > 0
+
+	if hasFeatures {
+		// Check if derived image is already cached before building base image
+		derivedTag := r.getDerivedImageTag()
+		if !opts.Rebuild && r.derivedImageExists(ctx, derivedTag) {
+			fmt.Printf("Using cached derived image\n")
+			r.derivedImage = derivedTag
+			return derivedTag, nil
+		}
+		baseImage, err := r.resolveBaseImage(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		derivedImage, err := r.buildDerivedImage(ctx, baseImage, opts.Rebuild)
+		if err != nil {
+			return "", fmt.Errorf("failed to build derived image with features: %w", err)
+		}
+		r.derivedImage = derivedImage
+		return derivedImage, nil
+	}
+
+	// No features - resolve base image
+	baseImage, err := r.resolveBaseImage(ctx, opts)
+	if err != nil {
+		return "", err
+	}
+	// Even without features, we may need to apply UID update layer
+	uidImage, err := r.applyUIDUpdateLayer(ctx, baseImage, opts.Rebuild)
+	if err != nil {
+		return "", fmt.Errorf("failed to apply UID update: %w", err)
+	}
+	if uidImage != baseImage {
+		r.derivedImage = uidImage
+		return uidImage, nil
+	}
+	return baseImage, nil
+}
+
+// buildSinglePass runs the combined Dockerfile+features+UID build for a
+// Dockerfile plan, honoring the per-workspace derived tag for caching.
+func (r *UnifiedRuntime) buildSinglePass(ctx context.Context, opts UpOptions, plan *devcontainer.DockerfilePlan) (string, error) {
+	tag := r.getDerivedImageTag()
+	if tag == "" {
+		tag = fmt.Sprintf("dcx-derived-temp:%d", time.Now().UnixNano())
+	}
+
+	if !opts.Rebuild && r.derivedImageExists(ctx, tag) {
+		fmt.Printf("Using cached derived image\n")
+		return tag, nil
+	}
+
+	dockerfilePath := plan.Dockerfile
+	if !filepath.IsAbs(dockerfilePath) {
+		dockerfilePath = filepath.Join(r.resolved.ConfigDir, dockerfilePath)
+	}
+	buildCtx := plan.Context
+	if buildCtx == "" {
+		buildCtx = r.resolved.ConfigDir
+	} else if !filepath.IsAbs(buildCtx) {
+		buildCtx = filepath.Join(r.resolved.ConfigDir, buildCtx)
+	}
+
+	metadata, _ := build.GenerateMetadataLabel("", r.resolved.Features, r.resolved.RawConfig)
+
+	singleOpts := build.SinglePassBuildOptions{
+		Tag:           tag,
+		Dockerfile:    dockerfilePath,
+		Context:       buildCtx,
+		Args:          plan.Args,
+		Features:      r.resolved.Features,
+		RemoteUser:    r.resolved.RemoteUser,
+		ContainerUser: r.resolved.ContainerUser,
+		UpdateUID:     r.resolved.ShouldUpdateUID && !r.uidSyncViaEntrypoint(),
+		HostUID:       r.resolved.HostUID,
+		HostGID:       r.resolved.HostGID,
+		Metadata:      metadata,
+		Secrets:       opts.BuildSecrets,
+		Platform:      r.buildPlatform,
+		NoCache:       opts.Rebuild,
+		Progress:      os.Stdout,
+		SBOM:          r.buildSBOM,
+		Provenance:    r.buildProvenance,
+	}
+	if bc := r.resolved.BuildCache; bc != nil {
+		singleOpts.CacheFrom = bc.CacheFrom
+		singleOpts.CacheTo = bc.CacheTo
+		singleOpts.CacheDirs = bc.CacheDirs
+	}
+
+	cliBuilder, ok := r.builder.(*build.CLIBuilder)
+	if !ok {
+		return "", fmt.Errorf("single-pass build requires the CLI builder")
+	}
+	return cliBuilder.BuildSinglePass(ctx, singleOpts)
+}
+
 // resolveBaseImage determines the base image for single-container configs.
 func (r *UnifiedRuntime) resolveBaseImage(ctx context.Context, opts UpOptions) (string, error) {
 	switch plan := r.resolved.Plan.(type) {
@@ -263,15 +482,19 @@ func (r *UnifiedRuntime) buildDockerfile(ctx context.Context, imageTag string, p
 	metadata, _ := build.GenerateMetadataLabel("", nil, r.resolved.RawConfig)
 
 	_, err := r.builder.BuildFromDockerfile(ctx, build.DockerfileBuildOptions{
-		Tag:        imageTag,
-		Dockerfile: dockerfilePath,
-		Context:    buildCtx,
-		Args:       buildArgs,
-		Target:     plan.Target,
-		Progress:   os.Stdout,
-		Metadata:   metadata,
-		Secrets:    buildSecrets,
-		Options:    plan.Options,
+		Tag:             imageTag,
+		Dockerfile:      dockerfilePath,
+		Context:         buildCtx,
+		Args:            buildArgs,
+		Target:          plan.Target,
+		Progress:        os.Stdout,
+		Metadata:        metadata,
+		Secrets:         buildSecrets,
+		Options:         plan.Options,
+		Platform:        r.buildPlatform,
+		SBOM:            r.buildSBOM,
+		Provenance:      r.buildProvenance,
+		ForwardSSHAgent: r.forwardSSHAgent,
 	})
 	return err
 }
@@ -293,11 +516,26 @@ func (r *UnifiedRuntime) buildDerivedImage(ctx context.Context, baseImage string
 		}
 	}
 
-	// Build the derived image using the CLI builder
 	remoteUser := r.resolved.RemoteUser
 	containerUser := r.resolved.ContainerUser
 
-	derivedImage, err := r.builder.BuildWithFeatures(ctx, build.FeatureBuildOptions{
+	// Prefer a workspace-independent tag so identical environments across
+	// a monorepo share one derived image instead of building per-workspace
+	// copies. The key covers everything baked into the image — base
+	// digest, users, the merged metadata label, and the feature set — so
+	// two workspaces only share when the image content really is
+	// identical. Falls back to the per-workspace tag when the base image
+	// isn't resolvable locally.
+	sharedTag := ""
+	if digest, err := MustDocker().GetImageID(ctx, baseImage); err == nil && digest != "" {
+		mergedMetadata, err := build.GenerateMetadataLabel(baseImageMetadata, r.resolved.Features, r.resolved.RawConfig)
+		if err == nil {
+			sharedTag = build.SharedFeatureTag(digest, remoteUser, containerUser, mergedMetadata, r.resolved.Features)
+			derivedTag = sharedTag
+		}
+	}
+
+	featureOpts := build.FeatureBuildOptions{
 		BaseImage:         baseImage,
 		Tag:               derivedTag,
 		Features:          r.resolved.Features,
@@ -307,11 +545,31 @@ func (r *UnifiedRuntime) buildDerivedImage(ctx context.Context, baseImage string
 		Progress:          os.Stdout,
 		BaseImageMetadata: baseImageMetadata,
 		LocalConfig:       r.resolved.RawConfig,
-	})
+		Platform:          r.buildPlatform,
+	}
+	if bc := r.resolved.BuildCache; bc != nil {
+		featureOpts.CacheFrom = bc.CacheFrom
+		featureOpts.CacheTo = bc.CacheTo
+		featureOpts.CacheDirs = bc.CacheDirs
+	}
+	featureOpts.SBOM = r.buildSBOM
+	featureOpts.Provenance = r.buildProvenance
+	derivedImage, err := r.builder.BuildWithFeatures(ctx, featureOpts)
 	if err != nil {
 		return "", fmt.Errorf("failed to build derived image: %w", err)
 	}
 
+	// Mirror the shared image under the per-workspace tag: FinalImage's
+	// early cache check and the retention/cleanup paths all key on
+	// dcx/<workspace-id>, and would otherwise never see shared builds.
+	if sharedTag != "" {
+		if workspaceTag := r.getDerivedImageTag(); workspaceTag != "" && workspaceTag != sharedTag {
+			if err := MustDocker().TagImage(ctx, derivedImage, workspaceTag); err != nil {
+				ui.Warning("Failed to tag %s as %s: %v", derivedImage, workspaceTag, err)
+			}
+		}
+	}
+
 	// Apply UID update layer if needed
 	finalImage, err := r.applyUIDUpdateLayer(ctx, derivedImage, rebuild)
 	if err != nil {
@@ -327,12 +585,39 @@ func (r *UnifiedRuntime) applyUIDUpdateLayer(ctx context.Context, baseImage stri
 		return baseImage, nil
 	}
 
+	// Entrypoint-shim mode adjusts the user at container start instead
+	// of producing a per-host image variant.
+	if r.uidSyncViaEntrypoint() {
+		return baseImage, nil
+	}
+
+	// On rootless or userns-remapped daemons the host UID is already mapped
+	// to the container's root of the user namespace, so bind-mounted files
+	// appear correctly owned without rewriting /etc/passwd. Baking the host
+	// UID into the image there would actually break ownership.
+	if info, err := MustDocker().Info(ctx); err == nil && (info.Rootless || info.UsernsRemap) {
+		ui.Printf("Skipping UID update layer: daemon uses user namespaces")
+		return baseImage, nil
+	}
+
 	effectiveUser := r.resolved.EffectiveUser
 	hostUID := r.resolved.HostUID
 	hostGID := r.resolved.HostGID
 
 	uidTag := fmt.Sprintf("%s-uid%d", baseImage, hostUID)
 
+	// If the image's user already carries the host UID/GID (common on CI
+	// and the Linux default of 1000), the rewrite would be a no-op —
+	// skip the extra build and tag entirely. Probed only when the -uidN
+	// image isn't already cached, since the probe runs a throwaway
+	// container.
+	if !rebuild && !r.derivedImageExists(ctx, uidTag) {
+		if uid, gid, err := MustDocker().ImageUserID(ctx, baseImage, effectiveUser); err == nil && uid == hostUID && gid == hostGID {
+			ui.Printf("Image user %s already matches host %d:%d; skipping UID layer", effectiveUser, hostUID, hostGID)
+			return baseImage, nil
+		}
+	}
+
 	imageUser := r.resolved.ContainerUser
 	if imageUser == "" {
 		imageUser = effectiveUser
@@ -357,6 +642,41 @@ func (r *UnifiedRuntime) applyUIDUpdateLayer(ctx context.Context, baseImage stri
 
 // createContainer creates a single container.
 func (r *UnifiedRuntime) createContainer(ctx context.Context, imageRef string) (string, error) {
+	createOpts := r.BuildCreateOptions(ctx, imageRef)
+	r.auditCreateOptions(createOpts)
+	return MustDocker().CreateContainer(ctx, createOpts)
+}
+
+// auditCreateOptions records security-relevant aspects of a container
+// about to be created to the append-only audit log.
+func (r *UnifiedRuntime) auditCreateOptions(opts CreateContainerOptions) {
+	workspaceID := r.resolved.ID
+	configHash := r.resolved.ConfigHash
+
+	if opts.Privileged {
+		audit.Record(audit.ActionPrivilegedContainer, workspaceID, configHash, nil)
+	}
+	if opts.NetworkMode == "host" {
+		audit.Record(audit.ActionHostNetwork, workspaceID, configHash, nil)
+	}
+	for _, m := range opts.Mounts {
+		if m.Target == "/var/run/docker.sock" || m.Source == "/var/run/docker.sock" {
+			audit.Record(audit.ActionDockerSocketMount, workspaceID, configHash, map[string]string{
+				"source": m.Source,
+			})
+		}
+	}
+	if len(opts.CapAdd) > 0 {
+		audit.Record(audit.ActionCapabilityAdded, workspaceID, configHash, map[string]string{
+			"capabilities": strings.Join(opts.CapAdd, ","),
+		})
+	}
+}
+
+// BuildCreateOptions assembles the full CreateContainerOptions for the
+// primary container without creating anything — shared by createContainer
+// and `dcx up --dry-run`.
+func (r *UnifiedRuntime) BuildCreateOptions(ctx context.Context, imageRef string) CreateContainerOptions {
 	containerName := r.resolved.ServiceName
 	workspaceFolder := r.resolved.WorkspaceFolder
 
@@ -384,6 +704,12 @@ func (r *UnifiedRuntime) createContainer(ctx context.Context, imageRef string) (
 
 	ports := r.buildPortBindings()
 
+	// dcx-level securityOpt defaults precede the workspace's own entries.
+	var defaultSecurityOpt []string
+	if userCfg, err := config.User(); err == nil {
+		defaultSecurityOpt = userCfg.SecurityOpt
+	}
+
 	createOpts := CreateContainerOptions{
 		Name:            containerName,
 		Image:           imageRef,
@@ -396,7 +722,7 @@ func (r *UnifiedRuntime) createContainer(ctx context.Context, imageRef string) (
 		Tmpfs:           mountColl.Tmpfs,
 		Ports:           ports,
 		CapAdd:          r.resolved.CapAdd,
-		SecurityOpt:     r.resolved.SecurityOpt,
+		SecurityOpt:     append(append([]string{}, defaultSecurityOpt...), r.resolved.SecurityOpt...),
 		Privileged:      r.resolved.Privileged,
 		Init:            r.resolved.Init,
 		User:            r.resolved.ContainerUser,
@@ -412,6 +738,9 @@ func (r *UnifiedRuntime) createContainer(ctx context.Context, imageRef string) (
 			for _, name := range reqs.FeatureNames {
 				ui.Warning("  - %s", name)
 			}
+			if info, err := MustDocker().Info(ctx); err == nil && info.Rootless {
+				ui.Warning("Daemon is rootless: privileged mode grants capabilities only within the user namespace; some features may not work")
+			}
 		}
 
 		// Apply feature requirements to container
@@ -433,10 +762,23 @@ func (r *UnifiedRuntime) createContainer(ctx context.Context, imageRef string) (
 		}
 	}
 
-	// Pass GPU requirements to container creation
-	if r.resolved.GPURequirements != nil && r.resolved.GPURequirements.Enabled {
-		if r.resolved.GPURequirements.Count > 0 {
-			createOpts.GPURequest = strconv.Itoa(r.resolved.GPURequirements.Count)
+	// Pass GPU requirements to container creation. Prefer --gpus (requires
+	// the nvidia runtime); fall back to CDI device references when the
+	// daemon only has CDI configured (e.g. podman-style nvidia setups).
+	if gpu := r.resolved.GPURequirements; gpu != nil && gpu.Enabled {
+		if gpu.Optional && !r.gpuSupported(ctx) {
+			// Optional GPU, no usable stack: skip silently; the decision
+			// is recorded in labels for `dcx status`.
+			ui.Printf("GPU requested (optional) but not available on this host; continuing without")
+		} else if cdi := r.gpuCDIDevices(ctx); len(cdi) > 0 {
+			createOpts.Devices = append(createOpts.Devices, cdi...)
+		} else if devices, groups := gpuDRMAccess(); len(devices) > 0 {
+			// AMD ROCm / Intel: no --gpus support; mount the DRM device
+			// nodes and join the groups that own them.
+			createOpts.Devices = append(createOpts.Devices, devices...)
+			createOpts.GroupAdd = append(createOpts.GroupAdd, groups...)
+		} else if gpu.Count > 0 {
+			createOpts.GPURequest = strconv.Itoa(gpu.Count)
 		} else {
 			createOpts.GPURequest = "all"
 		}
@@ -456,21 +798,62 @@ func (r *UnifiedRuntime) createContainer(ctx context.Context, imageRef string) (
 		if runArgs.PidMode != "" {
 			createOpts.PidMode = runArgs.PidMode
 		}
+		if runArgs.UsernsMode != "" {
+			createOpts.UsernsMode = runArgs.UsernsMode
+		}
 		if runArgs.ShmSize > 0 {
 			createOpts.ShmSize = runArgs.ShmSize
 		}
 		if runArgs.User != "" {
 			createOpts.User = runArgs.User
 		}
+		if runArgs.CPUs != "" {
+			createOpts.CPULimit = runArgs.CPUs
+		}
+		if runArgs.Memory != "" {
+			createOpts.MemoryLimit = runArgs.Memory
+		}
+		if runArgs.Restart != "" {
+			createOpts.Restart = runArgs.Restart
+		}
+		if runArgs.Platform != "" {
+			createOpts.Platform = runArgs.Platform
+		}
 		createOpts.CapDrop = append(createOpts.CapDrop, runArgs.CapDrop...)
 		createOpts.Devices = append(createOpts.Devices, runArgs.Devices...)
 		createOpts.ExtraHosts = append(createOpts.ExtraHosts, runArgs.ExtraHosts...)
+		createOpts.Ulimits = append(createOpts.Ulimits, runArgs.Ulimits...)
+		createOpts.DNS = append(createOpts.DNS, runArgs.DNS...)
+		createOpts.EnvFiles = append(createOpts.EnvFiles, runArgs.EnvFiles...)
+		createOpts.GroupAdd = append(createOpts.GroupAdd, runArgs.GroupAdd...)
+		for _, vol := range runArgs.Volumes {
+			if parsed := devcontainer.ParseWorkspaceMount(vol); parsed != nil {
+				createOpts.Mounts = append(createOpts.Mounts, *parsed)
+			}
+		}
+		for k, v := range runArgs.Labels {
+			createOpts.Labels[k] = v
+		}
 		for k, v := range runArgs.Sysctls {
 			if createOpts.Sysctls == nil {
 				createOpts.Sysctls = make(map[string]string)
 			}
 			createOpts.Sysctls[k] = v
 		}
+		if len(runArgs.Unknown) > 0 {
+			ui.Warning("Passing unrecognized runArgs through to docker run: %s", strings.Join(runArgs.Unknown, " "))
+			createOpts.ExtraArgs = append(createOpts.ExtraArgs, runArgs.Unknown...)
+		}
+	}
+
+	// Opt-in: enforce hostRequirements as actual resource limits so the
+	// devcontainer can't starve the host.
+	if r.applyResourceLimits && r.resolved.RawConfig != nil && r.resolved.RawConfig.HostRequirements != nil {
+		hr := r.resolved.RawConfig.HostRequirements
+		if hr.CPUs > 0 {
+			createOpts.CPULimit = strconv.Itoa(hr.CPUs)
+		}
+		createOpts.MemoryLimit = hr.Memory
 	}
 
 	// Handle overrideCommand
@@ -489,7 +872,74 @@ func (r *UnifiedRuntime) createContainer(ctx context.Context, imageRef string) (
 		createOpts.Cmd = []string{"infinity"}
 	}
 
-	return MustDocker().CreateContainer(ctx, createOpts)
+	if r.uidSyncViaEntrypoint() {
+		if err := r.applyUIDSyncShim(&createOpts); err != nil {
+			ui.Warning("UID sync shim unavailable: %v", err)
+		}
+	}
+
+	return createOpts
+}
+
+// gpuSupported reports whether any GPU path is usable: the nvidia
+// runtime, CDI devices, or DRM device nodes on the host.
+func (r *UnifiedRuntime) gpuSupported(ctx context.Context) bool {
+	if info, err := MustDocker().Info(ctx); err == nil && (info.HasNvidiaRT || info.CDIEnabled) {
+		return true
+	}
+	devices, _ := gpuDRMAccess()
+	return len(devices) > 0
+}
+
+// gpuCDIDevices returns CDI device references satisfying the GPU
+// requirement when the daemon cannot honor --gpus but has CDI configured.
+// Returns nil when --gpus should be used (or no GPU is required).
+func (r *UnifiedRuntime) gpuCDIDevices(ctx context.Context) []string {
+	gpu := r.resolved.GPURequirements
+	if gpu == nil || !gpu.Enabled {
+		return nil
+	}
+	info, err := MustDocker().Info(ctx)
+	if err != nil || info.HasNvidiaRT || !info.CDIEnabled {
+		return nil
+	}
+	if gpu.Count > 0 {
+		devices := make([]string, gpu.Count)
+		for i := range devices {
+			devices[i] = fmt.Sprintf("nvidia.com/gpu=%d", i)
+		}
+		return devices
+	}
+	return []string{"nvidia.com/gpu=all"}
+}
+
+// gpuDRMAccess returns the device nodes and supplementary groups needed
+// for AMD ROCm or Intel GPU access. Empty when an NVIDIA stack is present
+// (handled via --gpus/CDI) or no DRM devices exist.
+func gpuDRMAccess() (devices, groups []string) {
+	vendors := devcontainer.DetectGPUVendors()
+	hasROCm, hasDRI := false, false
+	for _, v := range vendors {
+		switch v {
+		case "nvidia":
+			return nil, nil
+		case "rocm":
+			hasROCm = true
+		case "dri":
+			hasDRI = true
+		}
+	}
+	if hasROCm {
+		devices = append(devices, "/dev/kfd")
+		groups = append(groups, "video", "render")
+	}
+	if hasDRI {
+		devices = append(devices, "/dev/dri")
+		if len(groups) == 0 {
+			groups = append(groups, "video", "render")
+		}
+	}
+	return devices, groups
 }
 
 // buildLabels builds the container labels.
@@ -500,6 +950,18 @@ func (r *UnifiedRuntime) buildLabels() map[string]string {
 	l.WorkspacePath = r.resolved.LocalRoot
 	l.ConfigPath = r.resolved.ConfigPath
 	l.HashConfig = r.resolved.ConfigHash
+	l.HashStructural = r.resolved.StructuralHash
+	if r.resolved.RawConfig != nil {
+		l.ShutdownAction = r.resolved.RawConfig.ShutdownAction
+	}
+	if gpu := r.resolved.GPURequirements; gpu != nil && gpu.Enabled {
+		if r.gpuSupported(context.Background()) {
+			l.GPUDecision = "enabled"
+		} else {
+			l.GPUDecision = "unavailable"
+		}
+	}
+	l.DockerContext = CurrentDockerContext()
 	l.BuildMethod = string(r.resolved.Plan.Type())
 	l.IsPrimary = true
 
@@ -540,9 +1002,12 @@ func (r *UnifiedRuntime) buildMounts() mountCollections {
 		Tmpfs: make(map[string]string),
 	}
 
-	// Add tmpfs for /run/secrets only when runtime secrets are configured.
-	// This ensures secrets are stored in memory and not persisted to the container's writable layer.
-	if len(r.resolved.RuntimeSecrets) > 0 {
+	// Add a tmpfs for the secrets directory only when runtime secrets are
+	// configured, so values live in memory rather than the writable
+	// layer. A custom secretsDir opts out of tmpfs entirely — the
+	// documented path for images/runtimes without tmpfs support, at the
+	// cost of secrets touching the writable layer.
+	if len(r.resolved.RuntimeSecrets) > 0 && r.resolved.SecretsDir == "" {
 		result.Tmpfs[common.SecretsDir] = "rw,noexec,nosuid,size=1m"
 	}
 
@@ -555,6 +1020,73 @@ func (r *UnifiedRuntime) buildMounts() mountCollections {
 			result.Mounts = append(result.Mounts, m)
 		}
 	}
+
+	// Shared cache volumes from the user config (go module cache, cargo
+	// registry, ...) mount into every container unless the workspace
+	// already claims the target path. autoCaches adds toolchain-detected
+	// defaults underneath the explicit list.
+	if userCfg, err := config.User(); err == nil && (len(userCfg.CacheVolumes) > 0 || userCfg.AutoCaches) {
+		claimed := make(map[string]bool, len(result.Mounts))
+		for _, m := range result.Mounts {
+			claimed[m.Target] = true
+		}
+
+		cacheVolumes := make(map[string]string)
+		if userCfg.AutoCaches {
+			for volume, target := range r.autoCacheVolumes() {
+				cacheVolumes[volume] = target
+			}
+		}
+		for volume, target := range userCfg.CacheVolumes {
+			cacheVolumes[volume] = target
+		}
+
+		for volume, target := range cacheVolumes {
+			if claimed[target] {
+				continue
+			}
+			claimed[target] = true
+			result.Mounts = append(result.Mounts, devcontainer.Mount{
+				Type:   "volume",
+				Source: volume,
+				Target: target,
+			})
+		}
+	}
+	return result
+}
+
+// autoCacheMatchers maps toolchain markers (matched against feature IDs
+// and the base image reference — specific substrings, so "mongo" doesn't
+// read as go) to their cache volume and default path.
+var autoCacheMatchers = []struct {
+	markers []string
+	volume  string
+	target  string
+}{
+	{[]string{"features/go", "golang", "devcontainers/go"}, "dcx-cache-go", "/go/pkg/mod"},
+	{[]string{"features/node", "javascript-node", "nodejs"}, "dcx-cache-npm", "/home/node/.npm"},
+	{[]string{"features/rust", "devcontainers/rust"}, "dcx-cache-cargo", "/usr/local/cargo/registry"},
+	{[]string{"features/python", "devcontainers/python"}, "dcx-cache-pip", "/root/.cache/pip"},
+}
+
+// autoCacheVolumes detects toolchains from the resolved features and
+// base image and returns the matching cache volume mounts.
+func (r *UnifiedRuntime) autoCacheVolumes() map[string]string {
+	haystack := strings.ToLower(r.resolved.BaseImage)
+	for _, f := range r.resolved.Features {
+		haystack += " " + strings.ToLower(f.ID)
+	}
+
+	result := make(map[string]string)
+	for _, m := range autoCacheMatchers {
+		for _, marker := range m.markers {
+			if strings.Contains(haystack, marker) {
+				result[m.volume] = m.target
+				break
+			}
+		}
+	}
 	return result
 }
 
@@ -580,8 +1112,16 @@ func (r *UnifiedRuntime) buildEnvironment() []string {
 func (r *UnifiedRuntime) buildPortBindings() []devcontainer.PortForward {
 	var ports []devcontainer.PortForward
 
-	// Add forward ports (bind to all interfaces by default)
-	ports = append(ports, r.resolved.ForwardPorts...)
+	// Add forward ports. The docker default binds all interfaces;
+	// customizations.dcx.portPolicy "loopback" narrows entries without an
+	// explicit host to 127.0.0.1.
+	loopbackOnly := r.resolved.Dcx != nil && r.resolved.Dcx.PortPolicy == "loopback"
+	for _, pf := range r.resolved.ForwardPorts {
+		if loopbackOnly && pf.Host == "" {
+			pf.Host = "127.0.0.1"
+		}
+		ports = append(ports, pf)
+	}
 
 	// Add app ports (bound to localhost for security)
 	for _, ap := range r.resolved.AppPorts {
@@ -638,6 +1178,21 @@ func (r *UnifiedRuntime) sshPortBinding() devcontainer.PortForward {
 	return pf
 }
 
+// CreateFromImage creates and starts the primary container from a
+// pre-built image, skipping every build step. Used by snapshot restore,
+// where the image already contains the desired filesystem state.
+func (r *UnifiedRuntime) CreateFromImage(ctx context.Context, image string) error {
+	containerID, err := r.createContainer(ctx, image)
+	if err != nil {
+		return fmt.Errorf("failed to create container: %w", err)
+	}
+	if err := MustDocker().StartContainer(ctx, containerID); err != nil {
+		return fmt.Errorf("failed to start container: %w", err)
+	}
+	r.containerID = containerID
+	return nil
+}
+
 // Start implements ContainerRuntime.Start.
 func (r *UnifiedRuntime) Start(ctx context.Context) error {
 	if r.resolved != nil {
@@ -658,12 +1213,30 @@ func (r *UnifiedRuntime) Start(ctx context.Context) error {
 	return MustDocker().StartContainer(ctx, r.containerName)
 }
 
+// managedComposeServices returns the subset of services dcx manages: the
+// primary service plus runServices. Empty means the whole project.
+func managedComposeServices(plan *devcontainer.ComposePlan) []string {
+	if plan == nil || len(plan.RunServices) == 0 {
+		return nil
+	}
+	services := append([]string{}, plan.RunServices...)
+	for _, svc := range services {
+		if svc == plan.Service {
+			return services
+		}
+	}
+	return append(services, plan.Service)
+}
+
 // Stop implements ContainerRuntime.Stop.
 func (r *UnifiedRuntime) Stop(ctx context.Context) error {
 	if r.resolved != nil {
 		if plan, ok := r.resolved.Plan.(*devcontainer.ComposePlan); ok {
 			args := r.composeBaseArgs(plan)
 			args = append(args, "stop")
+			// With runServices, only the managed subset stops;
+			// independently managed services are left alone.
+			args = append(args, managedComposeServices(plan)...)
 			return r.runCompose(ctx, args)
 		}
 	}
@@ -681,6 +1254,18 @@ func (r *UnifiedRuntime) Stop(ctx context.Context) error {
 func (r *UnifiedRuntime) Down(ctx context.Context, opts DownOptions) error {
 	if r.resolved != nil {
 		if plan, ok := r.resolved.Plan.(*devcontainer.ComposePlan); ok {
+			// With runServices, remove only the managed subset (`compose
+			// rm -sf` — `down` always affects the whole project).
+			if services := managedComposeServices(plan); len(services) > 0 {
+				args := r.composeBaseArgs(plan)
+				args = append(args, "rm", "-s", "-f")
+				if opts.RemoveVolumes {
+					args = append(args, "-v")
+				}
+				args = append(args, services...)
+				return r.runCompose(ctx, args)
+			}
+
 			args := r.composeBaseArgs(plan)
 			args = append(args, "down")
 			if opts.RemoveVolumes {
@@ -708,6 +1293,15 @@ func (r *UnifiedRuntime) Build(ctx context.Context, opts BuildOptions) error {
 		return fmt.Errorf("no resolved configuration - use NewUnifiedRuntime")
 	}
 
+	if opts.Platform != "" {
+		r.buildPlatform = opts.Platform
+		// compose build and docker pull have no per-invocation platform
+		// flag; DOCKER_DEFAULT_PLATFORM covers them.
+		_ = os.Setenv("DOCKER_DEFAULT_PLATFORM", opts.Platform)
+	}
+	r.buildSBOM = opts.SBOM
+	r.buildProvenance = opts.Provenance
+
 	if plan, ok := r.resolved.Plan.(*devcontainer.ComposePlan); ok {
 		args := r.composeBaseArgs(plan)
 		args = append(args, "build")
@@ -751,7 +1345,8 @@ func (r *UnifiedRuntime) composeBaseArgs(plan *devcontainer.ComposePlan) []strin
 }
 
 func (r *UnifiedRuntime) runCompose(ctx context.Context, args []string) error {
-	cmd := exec.CommandContext(ctx, "docker", append([]string{"compose"}, args...)...)
+	fullArgs := append([]string{"compose"}, args...)
+	cmd := exec.CommandContext(ctx, "docker", fullArgs...)
 	if r.resolved != nil {
 		cmd.Dir = r.resolved.ConfigDir
 	} else if r.workspacePath != "" {
@@ -760,7 +1355,10 @@ func (r *UnifiedRuntime) runCompose(ctx context.Context, args []string) error {
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	cmd.Stdin = os.Stdin
-	return cmd.Run()
+	done := common.DebugCommand("docker", fullArgs)
+	err := cmd.Run()
+	done(err)
+	return err
 }
 
 func (r *UnifiedRuntime) generateComposeOverride(plan *devcontainer.ComposePlan, buildSecrets map[string]string) (string, error) {
@@ -789,6 +1387,41 @@ func (r *UnifiedRuntime) generateComposeOverride(plan *devcontainer.ComposePlan,
 		fmt.Fprintf(&sb, "    image: %s\n", r.derivedImage)
 	}
 
+	// securityOpt (seccomp/AppArmor profiles and other options) carries
+	// into the compose service as security_opt entries.
+	securityOpts := append([]string{}, r.resolved.SecurityOpt...)
+	if userCfg, err := config.User(); err == nil && len(userCfg.SecurityOpt) > 0 {
+		securityOpts = append(userCfg.SecurityOpt, securityOpts...)
+	}
+	if len(securityOpts) > 0 {
+		sb.WriteString("    security_opt:\n")
+		for _, opt := range securityOpts {
+			fmt.Fprintf(&sb, "      - %q\n", opt)
+		}
+	}
+
+	// Runtime secrets ride as native compose secrets so they exist
+	// before the service entrypoint runs (the single-container path
+	// mounts them post-start instead).
+	if len(r.runtimeSecretFiles) > 0 {
+		sb.WriteString("    secrets:\n")
+		for name := range r.runtimeSecretFiles {
+			fmt.Fprintf(&sb, "      - source: dcx_%s\n", name)
+			fmt.Fprintf(&sb, "        target: %s\n", name)
+		}
+	}
+
+	// overrideCommand: for compose plans the spec default is false (the
+	// service's own entrypoint runs); an explicit true keeps the
+	// container alive exactly like the single-container path. The
+	// override only exists in dcx's generated file, so a plain
+	// docker compose up without it restores the service's original
+	// entrypoint/command.
+	if r.resolved.RawConfig != nil && r.resolved.RawConfig.OverrideCommand != nil && *r.resolved.RawConfig.OverrideCommand {
+		sb.WriteString("    entrypoint: [\"sleep\"]\n")
+		sb.WriteString("    command: [\"infinity\"]\n")
+	}
+
 	// Add build secrets if any (for compose builds without features)
 	if len(buildSecrets) > 0 && r.derivedImage == "" {
 		sb.WriteString("    build:\n")
@@ -801,11 +1434,17 @@ func (r *UnifiedRuntime) generateComposeOverride(plan *devcontainer.ComposePlan,
 	// Ports — forwardPorts plus the dcx SSH listener.
 	sb.WriteString("    ports:\n")
 	for _, port := range r.resolved.ForwardPorts {
-		if port.HostPort == port.ContainerPort {
-			fmt.Fprintf(&sb, "      - \"%d\"\n", port.ContainerPort)
-		} else {
-			fmt.Fprintf(&sb, "      - \"%d:%d\"\n", port.HostPort, port.ContainerPort)
+		spec := fmt.Sprintf("%d", port.ContainerPort)
+		if port.HostPort != port.ContainerPort {
+			spec = fmt.Sprintf("%d:%d", port.HostPort, port.ContainerPort)
+		}
+		if port.Host != "" {
+			spec = fmt.Sprintf("%s:%d:%d", port.Host, port.HostPort, port.ContainerPort)
+		}
+		if port.Protocol != "" && port.Protocol != "tcp" {
+			spec += "/" + port.Protocol
 		}
+		fmt.Fprintf(&sb, "      - %q\n", spec)
 	}
 	// dcx SSH: "<bind>::48022" (Docker picks ephemeral) or "<bind>:<pref>:48022"
 	// (we have a remembered port from a prior up for this workspace).
@@ -816,6 +1455,28 @@ func (r *UnifiedRuntime) generateComposeOverride(plan *devcontainer.ComposePlan,
 		fmt.Fprintf(&sb, "      - \"%s:%d:%d\"\n", ssh.Host, ssh.HostPort, ssh.ContainerPort)
 	}
 
+	// GPU via CDI device references (compose supports CDI names under
+	// devices:). Only emitted when --gpus is unavailable on the daemon.
+	// For AMD ROCm / Intel the DRM device nodes and owning groups are
+	// passed instead.
+	if r.resolved.GPURequirements != nil && r.resolved.GPURequirements.Enabled {
+		if cdi := r.gpuCDIDevices(context.Background()); len(cdi) > 0 {
+			sb.WriteString("    devices:\n")
+			for _, d := range cdi {
+				fmt.Fprintf(&sb, "      - %q\n", d)
+			}
+		} else if devices, groups := gpuDRMAccess(); len(devices) > 0 {
+			sb.WriteString("    devices:\n")
+			for _, d := range devices {
+				fmt.Fprintf(&sb, "      - %q\n", d)
+			}
+			sb.WriteString("    group_add:\n")
+			for _, g := range groups {
+				fmt.Fprintf(&sb, "      - %q\n", g)
+			}
+		}
+	}
+
 	// Add mounts
 	mountColl := r.buildMounts()
 	if len(mountColl.Mounts) > 0 {
@@ -843,10 +1504,16 @@ func (r *UnifiedRuntime) generateComposeOverride(plan *devcontainer.ComposePlan,
 	}
 
 	// Add top-level secrets definitions if any
-	if len(buildSecrets) > 0 && r.derivedImage == "" {
+	if (len(buildSecrets) > 0 && r.derivedImage == "") || len(r.runtimeSecretFiles) > 0 {
 		sb.WriteString("secrets:\n")
-		for name, path := range buildSecrets {
-			fmt.Fprintf(&sb, "  %s:\n", name)
+		if len(buildSecrets) > 0 && r.derivedImage == "" {
+			for name, path := range buildSecrets {
+				fmt.Fprintf(&sb, "  %s:\n", name)
+				fmt.Fprintf(&sb, "    file: %s\n", path)
+			}
+		}
+		for name, path := range r.runtimeSecretFiles {
+			fmt.Fprintf(&sb, "  dcx_%s:\n", name)
 			fmt.Fprintf(&sb, "    file: %s\n", path)
 		}
 	}
@@ -860,7 +1527,7 @@ func (r *UnifiedRuntime) ensureServicesBuilt(ctx context.Context, plan *devconta
 	// Add build secrets override if any
 	if len(buildSecrets) > 0 {
 		override := r.generateBuildSecretsOverride(plan, buildSecrets)
-		overridePath, err := r.writeToTempFile(override, "dcx-build-secrets-*.yml")
+		overridePath, err := r.writeRuntimeFile("build-secrets.yml", override)
 		if err != nil {
 			return err
 		}
@@ -943,29 +1610,12 @@ func (r *UnifiedRuntime) getComposeBaseImage(ctx context.Context, plan *devconta
 		return "", fmt.Errorf("no compose files specified")
 	}
 
-	// Use docker compose config to get fully resolved configuration
-	args := []string{"compose"}
-	for _, f := range paths {
-		args = append(args, "-f", f)
-	}
-	args = append(args, "config", "--format", "json")
-
-	cmd := exec.CommandContext(ctx, "docker", args...)
-	cmd.Dir = filepath.Dir(paths[0])
-	output, err := cmd.Output()
+	// Read the compose files natively (env interpolation runs against
+	// the same process env compose would see), avoiding a full
+	// `docker compose config` CLI round-trip.
+	project, err := compose.Load(paths)
 	if err != nil {
-		return "", fmt.Errorf("failed to get compose config: %w", err)
-	}
-
-	var config struct {
-		Services map[string]struct {
-			Image string `json:"image"`
-		} `json:"services"`
-		Name string `json:"name"`
-	}
-
-	if err := json.Unmarshal(output, &config); err != nil {
-		return "", fmt.Errorf("failed to parse compose config: %w", err)
+		return "", fmt.Errorf("failed to read compose files: %w", err)
 	}
 
 	serviceName := plan.Service
@@ -973,7 +1623,7 @@ func (r *UnifiedRuntime) getComposeBaseImage(ctx context.Context, plan *devconta
 		return "", fmt.Errorf("no primary service specified")
 	}
 
-	svc, ok := config.Services[serviceName]
+	svc, ok := project.Services[serviceName]
 	if !ok {
 		return "", fmt.Errorf("service %q not found in compose file", serviceName)
 	}
@@ -985,7 +1635,7 @@ func (r *UnifiedRuntime) getComposeBaseImage(ctx context.Context, plan *devconta
 	// Service uses build - compute built image name
 	projectName := plan.ProjectName
 	if projectName == "" {
-		projectName = config.Name
+		projectName = project.Name
 	}
 	if projectName == "" {
 		projectName = r.resolved.ServiceName
@@ -993,8 +1643,26 @@ func (r *UnifiedRuntime) getComposeBaseImage(ctx context.Context, plan *devconta
 	return fmt.Sprintf("%s-%s", projectName, serviceName), nil
 }
 
-// getDerivedImageTag returns the expected tag for the derived image.
-// This mirrors the logic in buildDerivedImage but without building.
+// DerivedImageTag exposes the expected derived image tag for dry-run
+// reporting.
+func (r *UnifiedRuntime) DerivedImageTag() string {
+	return r.getDerivedImageTag()
+}
+
+// RenderComposeOverride exposes the generated compose override content
+// for dry-run reporting (compose plans only).
+func (r *UnifiedRuntime) RenderComposeOverride() (string, error) {
+	plan, ok := r.resolved.Plan.(*devcontainer.ComposePlan)
+	if !ok {
+		return "", fmt.Errorf("not a compose plan")
+	}
+	return r.generateComposeOverride(plan, nil)
+}
+
+// getDerivedImageTag returns the per-workspace tag for the derived
+// image. buildDerivedImage prefers the shared dcx-features tag but
+// mirrors the result under this tag, so cache pre-checks and
+// cleanup/retention keyed on dcx/<workspace-id> keep working.
 func (r *UnifiedRuntime) getDerivedImageTag() string {
 	if r.resolved.DerivedImage != "" {
 		return r.resolved.DerivedImage
@@ -1018,20 +1686,38 @@ func (r *UnifiedRuntime) derivedImageExists(ctx context.Context, tag string) boo
 	return err == nil && exists
 }
 
-func (r *UnifiedRuntime) writeToTempFile(content, pattern string) (string, error) {
-	tmpFile, err := os.CreateTemp("", pattern)
-	if err != nil {
-		return "", fmt.Errorf("failed to create temp file: %w", err)
+// writeRuntimeFile writes content to <runtime-dir>/<workspace>/<name>
+// atomically (temp + rename) with owner-only permissions. Falls back to
+// an os.TempDir file when the runtime dir is unavailable.
+func (r *UnifiedRuntime) writeRuntimeFile(name, content string) (string, error) {
+	workspaceID := ""
+	if r.resolved != nil {
+		workspaceID = r.resolved.ID
 	}
-
-	if _, err := tmpFile.WriteString(content); err != nil {
-		_ = tmpFile.Close()
-		_ = os.Remove(tmpFile.Name())
-		return "", fmt.Errorf("failed to write temp file: %w", err)
+	dir := common.WorkspaceRuntimeDir(workspaceID)
+	if dir == "" {
+		tmpFile, err := os.CreateTemp("", "dcx-"+name+"-*")
+		if err != nil {
+			return "", fmt.Errorf("failed to create temp file: %w", err)
+		}
+		defer func() { _ = tmpFile.Close() }()
+		if _, err := tmpFile.WriteString(content); err != nil {
+			_ = os.Remove(tmpFile.Name())
+			return "", fmt.Errorf("failed to write temp file: %w", err)
+		}
+		return tmpFile.Name(), nil
 	}
 
-	_ = tmpFile.Close()
-	return tmpFile.Name(), nil
+	path := filepath.Join(dir, name)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(content), 0600); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return "", fmt.Errorf("failed to place %s: %w", name, err)
+	}
+	return path, nil
 }
 
 // Ensure UnifiedRuntime implements ContainerRuntime.
@@ -315,7 +315,7 @@ func TestBuildMounts(t *testing.T) {
 			name: "with runtime secrets adds /run/secrets tmpfs",
 			resolved: &devcontainer.ResolvedDevContainer{
 				RuntimeSecrets: map[string]devcontainer.SecretConfig{
-					"MY_SECRET": "echo secret-value",
+					"MY_SECRET": {Command: "echo secret-value"},
 				},
 			},
 			wantMounts: nil,
@@ -0,0 +1,222 @@
+// Package daemon implements dcxd, the optional long-running background
+// process. It owns the cross-workspace state cache, periodic idle
+// detection, and (eventually) port forwarding and prebuild scheduling,
+// and serves a newline-delimited JSON protocol on a unix socket. The CLI
+// works fully without the daemon; when the socket answers, commands like
+// `dcx list` read the cache instead of querying Docker.
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/griffithind/dcx/internal/config"
+	"github.com/griffithind/dcx/internal/container"
+	"github.com/griffithind/dcx/internal/idle"
+	"github.com/griffithind/dcx/internal/state"
+	"github.com/griffithind/dcx/internal/ui"
+)
+
+// refreshInterval is how often the daemon refreshes its environment cache.
+const refreshInterval = 15 * time.Second
+
+// SocketPath returns the daemon's unix socket location:
+// $XDG_RUNTIME_DIR/dcx/dcxd.sock, falling back to ~/.dcx/dcxd.sock.
+func SocketPath() string {
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		return filepath.Join(runtimeDir, "dcx", "dcxd.sock")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".dcx", "dcxd.sock")
+}
+
+// Environment is a cached snapshot of one dcx-managed environment.
+type Environment struct {
+	WorkspaceID   string `json:"workspaceID"`
+	WorkspaceName string `json:"workspaceName,omitempty"`
+	WorkspacePath string `json:"workspacePath,omitempty"`
+	State         string `json:"state"`
+	ContainerID   string `json:"containerID"`
+	ContainerName string `json:"containerName"`
+}
+
+// Request is one line of the wire protocol.
+type Request struct {
+	Op string `json:"op"` // "ping" | "list"
+}
+
+// Response is the daemon's answer to a Request.
+type Response struct {
+	OK           bool          `json:"ok"`
+	Error        string        `json:"error,omitempty"`
+	Environments []Environment `json:"environments,omitempty"`
+}
+
+// Server is the dcxd daemon.
+type Server struct {
+	docker *container.Docker
+
+	mu    sync.RWMutex
+	cache []Environment
+}
+
+// NewServer creates a daemon server backed by the given Docker client.
+func NewServer(docker *container.Docker) *Server {
+	return &Server{docker: docker}
+}
+
+// Run serves until ctx is cancelled: a refresh loop keeps the environment
+// cache current (and runs the idle sweep when configured), while the
+// accept loop answers CLI queries on the unix socket.
+func (s *Server) Run(ctx context.Context) error {
+	socketPath := SocketPath()
+	if err := os.MkdirAll(filepath.Dir(socketPath), 0700); err != nil {
+		return err
+	}
+	// A previous daemon's socket blocks the bind; it's dead or we want to
+	// replace it either way.
+	_ = os.Remove(socketPath)
+
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = ln.Close()
+		_ = os.Remove(socketPath)
+	}()
+
+	go s.refreshLoop(ctx)
+
+	go func() {
+		<-ctx.Done()
+		_ = ln.Close()
+	}()
+
+	ui.Printf("dcxd: listening on %s", socketPath)
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		go s.handleConn(conn)
+	}
+}
+
+// refreshLoop refreshes the cache and runs the idle sweep periodically.
+func (s *Server) refreshLoop(ctx context.Context) {
+	var idleTimeout time.Duration
+	if userCfg, err := config.User(); err == nil && userCfg.IdleTimeout != "" {
+		if d, err := time.ParseDuration(userCfg.IdleTimeout); err == nil {
+			idleTimeout = d
+		}
+	}
+
+	lastSweep := time.Now()
+	for {
+		s.refresh(ctx)
+
+		// Idle detection rides the refresh loop at a coarser cadence.
+		if idleTimeout > 0 && time.Since(lastSweep) >= time.Minute {
+			idle.Sweep(ctx, s.docker, idleTimeout)
+			lastSweep = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(refreshInterval):
+		}
+	}
+}
+
+// refresh rebuilds the environment cache from Docker.
+func (s *Server) refresh(ctx context.Context) {
+	containers, err := s.docker.ListContainersWithLabels(ctx, map[string]string{
+		state.LabelManaged: "true",
+	})
+	if err != nil {
+		return
+	}
+
+	var envs []Environment
+	for _, c := range containers {
+		if !state.IsPrimaryContainer(c.Labels) {
+			continue
+		}
+		lbls := state.ContainerLabelsFromMap(c.Labels)
+		containerState := "created"
+		if c.Running {
+			containerState = "running"
+		}
+		envs = append(envs, Environment{
+			WorkspaceID:   lbls.WorkspaceID,
+			WorkspaceName: lbls.WorkspaceName,
+			WorkspacePath: lbls.WorkspacePath,
+			State:         containerState,
+			ContainerID:   c.ID,
+			ContainerName: c.Name,
+		})
+	}
+
+	s.mu.Lock()
+	s.cache = envs
+	s.mu.Unlock()
+}
+
+// handleConn answers one request per connection.
+func (s *Server) handleConn(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	var req Request
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		return
+	}
+
+	resp := Response{OK: true}
+	switch req.Op {
+	case "ping":
+		// OK alone is the answer.
+	case "list":
+		s.mu.RLock()
+		resp.Environments = s.cache
+		s.mu.RUnlock()
+	default:
+		resp.OK = false
+		resp.Error = "unknown op: " + req.Op
+	}
+
+	_ = json.NewEncoder(conn).Encode(resp)
+}
+
+// Query sends a single request to a running daemon. Returns an error when
+// no daemon answers — callers fall back to direct Docker queries.
+func Query(op string) (*Response, error) {
+	conn, err := net.DialTimeout("unix", SocketPath(), 250*time.Millisecond)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(2 * time.Second))
+
+	if err := json.NewEncoder(conn).Encode(Request{Op: op}); err != nil {
+		return nil, err
+	}
+	var resp Response
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
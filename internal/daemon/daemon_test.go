@@ -0,0 +1,62 @@
+package daemon
+
+import (
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"testing"
+)
+
+// roundTrip pushes one request through handleConn over an in-memory pipe.
+func roundTrip(t *testing.T, server *Server, req Request) Response {
+	t.Helper()
+	client, conn := net.Pipe()
+	go server.handleConn(conn)
+
+	if err := json.NewEncoder(client).Encode(req); err != nil {
+		t.Fatalf("encode request: %v", err)
+	}
+	var resp Response
+	if err := json.NewDecoder(client).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	_ = client.Close()
+	return resp
+}
+
+func TestHandleConnPing(t *testing.T) {
+	resp := roundTrip(t, NewServer(nil), Request{Op: "ping"})
+	if !resp.OK || resp.Error != "" {
+		t.Errorf("ping should succeed, got %+v", resp)
+	}
+}
+
+func TestHandleConnListServesCache(t *testing.T) {
+	server := NewServer(nil)
+	server.cache = []Environment{
+		{WorkspaceID: "ws1", WorkspaceName: "demo", State: "running", ContainerName: "demo"},
+	}
+
+	resp := roundTrip(t, server, Request{Op: "list"})
+	if !resp.OK || len(resp.Environments) != 1 {
+		t.Fatalf("list should return the cache, got %+v", resp)
+	}
+	if resp.Environments[0].WorkspaceID != "ws1" || resp.Environments[0].State != "running" {
+		t.Errorf("cached environment malformed: %+v", resp.Environments[0])
+	}
+}
+
+func TestHandleConnUnknownOp(t *testing.T) {
+	resp := roundTrip(t, NewServer(nil), Request{Op: "explode"})
+	if resp.OK || resp.Error == "" {
+		t.Errorf("unknown op should fail, got %+v", resp)
+	}
+}
+
+func TestSocketPathHonorsRuntimeDir(t *testing.T) {
+	runtimeDir := t.TempDir()
+	t.Setenv("XDG_RUNTIME_DIR", runtimeDir)
+	if got := SocketPath(); got != filepath.Join(runtimeDir, "dcx", "dcxd.sock") {
+		t.Errorf("SocketPath() = %q", got)
+	}
+}
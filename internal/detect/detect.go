@@ -0,0 +1,173 @@
+// Package detect maps a workspace's toolchains to recommended
+// devcontainer features and images, backing `dcx recommend`.
+package detect
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Recommendation suggests one feature (with options) based on detected
+// project files.
+type Recommendation struct {
+	// Tool is the detected toolchain ("go", "node", ...).
+	Tool string
+
+	// Evidence is the file that triggered the detection.
+	Evidence string
+
+	// FeatureRef is the suggested feature reference.
+	FeatureRef string
+
+	// Options are suggested feature options (e.g. detected version).
+	Options map[string]interface{}
+
+	// Image is a suggested base image when no image is configured.
+	Image string
+}
+
+// Scan inspects the workspace root for known toolchain markers and
+// returns feature recommendations, one per detected toolchain.
+func Scan(workspacePath string) []Recommendation {
+	var recs []Recommendation
+
+	if version := goVersionFromMod(filepath.Join(workspacePath, "go.mod")); version != "" {
+		recs = append(recs, Recommendation{
+			Tool:       "go",
+			Evidence:   "go.mod",
+			FeatureRef: "ghcr.io/devcontainers/features/go:1",
+			Options:    map[string]interface{}{"version": version},
+			Image:      "mcr.microsoft.com/devcontainers/go:" + version,
+		})
+	} else if fileExists(filepath.Join(workspacePath, "go.mod")) {
+		recs = append(recs, Recommendation{
+			Tool:       "go",
+			Evidence:   "go.mod",
+			FeatureRef: "ghcr.io/devcontainers/features/go:1",
+			Image:      "mcr.microsoft.com/devcontainers/go:latest",
+		})
+	}
+
+	if fileExists(filepath.Join(workspacePath, "package.json")) {
+		rec := Recommendation{
+			Tool:       "node",
+			Evidence:   "package.json",
+			FeatureRef: "ghcr.io/devcontainers/features/node:1",
+			Image:      "mcr.microsoft.com/devcontainers/javascript-node:latest",
+		}
+		if version := nodeVersionFromNvmrc(filepath.Join(workspacePath, ".nvmrc")); version != "" {
+			rec.Options = map[string]interface{}{"version": version}
+		}
+		recs = append(recs, rec)
+	}
+
+	pythonMarkers := []string{"pyproject.toml", "requirements.txt", "setup.py"}
+	for _, marker := range pythonMarkers {
+		if fileExists(filepath.Join(workspacePath, marker)) {
+			recs = append(recs, Recommendation{
+				Tool:       "python",
+				Evidence:   marker,
+				FeatureRef: "ghcr.io/devcontainers/features/python:1",
+				Image:      "mcr.microsoft.com/devcontainers/python:latest",
+			})
+			break
+		}
+	}
+
+	if fileExists(filepath.Join(workspacePath, "Cargo.toml")) {
+		recs = append(recs, Recommendation{
+			Tool:       "rust",
+			Evidence:   "Cargo.toml",
+			FeatureRef: "ghcr.io/devcontainers/features/rust:1",
+			Image:      "mcr.microsoft.com/devcontainers/rust:latest",
+		})
+	}
+
+	if fileExists(filepath.Join(workspacePath, "Gemfile")) {
+		recs = append(recs, Recommendation{
+			Tool:       "ruby",
+			Evidence:   "Gemfile",
+			FeatureRef: "ghcr.io/devcontainers/features/ruby:1",
+			Image:      "mcr.microsoft.com/devcontainers/ruby:latest",
+		})
+	}
+
+	javaMarkers := []string{"pom.xml", "build.gradle", "build.gradle.kts"}
+	for _, marker := range javaMarkers {
+		if fileExists(filepath.Join(workspacePath, marker)) {
+			recs = append(recs, Recommendation{
+				Tool:       "java",
+				Evidence:   marker,
+				FeatureRef: "ghcr.io/devcontainers/features/java:1",
+				Image:      "mcr.microsoft.com/devcontainers/java:latest",
+			})
+			break
+		}
+	}
+
+	if fileExists(filepath.Join(workspacePath, "docker-compose.yml")) ||
+		fileExists(filepath.Join(workspacePath, "compose.yaml")) {
+		recs = append(recs, Recommendation{
+			Tool:       "docker",
+			Evidence:   "compose file",
+			FeatureRef: "ghcr.io/devcontainers/features/docker-outside-of-docker:1",
+		})
+	}
+
+	return recs
+}
+
+// FeatureSnippet renders recommendations as the features block to add to
+// devcontainer.json.
+func FeatureSnippet(recs []Recommendation) (string, error) {
+	features := make(map[string]interface{}, len(recs))
+	for _, rec := range recs {
+		opts := rec.Options
+		if opts == nil {
+			opts = map[string]interface{}{}
+		}
+		features[rec.FeatureRef] = opts
+	}
+	data, err := json.MarshalIndent(map[string]interface{}{"features": features}, "", "\t")
+	return string(data), err
+}
+
+// goVersionFromMod extracts the go directive's major.minor version.
+func goVersionFromMod(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if version, found := strings.CutPrefix(line, "go "); found {
+			// "1.22.3" → "1.22" (feature/image tags track major.minor)
+			parts := strings.Split(strings.TrimSpace(version), ".")
+			if len(parts) >= 2 {
+				return parts[0] + "." + parts[1]
+			}
+			return strings.TrimSpace(version)
+		}
+	}
+	return ""
+}
+
+// nodeVersionFromNvmrc reads a .nvmrc version, stripping a leading "v".
+func nodeVersionFromNvmrc(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.TrimSpace(string(data)), "v")
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,81 @@
+package detect
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeMarker(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScanGoWithVersion(t *testing.T) {
+	dir := t.TempDir()
+	writeMarker(t, dir, "go.mod", "module example.com/app\n\ngo 1.22.3\n")
+
+	recs := Scan(dir)
+	if len(recs) != 1 {
+		t.Fatalf("expected one recommendation, got %v", recs)
+	}
+	rec := recs[0]
+	if rec.Tool != "go" || rec.Evidence != "go.mod" {
+		t.Errorf("unexpected recommendation: %+v", rec)
+	}
+	if rec.Options["version"] != "1.22" {
+		t.Errorf("go directive should map to major.minor, got %v", rec.Options)
+	}
+}
+
+func TestScanNodeWithNvmrc(t *testing.T) {
+	dir := t.TempDir()
+	writeMarker(t, dir, "package.json", "{}")
+	writeMarker(t, dir, ".nvmrc", "v20.11.0\n")
+
+	recs := Scan(dir)
+	if len(recs) != 1 || recs[0].Tool != "node" {
+		t.Fatalf("expected node recommendation, got %v", recs)
+	}
+	if recs[0].Options["version"] != "20.11.0" {
+		t.Errorf("nvmrc version should drop the v prefix, got %v", recs[0].Options)
+	}
+}
+
+func TestScanMultipleToolchains(t *testing.T) {
+	dir := t.TempDir()
+	writeMarker(t, dir, "go.mod", "module m\n\ngo 1.21\n")
+	writeMarker(t, dir, "pyproject.toml", "")
+	writeMarker(t, dir, "docker-compose.yml", "services: {}\n")
+
+	tools := make(map[string]bool)
+	for _, rec := range Scan(dir) {
+		tools[rec.Tool] = true
+	}
+	for _, want := range []string{"go", "python", "docker"} {
+		if !tools[want] {
+			t.Errorf("expected %s detection, got %v", want, tools)
+		}
+	}
+}
+
+func TestScanEmptyWorkspace(t *testing.T) {
+	if recs := Scan(t.TempDir()); len(recs) != 0 {
+		t.Errorf("empty workspace should yield nothing, got %v", recs)
+	}
+}
+
+func TestFeatureSnippet(t *testing.T) {
+	snippet, err := FeatureSnippet([]Recommendation{
+		{FeatureRef: "ghcr.io/devcontainers/features/go:1", Options: map[string]interface{}{"version": "1.22"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(snippet, `"features"`) || !strings.Contains(snippet, "features/go:1") {
+		t.Errorf("snippet missing features block: %s", snippet)
+	}
+}
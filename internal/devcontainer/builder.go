@@ -164,10 +164,14 @@ func (b *Builder) Build(ctx context.Context, opts BuilderOptions) (*ResolvedDevC
 	resolved.HostUID = os.Getuid()
 	resolved.HostGID = os.Getgid()
 
-	// Environment variables
+	// Environment variables. containerEnv resolves first and feeds the
+	// substitution context so ${containerEnv:VAR} in remoteEnv works.
 	for k, v := range opts.Config.ContainerEnv {
 		resolved.ContainerEnv[k] = Substitute(v, subCtx)
 	}
+	if subCtx.ContainerEnv == nil {
+		subCtx.ContainerEnv = resolved.ContainerEnv
+	}
 	for k, v := range opts.Config.RemoteEnv {
 		resolved.RemoteEnv[k] = Substitute(v, subCtx)
 	}
@@ -207,14 +211,29 @@ func (b *Builder) Build(ctx context.Context, opts BuilderOptions) (*ResolvedDevC
 		resolved.GPURequirements = parseGPURequirements(opts.Config.HostRequirements)
 	}
 
-	// Extract secrets from DCX customizations
-	if dcxConfig := GetDcxCustomizations(opts.Config); dcxConfig != nil {
+	// Parse and validate DCX customizations; a malformed block is a
+	// config error, not a silent no-op.
+	dcxConfig, err := ParseDcxCustomizations(opts.Config)
+	if err != nil {
+		return nil, err
+	}
+	resolved.Dcx = dcxConfig
+	if dcxConfig != nil {
 		if len(dcxConfig.Secrets) > 0 {
 			resolved.RuntimeSecrets = dcxConfig.Secrets
 		}
 		if len(dcxConfig.BuildSecrets) > 0 {
 			resolved.BuildSecrets = dcxConfig.BuildSecrets
 		}
+		if dcxConfig.BuildCache != nil {
+			resolved.BuildCache = dcxConfig.BuildCache
+		}
+		if len(dcxConfig.Sidecars) > 0 {
+			resolved.Sidecars = dcxConfig.Sidecars
+		}
+		if dcxConfig.SecretsDir != "" {
+			resolved.SecretsDir = dcxConfig.SecretsDir
+		}
 	}
 
 	// Resolve features if any exist
@@ -258,6 +277,14 @@ func (b *Builder) resolveFeatures(ctx context.Context, resolved *ResolvedDevCont
 		return fmt.Errorf("failed to resolve features: %w", err)
 	}
 
+	// Fail fast on option typos and type errors instead of letting
+	// install.sh choke on them mid-build.
+	for _, feat := range feats {
+		if err := features.ValidateOptions(feat); err != nil {
+			return err
+		}
+	}
+
 	resolved.Features = feats
 
 	// Merge feature mounts, capAdd, securityOpt, etc.
@@ -353,6 +380,10 @@ func (b *Builder) computeHashes(resolved *ResolvedDevContainer, cfg *DevContaine
 
 	resolved.ConfigHash = configHash
 
+	if structuralHash, err := ComputeStructuralHash(cfg, dockerfilePath, composeFiles, resolved.Features); err == nil {
+		resolved.StructuralHash = structuralHash
+	}
+
 	// Set derived image tag based on config hash so that any change
 	// (devcontainer.json, Dockerfiles, compose files, features) invalidates the cache.
 	if len(configHash) >= common.HashTruncationLength {
@@ -387,6 +418,7 @@ func parseGPURequirements(hr *HostRequirements) *GPURequirements {
 		gpu.Enabled = v
 	case string:
 		gpu.Enabled = v == "true" || v == "optional"
+		gpu.Optional = v == "optional"
 	case map[string]interface{}:
 		gpu.Enabled = true
 		if count, ok := v["count"].(float64); ok {
@@ -417,9 +449,7 @@ func parseForwardPorts(ports []interface{}) []PortForward {
 		case int:
 			result = append(result, PortForward{ContainerPort: v, HostPort: v})
 		case string:
-			if pf := parsePortString(v); pf.ContainerPort > 0 {
-				result = append(result, pf)
-			}
+			result = append(result, parsePortStrings(v)...)
 		}
 	}
 	return result
@@ -433,31 +463,107 @@ func parseAppPorts(ports []string) []PortForward {
 
 	result := make([]PortForward, 0, len(ports))
 	for _, port := range ports {
-		if pf := parsePortString(port); pf.ContainerPort > 0 {
-			result = append(result, pf)
-		}
+		result = append(result, parsePortStrings(port)...)
 	}
 	return result
 }
 
-// parsePortString parses a port string like "8080" or "8080:9000" into a PortForward.
-func parsePortString(s string) PortForward {
-	var pf PortForward
+// ParsePortForwards parses a forwardPorts-style string (full grammar)
+// into PortForwards. Used by one-off --port overrides.
+func ParsePortForwards(s string) []PortForward {
+	return parsePortStrings(s)
+}
+
+// parsePortStrings parses the full forwardPorts/appPort string grammar
+// into one or more PortForwards:
+//
+//	"8080"                  same host and container port
+//	"8080:80"               host:container
+//	"127.0.0.1:8080:80"     bind interface, host, container
+//	"53/udp"                protocol suffix (any of the above forms)
+//	"5000-5010"             port ranges, expanded pairwise
+//	"8000-8005:9000-9005"   host range mapped onto container range
+//	"0:80"                  host port 0: Docker assigns an ephemeral port
+//
+// Malformed entries (unparseable numbers, mismatched range lengths)
+// yield no forwards rather than a partial guess.
+func parsePortStrings(s string) []PortForward {
+	proto := ""
+	if idx := strings.LastIndex(s, "/"); idx >= 0 {
+		proto = s[idx+1:]
+		s = s[:idx]
+	}
+
+	host := ""
+	var hostPart, containerPart string
 	parts := strings.Split(s, ":")
-	if len(parts) == 2 {
-		if hp, err := strconv.Atoi(parts[0]); err == nil {
-			pf.HostPort = hp
-		}
-		if cp, err := strconv.Atoi(parts[1]); err == nil {
-			pf.ContainerPort = cp
-		}
-	} else if len(parts) == 1 {
-		if p, err := strconv.Atoi(parts[0]); err == nil {
-			pf.ContainerPort = p
-			pf.HostPort = p
+	switch len(parts) {
+	case 1:
+		hostPart, containerPart = parts[0], parts[0]
+	case 2:
+		hostPart, containerPart = parts[0], parts[1]
+	case 3:
+		host = parts[0]
+		hostPart, containerPart = parts[1], parts[2]
+	default:
+		return nil
+	}
+
+	containerLo, containerHi, ok := parsePortRange(containerPart)
+	if !ok {
+		return nil
+	}
+
+	// Host port 0 requests a Docker-assigned ephemeral port; the
+	// assignment is discovered post-create and surfaced via `dcx ports`.
+	if hostPart == "0" {
+		result := make([]PortForward, 0, containerHi-containerLo+1)
+		for p := containerLo; p <= containerHi; p++ {
+			result = append(result, PortForward{
+				Host:              host,
+				ContainerPort:     p,
+				Protocol:          proto,
+				EphemeralHostPort: true,
+			})
 		}
+		return result
+	}
+
+	hostLo, hostHi, ok := parsePortRange(hostPart)
+	if !ok {
+		return nil
+	}
+	if hostHi-hostLo != containerHi-containerLo {
+		return nil
+	}
+
+	result := make([]PortForward, 0, containerHi-containerLo+1)
+	for i := 0; i <= containerHi-containerLo; i++ {
+		result = append(result, PortForward{
+			Host:          host,
+			HostPort:      hostLo + i,
+			ContainerPort: containerLo + i,
+			Protocol:      proto,
+		})
+	}
+	return result
+}
+
+// parsePortRange parses "8080" or "5000-5010" into an inclusive range.
+func parsePortRange(s string) (lo, hi int, ok bool) {
+	first, rest, found := strings.Cut(s, "-")
+	lo, err := strconv.Atoi(first)
+	if err != nil || lo <= 0 {
+		return 0, 0, false
+	}
+	if !found {
+		return lo, lo, true
+	}
+	hi, err = strconv.Atoi(rest)
+	if err != nil || hi < lo {
+		return 0, 0, false
 	}
-	return pf
+	return lo, hi, true
 }
 
 // parseMounts validates config mounts and defaults the Type field.
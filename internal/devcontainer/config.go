@@ -28,6 +28,11 @@ const (
 
 // DevContainerConfig represents the parsed devcontainer.json configuration.
 type DevContainerConfig struct {
+	// Extends references a base configuration (relative path) that is
+	// deep-merged underneath this one. Resolved by ParseFileWithExtends
+	// before any other processing; always empty afterwards.
+	Extends string `json:"extends,omitempty"`
+
 	// Name is the display name for the dev container.
 	Name string `json:"name,omitempty"`
 
@@ -99,6 +104,10 @@ type DevContainerConfig struct {
 
 	// Store the raw JSON for hash computation
 	rawJSON []byte
+
+	// substitutionMisses records variable patterns that resolved to ""
+	// with no default during substitution (see SubstitutionContext.Missing).
+	substitutionMisses []string
 }
 
 // PlanType returns the execution plan type for this configuration.
@@ -175,6 +184,17 @@ func (c *DevContainerConfig) SetRawJSON(data []byte) {
 	c.rawJSON = data
 }
 
+// SetSubstitutionMisses records the patterns substitution resolved to ""
+// with no default.
+func (c *DevContainerConfig) SetSubstitutionMisses(misses []string) {
+	c.substitutionMisses = misses
+}
+
+// SubstitutionMisses returns the recorded substitution misses.
+func (c *DevContainerConfig) SubstitutionMisses() []string {
+	return c.substitutionMisses
+}
+
 // MarshalJSON implements json.Marshaler.
 func (c *DevContainerConfig) MarshalJSON() ([]byte, error) {
 	type Alias DevContainerConfig
@@ -1,6 +1,7 @@
 package devcontainer
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 )
@@ -8,6 +9,21 @@ import (
 // DcxCustomizations represents DCX-specific settings from customizations.dcx
 // in devcontainer.json. This replaces the old separate dcx.json file.
 type DcxCustomizations struct {
+	// Agent configures the in-container dcx-agent.
+	Agent *AgentConfig `json:"agent,omitempty"`
+
+	// UIDSyncMode selects how the remote user's UID is aligned with the
+	// host: "image" (default) bakes a -uidN image variant, "entrypoint"
+	// adjusts the user at container start via an injected shim — useful
+	// when teams share prebuilt images across machines with different
+	// UIDs.
+	UIDSyncMode string `json:"uidSyncMode,omitempty"`
+
+	// PortPolicy sets the default bind interface for forwardPorts
+	// entries without an explicit host: "loopback" binds 127.0.0.1,
+	// "all" (the docker default) binds every interface.
+	PortPolicy string `json:"portPolicy,omitempty"`
+
 	// Shortcuts defines command aliases for the 'run' command.
 	Shortcuts map[string]Shortcut `json:"shortcuts,omitempty"`
 
@@ -17,16 +33,181 @@ type DcxCustomizations struct {
 
 	// BuildSecrets defines build-time secrets for Docker BuildKit.
 	// These are only available during docker build via --mount=type=secret.
+	// A value's command is executed on the host and its stdout becomes
+	// the secret (computed secrets); the special value "type=ssh"
+	// instead forwards the host SSH agent into the build
+	// (--mount=type=ssh) for private git dependencies.
 	BuildSecrets map[string]SecretConfig `json:"buildSecrets,omitempty"`
+
+	// SecretsDir overrides where runtime secrets are written in the
+	// container (default /run/secrets). For images without tmpfs
+	// support, point this at a writable path — the files then live in
+	// the container's writable layer instead of memory.
+	SecretsDir string `json:"secretsDir,omitempty"`
+
+	// ReverseForwardPorts exposes host-side services inside the container
+	// as loopback listeners ("5432" or "containerPort:hostPort"), carried
+	// by dcx ssh/shell sessions and `dcx forward`.
+	ReverseForwardPorts []string `json:"reverseForwardPorts,omitempty"`
+
+	// TestCommand is the project's check command run by `dcx test`
+	// (shell syntax, executed in the container as the remote user).
+	TestCommand string `json:"testCommand,omitempty"`
+
+	// HostHooks are commands run on the HOST around lifecycle phases —
+	// registering DNS, starting VPNs, notifying chat — unlike
+	// devcontainer lifecycle hooks, which run in the container.
+	HostHooks *HostHooks `json:"hostHooks,omitempty"`
+
+	// Sidecars declares auxiliary containers (a browser for e2e tests, a
+	// proxy) created alongside image/Dockerfile-based devcontainers on a
+	// shared network, managed by up/down like compose side services.
+	Sidecars map[string]SidecarConfig `json:"sidecars,omitempty"`
+
+	// Watch configures the `dcx watch` loop (globs and the command to
+	// re-run on change; command defaults to updateContentCommand).
+	Watch *WatchConfig `json:"watch,omitempty"`
+
+	// BuildCache configures cache export/import for the derived-image
+	// build, so feature installation layers can be shared across machines
+	// via a registry instead of rebuilt everywhere.
+	BuildCache *BuildCacheConfig `json:"buildCache,omitempty"`
 }
 
-// SecretConfig is a shell command to execute on the host to fetch a secret value.
-// The command's stdout is captured as the secret value.
-// Examples:
+// AgentConfig holds dcx-agent options.
+type AgentConfig struct {
+	// Shell overrides the agent's shell auto-detection for sessions.
+	Shell string `json:"shell,omitempty"`
+
+	// AllowCIDRs extends the agent's connection allowlist beyond
+	// loopback, like `dcx up --hosts` but persisted in config.
+	AllowCIDRs []string `json:"allowCIDRs,omitempty"`
+}
+
+// HostHooks are host-side shell commands around lifecycle operations.
+// Pre hooks abort the operation on failure; post hooks only warn.
+type HostHooks struct {
+	PreUp    string `json:"preUp,omitempty"`
+	PostUp   string `json:"postUp,omitempty"`
+	PreDown  string `json:"preDown,omitempty"`
+	PostDown string `json:"postDown,omitempty"`
+}
+
+// SidecarConfig describes one auxiliary container.
+type SidecarConfig struct {
+	// Image to run.
+	Image string `json:"image"`
+
+	// Env variables for the sidecar.
+	Env map[string]string `json:"env,omitempty"`
+
+	// Ports published on the host ("hostPort:containerPort" strings).
+	Ports []string `json:"ports,omitempty"`
+
+	// Command overrides the image's default command.
+	Command []string `json:"command,omitempty"`
+}
+
+// WatchConfig configures the agent-based file watcher.
+type WatchConfig struct {
+	// Globs restrict which files trigger the watch command (relative
+	// paths; "**/" prefix matches any depth). Empty watches everything.
+	Globs []string `json:"globs,omitempty"`
+
+	// Command overrides updateContentCommand as the on-change command.
+	Command string `json:"command,omitempty"`
+}
+
+// BuildCacheConfig holds buildx cache flags for the derived-image build.
+// Entries are passed verbatim as --cache-from/--cache-to values, e.g.
+// "type=registry,ref=ghcr.io/acme/devcache:features".
+type BuildCacheConfig struct {
+	// CacheFrom lists external cache sources to import from.
+	CacheFrom []string `json:"cacheFrom,omitempty"`
+
+	// CacheTo lists cache destinations to export to after a build.
+	CacheTo []string `json:"cacheTo,omitempty"`
+
+	// CacheDirs are container paths mounted as persistent BuildKit cache
+	// mounts during feature installation (e.g. "/var/cache/apt",
+	// "/root/.npm"), so rebuilds don't redownload packages. Opt-in.
+	CacheDirs []string `json:"cacheDirs,omitempty"`
+}
+
+// SecretConfig describes one secret. The common shorthand is a plain
+// string — a shell command executed on the host whose stdout becomes the
+// secret value:
 //   - "op read op://vault/item"
 //   - "echo $MY_ENV_VAR"
 //   - "cat /path/to/secret"
-type SecretConfig string
+//
+// The object form adds delivery options for runtime secrets:
+//
+//	{"command": "op read ...", "target": "db/password", "mode": "0440", "owner": "postgres"}
+//
+// target is the filename under the secrets directory (default: the
+// secret's name), mode the file mode (default 0400), owner the file
+// owner (default remoteUser).
+type SecretConfig struct {
+	// Command is the host command whose stdout becomes the value.
+	Command string `json:"command,omitempty"`
+
+	// Target overrides the filename under the secrets directory.
+	Target string `json:"target,omitempty"`
+
+	// Mode is the octal file mode (default "0400").
+	Mode string `json:"mode,omitempty"`
+
+	// Owner is the in-container file owner (default remoteUser).
+	Owner string `json:"owner,omitempty"`
+
+	// Provider names an external secret provider: the dcx-secret-<name>
+	// binary on PATH receives a JSON request on stdin and prints the
+	// value on stdout. Mutually exclusive with Command.
+	Provider string `json:"provider,omitempty"`
+
+	// Ref is the provider-specific reference passed in the request.
+	Ref string `json:"ref,omitempty"`
+
+	// RefreshOnStart re-fetches and re-mounts this secret whenever an
+	// existing container starts, for tokens that expire between
+	// sessions. Default: fetched only at container creation.
+	RefreshOnStart bool `json:"refreshOnStart,omitempty"`
+}
+
+// UnmarshalJSON accepts both the string shorthand and the object form.
+func (c *SecretConfig) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		c.Command = str
+		return nil
+	}
+	type alias SecretConfig
+	var obj alias
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("invalid secret config: %w", err)
+	}
+	*c = SecretConfig(obj)
+	return nil
+}
+
+// MarshalJSON emits the string shorthand only when nothing beyond the
+// command is set — provider-based secrets and delivery/refresh options
+// must round-trip through the object form.
+func (c SecretConfig) MarshalJSON() ([]byte, error) {
+	if c.Target == "" && c.Mode == "" && c.Owner == "" &&
+		c.Provider == "" && c.Ref == "" && !c.RefreshOnStart {
+		return json.Marshal(c.Command)
+	}
+	type alias SecretConfig
+	return json.Marshal(alias(c))
+}
+
+// IsSSHForward reports whether this build secret requests SSH agent
+// forwarding instead of a computed value.
+func (c SecretConfig) IsSSHForward() bool {
+	return c.Command == "type=ssh"
+}
 
 // Shortcut represents a command shortcut configuration.
 // Can be either a simple string (the command) or a complex object.
@@ -88,23 +269,51 @@ func (s Shortcut) MarshalJSON() ([]byte, error) {
 }
 
 // GetDcxCustomizations extracts DCX customizations from a DevContainerConfig.
-// Returns nil if no customizations.dcx section exists.
+// Returns nil if no customizations.dcx section exists or it doesn't parse
+// (lenient — use ParseDcxCustomizations for errors).
 func GetDcxCustomizations(cfg *DevContainerConfig) *DcxCustomizations {
-	if cfg == nil || cfg.Customizations == nil {
+	dcx, err := ParseDcxCustomizations(cfg)
+	if err != nil {
 		return nil
 	}
+	return dcx
+}
+
+// ParseDcxCustomizations parses and validates customizations.dcx,
+// reporting unknown keys and invalid enum values instead of silently
+// dropping them. Returns (nil, nil) when the section is absent.
+func ParseDcxCustomizations(cfg *DevContainerConfig) (*DcxCustomizations, error) {
+	if cfg == nil || cfg.Customizations == nil {
+		return nil, nil
+	}
 	dcxRaw, ok := cfg.Customizations["dcx"]
 	if !ok {
-		return nil
+		return nil, nil
 	}
-	// Marshal and unmarshal to parse the structure
+
 	data, err := json.Marshal(dcxRaw)
 	if err != nil {
-		return nil
+		return nil, fmt.Errorf("customizations.dcx: %w", err)
 	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
 	var dcx DcxCustomizations
-	if err := json.Unmarshal(data, &dcx); err != nil {
-		return nil
+	if err := decoder.Decode(&dcx); err != nil {
+		return nil, fmt.Errorf("customizations.dcx: %w", err)
+	}
+
+	switch dcx.PortPolicy {
+	case "", "loopback", "all":
+	default:
+		return nil, fmt.Errorf("customizations.dcx.portPolicy: invalid value %q (one of: loopback, all)", dcx.PortPolicy)
+	}
+
+	switch dcx.UIDSyncMode {
+	case "", "image", "entrypoint":
+	default:
+		return nil, fmt.Errorf("customizations.dcx.uidSyncMode: invalid value %q (one of: image, entrypoint)", dcx.UIDSyncMode)
 	}
-	return &dcx
+
+	return &dcx, nil
 }
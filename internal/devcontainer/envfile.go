@@ -0,0 +1,83 @@
+package devcontainer
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ParseEnvFile reads a dotenv-style file into a map. Supported syntax:
+// KEY=VALUE lines, blank lines, # comments, an optional `export ` prefix,
+// and single- or double-quoted values (quotes stripped, no interpolation).
+func ParseEnvFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	result := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("%s:%d: expected KEY=VALUE", path, lineNo)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 {
+			if (value[0] == '"' && value[len(value)-1] == '"') ||
+				(value[0] == '\'' && value[len(value)-1] == '\'') {
+				value = value[1 : len(value)-1]
+			}
+		}
+		result[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// LoadWorkspaceEnv collects env-file variables for a workspace with
+// documented precedence (highest first):
+//
+//  1. --env-file flags, in the order given
+//  2. .devcontainer/.env (next to devcontainer.json)
+//  3. <workspace>/.env
+//
+// The process environment is NOT included — callers layer it on top so
+// exported shell variables always win over file contents.
+func LoadWorkspaceEnv(workspacePath string, extraFiles []string) map[string]string {
+	merged := make(map[string]string)
+
+	// Lowest precedence first so later sources overwrite.
+	candidates := []string{
+		filepath.Join(workspacePath, ".env"),
+		filepath.Join(workspacePath, ".devcontainer", ".env"),
+	}
+	for i := len(extraFiles) - 1; i >= 0; i-- {
+		candidates = append(candidates, extraFiles[i])
+	}
+
+	for _, path := range candidates {
+		vars, err := ParseEnvFile(path)
+		if err != nil {
+			continue
+		}
+		for k, v := range vars {
+			merged[k] = v
+		}
+	}
+	return merged
+}
@@ -0,0 +1,77 @@
+package devcontainer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	content := `# comment
+FOO=bar
+export EXPORTED=yes
+QUOTED="hello world"
+SINGLE='single'
+EMPTY=
+
+SPACED = value
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	vars, err := ParseEnvFile(path)
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"FOO":      "bar",
+		"EXPORTED": "yes",
+		"QUOTED":   "hello world",
+		"SINGLE":   "single",
+		"EMPTY":    "",
+		"SPACED":   "value",
+	}
+	for k, want := range expected {
+		if got := vars[k]; got != want {
+			t.Errorf("%s = %q, want %q", k, got, want)
+		}
+	}
+}
+
+func TestParseEnvFileInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte("NOT A VALID LINE\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseEnvFile(path); err == nil {
+		t.Error("expected error for malformed line")
+	}
+}
+
+func TestLoadWorkspaceEnvPrecedence(t *testing.T) {
+	ws := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(ws, ".devcontainer"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// workspace .env (lowest)
+	if err := os.WriteFile(filepath.Join(ws, ".env"), []byte("A=workspace\nB=workspace\nC=workspace\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// .devcontainer/.env overrides workspace
+	if err := os.WriteFile(filepath.Join(ws, ".devcontainer", ".env"), []byte("B=devcontainer\nC=devcontainer\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// --env-file overrides both
+	extra := filepath.Join(ws, "extra.env")
+	if err := os.WriteFile(extra, []byte("C=extra\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	vars := LoadWorkspaceEnv(ws, []string{extra})
+	if vars["A"] != "workspace" || vars["B"] != "devcontainer" || vars["C"] != "extra" {
+		t.Errorf("precedence wrong: %v", vars)
+	}
+}
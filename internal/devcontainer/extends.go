@@ -0,0 +1,144 @@
+package devcontainer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tidwall/jsonc"
+)
+
+// maxExtendsDepth bounds the extends chain to catch runaway references.
+const maxExtendsDepth = 10
+
+// strictSpec disables dcx-specific config extensions so behavior matches
+// the devcontainer reference implementation. Set via `dcx --strict-spec`.
+var strictSpec bool
+
+// SetStrictSpec toggles strict-spec mode for config loading.
+func SetStrictSpec(v bool) {
+	strictSpec = v
+}
+
+// ParseFileWithExtends parses a devcontainer.json and, when it carries an
+// `extends` reference, deep-merges the base configuration(s) underneath it
+// before returning. The merged JSON becomes the raw content used for hash
+// computation, so edits to a shared base invalidate every child that
+// extends it.
+func ParseFileWithExtends(path string) (*DevContainerConfig, error) {
+	merged, err := loadConfigMap(path, make(map[string]bool), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("failed to re-encode merged config: %w", err)
+	}
+
+	var cfg DevContainerConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse merged devcontainer.json: %w", err)
+	}
+	cfg.SetRawJSON(data)
+	return &cfg, nil
+}
+
+// loadConfigMap reads a config file as a generic map, recursively merging
+// any extended base configuration underneath it (base first, child wins).
+func loadConfigMap(path string, visited map[string]bool, depth int) (map[string]interface{}, error) {
+	if depth > maxExtendsDepth {
+		return nil, fmt.Errorf("extends chain exceeds %d levels", maxExtendsDepth)
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if visited[absPath] {
+		return nil, fmt.Errorf("extends cycle detected at %s", path)
+	}
+	visited[absPath] = true
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(jsonc.ToJSON(data), &m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	extends, _ := m["extends"].(string)
+	delete(m, "extends")
+	if extends == "" {
+		return m, nil
+	}
+	if strictSpec {
+		return nil, fmt.Errorf("extends is a dcx extension and is disabled in --strict-spec mode")
+	}
+
+	if isOCIConfigRef(extends) {
+		return nil, fmt.Errorf("extends: OCI base configurations (%s) are not supported yet; use a relative path", extends)
+	}
+
+	basePath := extends
+	if !filepath.IsAbs(basePath) {
+		basePath = filepath.Join(filepath.Dir(path), basePath)
+	}
+
+	base, err := loadConfigMap(basePath, visited, depth+1)
+	if err != nil {
+		return nil, fmt.Errorf("extends %s: %w", extends, err)
+	}
+
+	return deepMergeConfig(base, m), nil
+}
+
+// isOCIConfigRef reports whether an extends reference points at an OCI
+// artifact rather than a filesystem path (registry host + path syntax, no
+// local file semantics).
+func isOCIConfigRef(ref string) bool {
+	if strings.HasPrefix(ref, "oci://") {
+		return true
+	}
+	// "ghcr.io/org/repo:tag" style: first segment has a dot or port and
+	// the ref isn't a path that exists relative to anything local.
+	first := strings.SplitN(ref, "/", 2)[0]
+	return strings.Contains(ref, "/") && !strings.HasPrefix(ref, ".") && !filepath.IsAbs(ref) &&
+		(strings.Contains(first, ".") || strings.Contains(first, ":"))
+}
+
+// deepMergeConfig merges override on top of base: objects merge
+// recursively, arrays concatenate (base entries first), and scalars from
+// the override win.
+func deepMergeConfig(base, override map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(base)+len(override))
+	for k, v := range base {
+		result[k] = v
+	}
+	for k, v := range override {
+		existing, ok := result[k]
+		if !ok {
+			result[k] = v
+			continue
+		}
+		switch ev := existing.(type) {
+		case map[string]interface{}:
+			if ov, ok := v.(map[string]interface{}); ok {
+				result[k] = deepMergeConfig(ev, ov)
+				continue
+			}
+		case []interface{}:
+			if ov, ok := v.([]interface{}); ok {
+				result[k] = append(append([]interface{}{}, ev...), ov...)
+				continue
+			}
+		}
+		result[k] = v
+	}
+	return result
+}
@@ -0,0 +1,89 @@
+package devcontainer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeConfig(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestParseFileWithExtends(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, dir, "base.json", `{
+	"image": "ubuntu:22.04",
+	"containerEnv": {"FROM_BASE": "1", "SHARED": "base"},
+	"runArgs": ["--init"]
+}`)
+	child := writeConfig(t, dir, "devcontainer.json", `{
+	"extends": "base.json",
+	"name": "child",
+	"containerEnv": {"SHARED": "child", "FROM_CHILD": "1"},
+	"runArgs": ["--privileged"]
+}`)
+
+	cfg, err := ParseFileWithExtends(child)
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+
+	if cfg.Image != "ubuntu:22.04" {
+		t.Errorf("image not inherited: %q", cfg.Image)
+	}
+	if cfg.Name != "child" {
+		t.Errorf("name = %q", cfg.Name)
+	}
+	if cfg.ContainerEnv["SHARED"] != "child" {
+		t.Errorf("child should win for SHARED, got %q", cfg.ContainerEnv["SHARED"])
+	}
+	if cfg.ContainerEnv["FROM_BASE"] != "1" || cfg.ContainerEnv["FROM_CHILD"] != "1" {
+		t.Errorf("containerEnv not merged: %v", cfg.ContainerEnv)
+	}
+	if len(cfg.RunArgs) != 2 || cfg.RunArgs[0] != "--init" || cfg.RunArgs[1] != "--privileged" {
+		t.Errorf("runArgs not concatenated base-first: %v", cfg.RunArgs)
+	}
+	if cfg.Extends != "" {
+		t.Errorf("extends should be consumed, got %q", cfg.Extends)
+	}
+}
+
+func TestParseFileWithExtendsChain(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, dir, "root.json", `{"image": "alpine"}`)
+	writeConfig(t, dir, "mid.json", `{"extends": "root.json", "remoteUser": "dev"}`)
+	child := writeConfig(t, dir, "devcontainer.json", `{"extends": "mid.json", "name": "leaf"}`)
+
+	cfg, err := ParseFileWithExtends(child)
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if cfg.Image != "alpine" || cfg.RemoteUser != "dev" || cfg.Name != "leaf" {
+		t.Errorf("chain merge incomplete: %+v", cfg)
+	}
+}
+
+func TestParseFileWithExtendsCycle(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, dir, "a.json", `{"extends": "b.json"}`)
+	writeConfig(t, dir, "b.json", `{"extends": "a.json"}`)
+
+	if _, err := ParseFileWithExtends(filepath.Join(dir, "a.json")); err == nil {
+		t.Error("expected cycle error")
+	}
+}
+
+func TestParseFileWithExtendsOCIRef(t *testing.T) {
+	dir := t.TempDir()
+	child := writeConfig(t, dir, "devcontainer.json", `{"extends": "ghcr.io/acme/base:1"}`)
+
+	if _, err := ParseFileWithExtends(child); err == nil {
+		t.Error("expected OCI refs to be rejected with a clear error")
+	}
+}
@@ -88,6 +88,21 @@ func ComputeConfigHash(cfg *DevContainerConfig, dockerfilePath string, composeFi
 	return hex.EncodeToString(h.Sum(nil)), nil
 }
 
+// ComputeStructuralHash computes the config hash with the env-only inputs
+// (containerEnv, remoteEnv, forwardPorts) blanked out. When the full hash
+// differs but the structural hash matches the running container's, only
+// environment/port configuration changed and the container can be updated
+// in place instead of recreated.
+func ComputeStructuralHash(cfg *DevContainerConfig, dockerfilePath string, composeFiles []string, resolvedFeatures []*features.Feature) (string, error) {
+	structural := *cfg
+	structural.ContainerEnv = nil
+	structural.RemoteEnv = nil
+	structural.ForwardPorts = nil
+	// Force the marshal path — the raw JSON still contains the env blocks.
+	structural.SetRawJSON(nil)
+	return ComputeConfigHash(&structural, dockerfilePath, composeFiles, resolvedFeatures)
+}
+
 // collectComposeDockerfiles parses compose files and returns absolute paths
 // to all Dockerfiles referenced by service build directives.
 func collectComposeDockerfiles(composeFiles []string) []string {
@@ -2,6 +2,7 @@ package devcontainer
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 	"runtime"
 	"strconv"
@@ -20,8 +21,9 @@ type HostRequirementsResult struct {
 // DockerResources represents the resources available to Docker.
 // This may be less than the host's actual resources (e.g., Docker Desktop VM limits).
 type DockerResources struct {
-	CPUs   int    // Number of CPUs available to Docker
-	Memory uint64 // Total memory available to Docker in bytes
+	CPUs        int    // Number of CPUs available to Docker
+	Memory      uint64 // Total memory available to Docker in bytes
+	StorageFree uint64 // Free bytes on the docker data-root (0 = unknown)
 }
 
 // ValidateHostRequirementsWithDocker checks if Docker's configured resources meet the requirements.
@@ -87,10 +89,22 @@ func ValidateHostRequirementsWithDocker(reqs *HostRequirements, dockerRes *Docke
 		}
 	}
 
-	// Storage cannot be validated meaningfully
+	// Storage: check free space on the docker data-root when known.
 	if reqs.Storage != "" {
-		result.Warnings = append(result.Warnings,
-			fmt.Sprintf("Storage requirement (%s) cannot be validated automatically", reqs.Storage))
+		reqBytes, err := parseMemoryString(reqs.Storage)
+		switch {
+		case err != nil:
+			result.Warnings = append(result.Warnings,
+				fmt.Sprintf("Could not parse storage requirement '%s': %v", reqs.Storage, err))
+		case dockerRes.StorageFree == 0:
+			result.Warnings = append(result.Warnings,
+				fmt.Sprintf("Storage requirement (%s) cannot be validated on this host", reqs.Storage))
+		case dockerRes.StorageFree < reqBytes:
+			result.Satisfied = false
+			result.Errors = append(result.Errors,
+				fmt.Sprintf("Storage requirement not met: need %s, docker data-root has %s free",
+					reqs.Storage, formatBytes(dockerRes.StorageFree)))
+		}
 	}
 
 	return result
@@ -177,6 +191,31 @@ func formatBytes(b uint64) string {
 	return fmt.Sprintf("%.1f %cB", float64(b)/float64(div), "KMGTPE"[exp])
 }
 
+// DetectGPUVendors lists the GPU stacks usable from containers on this
+// host. Possible values: "nvidia" (nvidia-smi responds), "rocm" (/dev/kfd
+// present), "dri" (/dev/dri present — Intel or other DRM devices). Empty
+// means no GPU path was found.
+func DetectGPUVendors() []string {
+	var vendors []string
+
+	if _, err := exec.LookPath("nvidia-smi"); err == nil {
+		if err := exec.Command("nvidia-smi").Run(); err == nil {
+			vendors = append(vendors, "nvidia")
+		}
+	}
+
+	if runtime.GOOS == "linux" {
+		if _, err := os.Stat("/dev/kfd"); err == nil {
+			vendors = append(vendors, "rocm")
+		}
+		if util.IsDir("/dev/dri") {
+			vendors = append(vendors, "dri")
+		}
+	}
+
+	return vendors
+}
+
 // hasGPU checks if a GPU is available.
 func hasGPU() bool {
 	// Try nvidia-smi first
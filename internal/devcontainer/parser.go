@@ -102,6 +102,35 @@ func Resolve(workspacePath string) (string, error) {
 	return "", fmt.Errorf("no devcontainer.json found in %s", workspacePath)
 }
 
+// DiscoverWorkspaceRoot walks up from start looking for a directory with
+// a devcontainer configuration, so dcx works from any subdirectory of a
+// project (monorepo service dirs included). Stops at the user's home
+// directory or filesystem root; returns start when nothing is found.
+func DiscoverWorkspaceRoot(start string) string {
+	home, _ := os.UserHomeDir()
+	dir := start
+	for {
+		if hasConfigMarker(dir) {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir || dir == home {
+			return start
+		}
+		dir = parent
+	}
+}
+
+// hasConfigMarker reports whether dir carries a devcontainer config.
+func hasConfigMarker(dir string) bool {
+	for _, loc := range configLocations {
+		if util.IsFile(filepath.Join(dir, loc)) {
+			return true
+		}
+	}
+	return util.IsDir(filepath.Join(dir, ".devcontainer"))
+}
+
 // Load loads and parses the devcontainer configuration.
 // Returns the parsed config and the path to the config file.
 func Load(workspacePath, configPath string) (*DevContainerConfig, string, error) {
@@ -110,7 +139,7 @@ func Load(workspacePath, configPath string) (*DevContainerConfig, string, error)
 		if !filepath.IsAbs(configPath) {
 			configPath = filepath.Join(workspacePath, configPath)
 		}
-		cfg, err := ParseFile(configPath)
+		cfg, err := ParseFileWithExtends(configPath)
 		return cfg, configPath, err
 	}
 
@@ -120,7 +149,7 @@ func Load(workspacePath, configPath string) (*DevContainerConfig, string, error)
 		return nil, "", err
 	}
 
-	cfg, err := ParseFile(resolvedPath)
+	cfg, err := ParseFileWithExtends(resolvedPath)
 	if err != nil {
 		return nil, resolvedPath, err
 	}
@@ -128,14 +157,18 @@ func Load(workspacePath, configPath string) (*DevContainerConfig, string, error)
 	// Compute container workspace folder
 	containerWorkspace := DetermineContainerWorkspaceFolder(cfg, workspacePath)
 
-	// Create substitution context
+	// Create substitution context, collecting lookups that resolve to
+	// "" with no default so the load path can warn about them.
+	var misses []string
 	ctx := &SubstitutionContext{
 		LocalWorkspaceFolder:     workspacePath,
 		ContainerWorkspaceFolder: containerWorkspace,
+		Missing:                  &misses,
 	}
 
 	// Perform variable substitution
 	SubstituteConfig(cfg, ctx)
+	cfg.SetSubstitutionMisses(misses)
 
 	return cfg, resolvedPath, nil
 }
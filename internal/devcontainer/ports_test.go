@@ -0,0 +1,42 @@
+package devcontainer
+
+import "testing"
+
+func TestParsePortStrings(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []PortForward
+	}{
+		{"8080", []PortForward{{HostPort: 8080, ContainerPort: 8080}}},
+		{"8080:80", []PortForward{{HostPort: 8080, ContainerPort: 80}}},
+		{"127.0.0.1:8080:80", []PortForward{{Host: "127.0.0.1", HostPort: 8080, ContainerPort: 80}}},
+		{"53/udp", []PortForward{{HostPort: 53, ContainerPort: 53, Protocol: "udp"}}},
+		{"5000-5002", []PortForward{
+			{HostPort: 5000, ContainerPort: 5000},
+			{HostPort: 5001, ContainerPort: 5001},
+			{HostPort: 5002, ContainerPort: 5002},
+		}},
+		{"8000-8001:9000-9001", []PortForward{
+			{HostPort: 8000, ContainerPort: 9000},
+			{HostPort: 8001, ContainerPort: 9001},
+		}},
+		{"127.0.0.1:8080:80/udp", []PortForward{{Host: "127.0.0.1", HostPort: 8080, ContainerPort: 80, Protocol: "udp"}}},
+		// Malformed inputs produce nothing rather than a partial guess.
+		{"not-a-port", nil},
+		{"5000-4000", nil},
+		{"8000-8005:9000-9001", nil},
+	}
+
+	for _, tt := range tests {
+		got := parsePortStrings(tt.in)
+		if len(got) != len(tt.want) {
+			t.Errorf("parsePortStrings(%q) = %v, want %v", tt.in, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("parsePortStrings(%q)[%d] = %+v, want %+v", tt.in, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
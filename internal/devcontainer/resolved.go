@@ -127,6 +127,10 @@ type ResolvedDevContainer struct {
 
 	// === Hash ===
 
+	// StructuralHash is ConfigHash with env-only inputs (containerEnv,
+	// remoteEnv, forwardPorts) excluded; used to detect env-only changes.
+	StructuralHash string
+
 	// ConfigHash is the combined hash of all build inputs (devcontainer.json,
 	// Dockerfiles, compose files, features). Used for staleness detection
 	// and image cache tagging.
@@ -148,6 +152,22 @@ type ResolvedDevContainer struct {
 	// Map of secret name to config (command to fetch value).
 	RuntimeSecrets map[string]SecretConfig
 
+	// Dcx carries the validated customizations.dcx block (agent options,
+	// port policy, hooks, ...); nil when absent.
+	Dcx *DcxCustomizations
+
+	// SecretsDir overrides the in-container runtime secrets directory
+	// ("" = /run/secrets, tmpfs-backed).
+	SecretsDir string
+
+	// Sidecars are auxiliary containers created alongside the primary for
+	// image/Dockerfile plans (from customizations.dcx.sidecars).
+	Sidecars map[string]SidecarConfig
+
+	// BuildCache holds buildx cache import/export flags for the
+	// derived-image build (from customizations.dcx.buildCache).
+	BuildCache *BuildCacheConfig
+
 	// BuildSecrets are secrets for Docker BuildKit during builds.
 	// Map of secret name to config (command to fetch value).
 	BuildSecrets map[string]SecretConfig
@@ -212,7 +232,10 @@ type HookCommand struct {
 // GPURequirements specifies GPU requirements for the container.
 type GPURequirements struct {
 	Enabled bool
-	Count   int
+	// Optional means the environment works without a GPU: enable GPU
+	// access when the daemon supports it, silently skip otherwise.
+	Optional bool
+	Count    int
 	Memory  string
 	Cores   int
 }
@@ -225,11 +248,27 @@ type ParsedRunArgs struct {
 	NetworkMode string
 	IpcMode     string
 	PidMode     string
+	UsernsMode  string
 	ShmSize     int64
+	CPUs        string
+	Memory      string
+	Restart     string
+	Platform    string
 	CapDrop     []string
 	Devices     []string
 	ExtraHosts  []string
+	Ulimits     []string
+	DNS         []string
+	EnvFiles    []string
+	GroupAdd    []string
+	Volumes     []string
 	Sysctls     map[string]string
+	Labels      map[string]string
+
+	// Unknown holds flags the parser doesn't recognize, passed through
+	// to docker run verbatim (with a warning) so settings aren't
+	// silently dropped.
+	Unknown []string
 }
 
 // NewResolvedDevContainer creates a new ResolvedDevContainer with initialized maps.
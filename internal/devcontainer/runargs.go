@@ -12,88 +12,108 @@ import (
 //   - --network, --net: Network mode
 //   - --ipc: IPC mode
 //   - --pid: PID mode
+//   - --userns: User namespace mode (e.g. "host" to disable remapping)
 //   - --shm-size: Shared memory size
 //   - -u, --user: Container user
 //   - --cap-drop: Capabilities to drop
-//   - --device: Devices to add
+//   - --device: Devices to add (plain paths or CDI references like nvidia.com/gpu=all)
 //   - --add-host: Extra hosts
 //   - --sysctl: Sysctl settings
+//   - --cpus, --memory: Resource limits
+//   - --ulimit, --dns, --label, --env-file, --group-add, -v/--volume,
+//     --restart, --platform: passed through structurally
+//
+// Flags the parser doesn't recognize are collected into Unknown and
+// passed to docker run verbatim (callers warn), so devcontainer.json
+// files using exotic flags don't silently lose settings.
 func ParseRunArgs(args []string) *ParsedRunArgs {
 	result := &ParsedRunArgs{
 		Sysctls: make(map[string]string),
+		Labels:  make(map[string]string),
 	}
 
-	for i := 0; i < len(args); i++ {
-		arg := args[i]
-
-		// Handle --flag=value syntax
-		if strings.Contains(arg, "=") {
-			parts := strings.SplitN(arg, "=", 2)
-			flag := parts[0]
-			value := parts[1]
-
-			switch flag {
-			case "--network", "--net":
-				result.NetworkMode = value
-			case "--ipc":
-				result.IpcMode = value
-			case "--pid":
-				result.PidMode = value
-			case "--shm-size":
-				result.ShmSize = parseShmSize(value)
-			case "-u", "--user":
-				result.User = value
-			case "--cap-drop":
-				result.CapDrop = append(result.CapDrop, value)
-			case "--device":
-				result.Devices = append(result.Devices, value)
-			case "--add-host":
-				result.ExtraHosts = append(result.ExtraHosts, value)
-			case "--sysctl":
-				if kv := strings.SplitN(value, "=", 2); len(kv) == 2 {
-					result.Sysctls[kv[0]] = kv[1]
-				}
-			}
-			continue
-		}
-
-		// Handle --flag value syntax (requires next arg)
-		if i+1 >= len(args) {
-			continue
-		}
-		value := args[i+1]
-
-		switch arg {
+	// apply consumes one flag/value pair; returns false for unknown flags.
+	apply := func(flag, value string) bool {
+		switch flag {
 		case "--network", "--net":
 			result.NetworkMode = value
-			i++
 		case "--ipc":
 			result.IpcMode = value
-			i++
 		case "--pid":
 			result.PidMode = value
-			i++
+		case "--userns":
+			result.UsernsMode = value
 		case "--shm-size":
 			result.ShmSize = parseShmSize(value)
-			i++
 		case "-u", "--user":
 			result.User = value
-			i++
+		case "--cpus":
+			result.CPUs = value
+		case "-m", "--memory":
+			result.Memory = value
+		case "--restart":
+			result.Restart = value
+		case "--platform":
+			result.Platform = value
 		case "--cap-drop":
 			result.CapDrop = append(result.CapDrop, value)
-			i++
 		case "--device":
 			result.Devices = append(result.Devices, value)
-			i++
 		case "--add-host":
 			result.ExtraHosts = append(result.ExtraHosts, value)
-			i++
+		case "--ulimit":
+			result.Ulimits = append(result.Ulimits, value)
+		case "--dns":
+			result.DNS = append(result.DNS, value)
+		case "--env-file":
+			result.EnvFiles = append(result.EnvFiles, value)
+		case "--group-add":
+			result.GroupAdd = append(result.GroupAdd, value)
+		case "-v", "--volume":
+			result.Volumes = append(result.Volumes, value)
+		case "--label", "-l":
+			if kv := strings.SplitN(value, "=", 2); len(kv) == 2 {
+				result.Labels[kv[0]] = kv[1]
+			} else {
+				result.Labels[value] = ""
+			}
 		case "--sysctl":
 			if kv := strings.SplitN(value, "=", 2); len(kv) == 2 {
 				result.Sysctls[kv[0]] = kv[1]
 			}
+		default:
+			return false
+		}
+		return true
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		// --flag=value syntax
+		if strings.HasPrefix(arg, "-") && strings.Contains(arg, "=") {
+			parts := strings.SplitN(arg, "=", 2)
+			if !apply(parts[0], parts[1]) {
+				result.Unknown = append(result.Unknown, arg)
+			}
+			continue
+		}
+
+		// --flag value syntax
+		if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			if apply(arg, args[i+1]) {
+				i++
+				continue
+			}
+			// Unknown flag with an apparent value: pass both through.
+			result.Unknown = append(result.Unknown, arg, args[i+1])
 			i++
+			continue
 		}
+
+		// Bare flag (boolean or trailing) — known flags all take values,
+		// so anything landing here is unknown.
+		result.Unknown = append(result.Unknown, arg)
 	}
 
 	return result
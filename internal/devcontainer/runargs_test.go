@@ -135,8 +135,8 @@ func TestParseRunArgs(t *testing.T) {
 			},
 		},
 		{
-			name: "unknown args are ignored",
-			args: []string{"--unknown=value", "--network=host", "-v", "/tmp:/tmp"},
+			name: "unknown args are collected for passthrough",
+			args: []string{"--unknown=value", "--network=host"},
 			expected: &ParsedRunArgs{
 				NetworkMode: "host",
 				Sysctls:     map[string]string{},
@@ -203,6 +203,50 @@ func TestParseRunArgs(t *testing.T) {
 	}
 }
 
+func TestParseRunArgsExtendedFlags(t *testing.T) {
+	result := ParseRunArgs([]string{
+		"--cpus=1.5",
+		"--memory", "4g",
+		"--ulimit", "nofile=65536:65536",
+		"--dns=1.1.1.1",
+		"--label", "team=platform",
+		"--env-file", ".env.runtime",
+		"--group-add", "audio",
+		"-v", "/tmp/cache:/cache",
+		"--restart", "unless-stopped",
+		"--platform=linux/arm64",
+		"--exotic-flag=1",
+	})
+
+	if result.CPUs != "1.5" || result.Memory != "4g" {
+		t.Errorf("resource limits not parsed: cpus=%q memory=%q", result.CPUs, result.Memory)
+	}
+	if len(result.Ulimits) != 1 || result.Ulimits[0] != "nofile=65536:65536" {
+		t.Errorf("Ulimits = %v", result.Ulimits)
+	}
+	if len(result.DNS) != 1 || result.DNS[0] != "1.1.1.1" {
+		t.Errorf("DNS = %v", result.DNS)
+	}
+	if result.Labels["team"] != "platform" {
+		t.Errorf("Labels = %v", result.Labels)
+	}
+	if len(result.EnvFiles) != 1 || result.EnvFiles[0] != ".env.runtime" {
+		t.Errorf("EnvFiles = %v", result.EnvFiles)
+	}
+	if len(result.GroupAdd) != 1 || result.GroupAdd[0] != "audio" {
+		t.Errorf("GroupAdd = %v", result.GroupAdd)
+	}
+	if len(result.Volumes) != 1 || result.Volumes[0] != "/tmp/cache:/cache" {
+		t.Errorf("Volumes = %v", result.Volumes)
+	}
+	if result.Restart != "unless-stopped" || result.Platform != "linux/arm64" {
+		t.Errorf("restart=%q platform=%q", result.Restart, result.Platform)
+	}
+	if len(result.Unknown) != 1 || result.Unknown[0] != "--exotic-flag=1" {
+		t.Errorf("Unknown = %v", result.Unknown)
+	}
+}
+
 func TestParseShmSize(t *testing.T) {
 	tests := []struct {
 		input    string
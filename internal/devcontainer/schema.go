@@ -0,0 +1,231 @@
+package devcontainer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/tidwall/jsonc"
+)
+
+// SchemaIssueSeverity classifies a schema issue.
+type SchemaIssueSeverity string
+
+const (
+	// SchemaError means the config cannot be interpreted as intended
+	// (type errors, invalid enum values, syntax errors).
+	SchemaError SchemaIssueSeverity = "error"
+
+	// SchemaWarning means the config is usable but contains keys dcx
+	// does not understand (typos, tool-specific extensions).
+	SchemaWarning SchemaIssueSeverity = "warning"
+)
+
+// SchemaIssue is a single finding from ValidateSchema, with the position
+// in the original JSONC source (1-based line/column; 0 when unknown).
+type SchemaIssue struct {
+	Severity SchemaIssueSeverity
+	Key      string
+	Line     int
+	Column   int
+	Message  string
+}
+
+// String formats the issue as "line:col key: message".
+func (i SchemaIssue) String() string {
+	pos := ""
+	if i.Line > 0 {
+		pos = fmt.Sprintf("%d:%d ", i.Line, i.Column)
+	}
+	if i.Key != "" {
+		return fmt.Sprintf("%s%s: %s", pos, i.Key, i.Message)
+	}
+	return pos + i.Message
+}
+
+// HasSchemaErrors reports whether any issue has error severity.
+func HasSchemaErrors(issues []SchemaIssue) bool {
+	for _, i := range issues {
+		if i.Severity == SchemaError {
+			return true
+		}
+	}
+	return false
+}
+
+// Enumerated property values per the devcontainer schema.
+var schemaEnums = map[string][]string{
+	"userEnvProbe":   {"none", "loginShell", "loginInteractiveShell", "interactiveShell"},
+	"shutdownAction": {"none", "stopContainer", "stopCompose"},
+	"waitFor":        {"initializeCommand", "onCreateCommand", "updateContentCommand", "postCreateCommand", "postStartCommand"},
+}
+
+// ValidateSchema checks raw devcontainer.json (JSONC) content against the
+// schema dcx understands: unknown top-level keys, type mismatches, and
+// invalid enum values, each reported with line/column from the original
+// source. jsonc.ToJSON replaces comments with spaces in place, so byte
+// offsets in the stripped document match the JSONC source exactly.
+func ValidateSchema(data []byte) []SchemaIssue {
+	stripped := jsonc.ToJSON(data)
+	var issues []SchemaIssue
+
+	// Walk top-level keys with a token decoder, recording their offsets
+	// for position reporting and flagging keys the schema doesn't know.
+	known := knownConfigKeys()
+	keyOffsets := make(map[string]int64)
+
+	dec := json.NewDecoder(bytes.NewReader(stripped))
+	if tok, err := dec.Token(); err != nil {
+		line, col := offsetToPosition(data, syntaxErrorOffset(err))
+		return []SchemaIssue{{
+			Severity: SchemaError,
+			Line:     line,
+			Column:   col,
+			Message:  fmt.Sprintf("invalid JSON: %v", err),
+		}}
+	} else if tok != json.Delim('{') {
+		return []SchemaIssue{{
+			Severity: SchemaError,
+			Message:  "devcontainer.json must contain a JSON object",
+		}}
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			break
+		}
+		key, ok := tok.(string)
+		if !ok {
+			break
+		}
+		// InputOffset is just past the key token; back up its length for
+		// a position pointing at the key itself.
+		off := dec.InputOffset() - int64(len(key)) - 2
+		keyOffsets[key] = off
+
+		if !known[key] {
+			line, col := offsetToPosition(data, off)
+			issues = append(issues, SchemaIssue{
+				Severity: SchemaWarning,
+				Key:      key,
+				Line:     line,
+				Column:   col,
+				Message:  "unknown property (ignored by dcx)",
+			})
+		}
+
+		// Skip the value.
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			break
+		}
+	}
+
+	// Type checking: let encoding/json report mismatches against the
+	// typed config struct, then map the byte offset back to a position.
+	var cfg DevContainerConfig
+	if err := json.Unmarshal(stripped, &cfg); err != nil {
+		if typeErr, ok := err.(*json.UnmarshalTypeError); ok {
+			line, col := offsetToPosition(data, typeErr.Offset)
+			issues = append(issues, SchemaIssue{
+				Severity: SchemaError,
+				Key:      typeErr.Field,
+				Line:     line,
+				Column:   col,
+				Message:  fmt.Sprintf("expected %s, got %s", typeErr.Type, typeErr.Value),
+			})
+		} else {
+			line, col := offsetToPosition(data, syntaxErrorOffset(err))
+			issues = append(issues, SchemaIssue{
+				Severity: SchemaError,
+				Line:     line,
+				Column:   col,
+				Message:  fmt.Sprintf("invalid JSON: %v", err),
+			})
+		}
+		return issues
+	}
+
+	// Enum checks on successfully parsed values.
+	enumValues := map[string]string{
+		"userEnvProbe":   cfg.UserEnvProbe,
+		"shutdownAction": cfg.ShutdownAction,
+		"waitFor":        cfg.WaitFor,
+	}
+	for key, value := range enumValues {
+		if value == "" {
+			continue
+		}
+		if !containsString(schemaEnums[key], value) {
+			line, col := offsetToPosition(data, keyOffsets[key])
+			issues = append(issues, SchemaIssue{
+				Severity: SchemaError,
+				Key:      key,
+				Line:     line,
+				Column:   col,
+				Message:  fmt.Sprintf("invalid value %q (one of: %s)", value, strings.Join(schemaEnums[key], ", ")),
+			})
+		}
+	}
+
+	return issues
+}
+
+// knownConfigKeys builds the set of top-level keys from the
+// DevContainerConfig struct's json tags, so the schema stays in sync with
+// the parser without a hand-maintained list.
+func knownConfigKeys() map[string]bool {
+	known := make(map[string]bool)
+	t := reflect.TypeOf(DevContainerConfig{})
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("json")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if name != "" {
+			known[name] = true
+		}
+	}
+	// Accepted by the spec but carried outside the struct.
+	known["$schema"] = true
+	return known
+}
+
+// offsetToPosition converts a byte offset into 1-based line/column.
+func offsetToPosition(data []byte, offset int64) (line, col int) {
+	if offset < 0 || offset > int64(len(data)) {
+		return 0, 0
+	}
+	line, col = 1, 1
+	for _, b := range data[:offset] {
+		if b == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+	}
+	return line, col
+}
+
+// syntaxErrorOffset extracts the offset from a json.SyntaxError, or -1.
+func syntaxErrorOffset(err error) int64 {
+	if syntaxErr, ok := err.(*json.SyntaxError); ok {
+		return syntaxErr.Offset
+	}
+	return -1
+}
+
+// containsString reports whether list contains s.
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package devcontainer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateSchemaClean(t *testing.T) {
+	data := []byte(`{
+	// comment
+	"name": "test",
+	"image": "ubuntu:22.04",
+	"userEnvProbe": "loginShell"
+}`)
+	issues := ValidateSchema(data)
+	if len(issues) != 0 {
+		t.Errorf("expected no issues, got %v", issues)
+	}
+}
+
+func TestValidateSchemaUnknownKey(t *testing.T) {
+	data := []byte(`{
+	"image": "ubuntu",
+	"imgae": "typo"
+}`)
+	issues := ValidateSchema(data)
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue, got %v", issues)
+	}
+	issue := issues[0]
+	if issue.Severity != SchemaWarning || issue.Key != "imgae" {
+		t.Errorf("unexpected issue: %+v", issue)
+	}
+	if issue.Line != 3 {
+		t.Errorf("expected line 3, got %d", issue.Line)
+	}
+	if HasSchemaErrors(issues) {
+		t.Error("unknown keys should be warnings, not errors")
+	}
+}
+
+func TestValidateSchemaTypeError(t *testing.T) {
+	data := []byte(`{
+	"image": "ubuntu",
+	"forwardPorts": 8080
+}`)
+	issues := ValidateSchema(data)
+	if !HasSchemaErrors(issues) {
+		t.Fatalf("expected a type error, got %v", issues)
+	}
+}
+
+func TestValidateSchemaInvalidEnum(t *testing.T) {
+	data := []byte(`{
+	"image": "ubuntu",
+	"userEnvProbe": "shell"
+}`)
+	issues := ValidateSchema(data)
+	if !HasSchemaErrors(issues) {
+		t.Fatalf("expected enum error, got %v", issues)
+	}
+	if !strings.Contains(issues[0].Message, "loginShell") {
+		t.Errorf("enum error should list valid values: %v", issues[0])
+	}
+}
+
+func TestValidateSchemaSyntaxError(t *testing.T) {
+	issues := ValidateSchema([]byte(`{"image": `))
+	if !HasSchemaErrors(issues) {
+		t.Fatalf("expected syntax error, got %v", issues)
+	}
+}
@@ -0,0 +1,61 @@
+package devcontainer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeStructuralHashIgnoresEnvOnlyInputs(t *testing.T) {
+	base := &DevContainerConfig{
+		Image:        "ubuntu:22.04",
+		ContainerEnv: map[string]string{"A": "1"},
+		ForwardPorts: []interface{}{float64(3000)},
+	}
+	changed := &DevContainerConfig{
+		Image:        "ubuntu:22.04",
+		ContainerEnv: map[string]string{"A": "2", "B": "3"},
+		RemoteEnv:    map[string]string{"C": "4"},
+		ForwardPorts: []interface{}{float64(4000)},
+	}
+
+	baseFull, err := ComputeConfigHash(base, "", nil, nil)
+	require.NoError(t, err)
+	changedFull, err := ComputeConfigHash(changed, "", nil, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, baseFull, changedFull, "full hash must notice env/port edits")
+
+	baseStructural, err := ComputeStructuralHash(base, "", nil, nil)
+	require.NoError(t, err)
+	changedStructural, err := ComputeStructuralHash(changed, "", nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, baseStructural, changedStructural, "structural hash must ignore env-only edits")
+}
+
+func TestComputeStructuralHashNoticesStructuralChange(t *testing.T) {
+	base := &DevContainerConfig{Image: "ubuntu:22.04"}
+	changed := &DevContainerConfig{Image: "debian:12"}
+
+	baseHash, err := ComputeStructuralHash(base, "", nil, nil)
+	require.NoError(t, err)
+	changedHash, err := ComputeStructuralHash(changed, "", nil, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, baseHash, changedHash)
+}
+
+func TestComputeStructuralHashIgnoresRawJSON(t *testing.T) {
+	// The raw JSON still contains the env blocks; the structural hash
+	// must use the marshal path instead.
+	cfg := &DevContainerConfig{Image: "ubuntu:22.04", ContainerEnv: map[string]string{"A": "1"}}
+	cfg.SetRawJSON([]byte(`{"image":"ubuntu:22.04","containerEnv":{"A":"1"}}`))
+
+	withRaw, err := ComputeStructuralHash(cfg, "", nil, nil)
+	require.NoError(t, err)
+
+	bare := &DevContainerConfig{Image: "ubuntu:22.04", ContainerEnv: map[string]string{"A": "1"}}
+	withoutRaw, err := ComputeStructuralHash(bare, "", nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, withRaw, withoutRaw)
+}
@@ -1,6 +1,7 @@
 package devcontainer
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -14,6 +15,20 @@ type SubstitutionContext struct {
 	UserHome                 string              // User's home directory for ${userHome}
 	ContainerEnv             map[string]string   // Container environment variables for ${containerEnv:VAR}
 	LocalEnv                 func(string) string // Optional function to get local env vars; falls back to os.Getenv
+
+	// Missing collects substitution patterns that resolved to empty with
+	// no default (a missing host variable, usually). Substitution still
+	// replaces them with "" per spec; callers surface the collected
+	// patterns as a warning. Nil disables collection.
+	Missing *[]string
+}
+
+// recordMiss notes a pattern that resolved empty without a default.
+func (c *SubstitutionContext) recordMiss(pattern string) {
+	if c == nil || c.Missing == nil {
+		return
+	}
+	*c.Missing = append(*c.Missing, pattern)
 }
 
 // substitution represents a single variable substitution pattern.
@@ -87,6 +102,12 @@ func handleLocalEnv(match []string, ctx *SubstitutionContext) string {
 	if value == "" && len(match) >= 3 {
 		value = match[2] // default value
 	}
+	if value == "" {
+		// Substitution replaces the pattern with "" per spec, which
+		// would otherwise hide the missing variable entirely — record it
+		// so the load path can warn.
+		ctx.recordMiss(match[0])
+	}
 	return value
 }
 
@@ -98,6 +119,9 @@ func handleContainerEnv(match []string, ctx *SubstitutionContext) string {
 	if value == "" && len(match) >= 3 {
 		value = match[2] // default value
 	}
+	if value == "" {
+		ctx.recordMiss(match[0])
+	}
 	return value
 }
 
@@ -176,10 +200,12 @@ func SubstituteConfig(cfg *DevContainerConfig, ctx *SubstitutionContext) {
 	}
 
 	// Substitute in string fields
+	cfg.Name = Substitute(cfg.Name, ctx)
 	cfg.Image = Substitute(cfg.Image, ctx)
 	cfg.WorkspaceFolder = Substitute(cfg.WorkspaceFolder, ctx)
 	cfg.WorkspaceMount = Substitute(cfg.WorkspaceMount, ctx)
 	cfg.RemoteUser = Substitute(cfg.RemoteUser, ctx)
+	cfg.ContainerUser = Substitute(cfg.ContainerUser, ctx)
 
 	// Substitute in build config
 	if cfg.Build != nil {
@@ -190,10 +216,21 @@ func SubstituteConfig(cfg *DevContainerConfig, ctx *SubstitutionContext) {
 		}
 	}
 
-	// Substitute in environment maps
+	// Substitute containerEnv first so ${containerEnv:VAR} references in
+	// remoteEnv (and hooks) resolve against the substituted values.
 	for k, v := range cfg.ContainerEnv {
 		cfg.ContainerEnv[k] = Substitute(v, ctx)
 	}
+	if len(cfg.ContainerEnv) > 0 {
+		merged := make(map[string]string, len(ctx.ContainerEnv)+len(cfg.ContainerEnv))
+		for k, v := range ctx.ContainerEnv {
+			merged[k] = v
+		}
+		for k, v := range cfg.ContainerEnv {
+			merged[k] = v
+		}
+		ctx.ContainerEnv = merged
+	}
 	for k, v := range cfg.RemoteEnv {
 		cfg.RemoteEnv[k] = Substitute(v, ctx)
 	}
@@ -211,6 +248,90 @@ func SubstituteConfig(cfg *DevContainerConfig, ctx *SubstitutionContext) {
 	for i, arg := range cfg.RunArgs {
 		cfg.RunArgs[i] = Substitute(arg, ctx)
 	}
+
+	// Substitute in lifecycle hooks (string, array, and map forms)
+	cfg.InitializeCommand = SubstituteCommandValue(cfg.InitializeCommand, ctx)
+	cfg.OnCreateCommand = SubstituteCommandValue(cfg.OnCreateCommand, ctx)
+	cfg.UpdateContentCommand = SubstituteCommandValue(cfg.UpdateContentCommand, ctx)
+	cfg.PostCreateCommand = SubstituteCommandValue(cfg.PostCreateCommand, ctx)
+	cfg.PostStartCommand = SubstituteCommandValue(cfg.PostStartCommand, ctx)
+	cfg.PostAttachCommand = SubstituteCommandValue(cfg.PostAttachCommand, ctx)
+
+	// Substitute in feature option values
+	for ref, opts := range cfg.Features {
+		if optMap, ok := opts.(map[string]interface{}); ok {
+			for k, v := range optMap {
+				if str, ok := v.(string); ok {
+					optMap[k] = Substitute(str, ctx)
+				}
+			}
+			cfg.Features[ref] = optMap
+		}
+	}
+}
+
+// SubstituteCommandValue substitutes variables in a lifecycle command,
+// which may be a string, an argv array, or a map of named commands (whose
+// values are again strings or arrays).
+func SubstituteCommandValue(v interface{}, ctx *SubstitutionContext) interface{} {
+	switch cmd := v.(type) {
+	case string:
+		return Substitute(cmd, ctx)
+	case []interface{}:
+		result := make([]interface{}, len(cmd))
+		for i, item := range cmd {
+			if str, ok := item.(string); ok {
+				result[i] = Substitute(str, ctx)
+			} else {
+				result[i] = item
+			}
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(cmd))
+		for name, sub := range cmd {
+			result[name] = SubstituteCommandValue(sub, ctx)
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+// unresolvedVarPattern matches devcontainer substitution variables that
+// survived all substitution passes. Restricted to the known variable
+// prefixes so shell syntax like ${PATH} inside lifecycle hooks isn't
+// flagged.
+var unresolvedVarPattern = regexp.MustCompile(`\$\{(?:localEnv|env|containerEnv|localWorkspaceFolder(?:Basename)?|containerWorkspaceFolder(?:Basename)?|devcontainerId|userHome|pathSeparator)(?::[^}]*)?\}`)
+
+// UnresolvedVariables returns the devcontainer substitution variables
+// that didn't resolve: patterns recorded as misses during substitution
+// (localEnv/containerEnv lookups that came back empty with no default —
+// the spec replaces those with "", which would otherwise hide them
+// completely) plus any patterns left intact in the config. Deduplicated
+// in first-seen order. Callers surface these as a warning rather than
+// failing, since an unresolved ${localEnv:FOO} usually means a missing
+// host variable.
+func UnresolvedVariables(cfg *DevContainerConfig) []string {
+	seen := make(map[string]bool)
+	var result []string
+	add := func(pattern string) {
+		if !seen[pattern] {
+			seen[pattern] = true
+			result = append(result, pattern)
+		}
+	}
+
+	for _, m := range cfg.SubstitutionMisses() {
+		add(m)
+	}
+
+	if data, err := json.Marshal(cfg); err == nil {
+		for _, m := range unresolvedVarPattern.FindAllString(string(data), -1) {
+			add(m)
+		}
+	}
+	return result
 }
 
 // DetermineContainerWorkspaceFolder computes the container workspace folder.
@@ -0,0 +1,114 @@
+package devcontainer
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubstituteCommandValue(t *testing.T) {
+	ctx := &SubstitutionContext{LocalWorkspaceFolder: "/ws"}
+
+	t.Run("string form", func(t *testing.T) {
+		got := SubstituteCommandValue("echo ${localWorkspaceFolder}", ctx)
+		assert.Equal(t, "echo /ws", got)
+	})
+
+	t.Run("array form", func(t *testing.T) {
+		got := SubstituteCommandValue([]interface{}{"ls", "${localWorkspaceFolder}"}, ctx)
+		assert.Equal(t, []interface{}{"ls", "/ws"}, got)
+	})
+
+	t.Run("map form", func(t *testing.T) {
+		got := SubstituteCommandValue(map[string]interface{}{
+			"build": "make -C ${localWorkspaceFolder}",
+			"lint":  []interface{}{"lint", "${localWorkspaceFolder}"},
+		}, ctx)
+		want := map[string]interface{}{
+			"build": "make -C /ws",
+			"lint":  []interface{}{"lint", "/ws"},
+		}
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("non-string passthrough", func(t *testing.T) {
+		assert.Equal(t, 42, SubstituteCommandValue(42, ctx))
+		assert.Nil(t, SubstituteCommandValue(nil, ctx))
+	})
+}
+
+func TestSubstituteConfigContainerEnvFeedsRemoteEnv(t *testing.T) {
+	cfg := &DevContainerConfig{
+		ContainerEnv: map[string]string{"APP_ROOT": "${containerWorkspaceFolder}/app"},
+		RemoteEnv:    map[string]string{"APP_CONFIG": "${containerEnv:APP_ROOT}/config"},
+	}
+	SubstituteConfig(cfg, &SubstitutionContext{ContainerWorkspaceFolder: "/workspaces/p"})
+
+	assert.Equal(t, "/workspaces/p/app", cfg.ContainerEnv["APP_ROOT"])
+	assert.Equal(t, "/workspaces/p/app/config", cfg.RemoteEnv["APP_CONFIG"])
+}
+
+func TestSubstituteConfigHooksAndFeatureOptions(t *testing.T) {
+	cfg := &DevContainerConfig{
+		PostCreateCommand: "setup ${localWorkspaceFolder}",
+		Features: map[string]interface{}{
+			"ghcr.io/devcontainers/features/go:1": map[string]interface{}{
+				"version": "${localEnv:MISSING:1.22}",
+			},
+		},
+	}
+	SubstituteConfig(cfg, &SubstitutionContext{
+		LocalWorkspaceFolder: "/ws",
+		LocalEnv:             func(string) string { return "" },
+	})
+
+	assert.Equal(t, "setup /ws", cfg.PostCreateCommand)
+	opts := cfg.Features["ghcr.io/devcontainers/features/go:1"].(map[string]interface{})
+	assert.Equal(t, "1.22", opts["version"])
+}
+
+func TestUnresolvedVariables(t *testing.T) {
+	cfg := &DevContainerConfig{
+		Image: "app:${localEnv:UNSET_TAG}",
+		ContainerEnv: map[string]string{
+			// Shell syntax in hook-style content must not be flagged.
+			"SCRIPT": "echo ${PATH} ${localEnv:UNSET_TAG}",
+		},
+	}
+
+	unresolved := UnresolvedVariables(cfg)
+	assert.Equal(t, []string{"${localEnv:UNSET_TAG}"}, unresolved)
+}
+
+func TestLoadRecordsSubstitutionMisses(t *testing.T) {
+	ws := t.TempDir()
+	dir := ws + "/.devcontainer"
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := `{
+	"image": "ubuntu:22.04",
+	"containerEnv": {
+		"FROM_HOST": "${localEnv:DCX_TEST_DEFINITELY_UNSET}",
+		"WITH_DEFAULT": "${localEnv:DCX_TEST_DEFINITELY_UNSET:fallback}"
+	}
+}`
+	if err := os.WriteFile(dir+"/devcontainer.json", []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, _, err := Load(ws, "")
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	// Substitution replaced the missing variable with "" per spec —
+	// the miss must still be reported, while the defaulted lookup is not.
+	assert.Equal(t, "", cfg.ContainerEnv["FROM_HOST"])
+	assert.Equal(t, "fallback", cfg.ContainerEnv["WITH_DEFAULT"])
+
+	unresolved := UnresolvedVariables(cfg)
+	assert.Contains(t, unresolved, "${localEnv:DCX_TEST_DEFINITELY_UNSET}")
+	assert.NotContains(t, unresolved, "${localEnv:DCX_TEST_DEFINITELY_UNSET:fallback}")
+}
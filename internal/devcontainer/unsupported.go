@@ -0,0 +1,55 @@
+package devcontainer
+
+import (
+	"fmt"
+	"sort"
+)
+
+// UnsupportedProperties lists configuration the parser accepts but dcx
+// does not (yet) apply, so users learn up front instead of wondering why
+// a setting had no effect. Returned strings name the property and the
+// limitation.
+func UnsupportedProperties(cfg *DevContainerConfig) []string {
+	if cfg == nil {
+		return nil
+	}
+	var ignored []string
+
+	// Tool-specific customizations other than dcx's own namespace are
+	// carried in labels/metadata but not applied by dcx itself.
+	var tools []string
+	for tool := range cfg.Customizations {
+		if tool != "dcx" {
+			tools = append(tools, tool)
+		}
+	}
+	sort.Strings(tools)
+	for _, tool := range tools {
+		ignored = append(ignored, fmt.Sprintf("customizations.%s (settings are not applied by dcx)", tool))
+	}
+
+	// Port attributes: only label/protocol are honored; auto-forward
+	// semantics are an editor concept.
+	for port, attr := range cfg.PortsAttributes {
+		attrMap, ok := attr.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if _, set := attrMap["onAutoForward"]; set {
+			ignored = append(ignored, fmt.Sprintf("portsAttributes[%q].onAutoForward (auto-forward is editor behavior)", port))
+		}
+		if _, set := attrMap["elevateIfNeeded"]; set {
+			ignored = append(ignored, fmt.Sprintf("portsAttributes[%q].elevateIfNeeded (not supported)", port))
+		}
+	}
+	if cfg.OtherPortsAttributes != nil {
+		ignored = append(ignored, "otherPortsAttributes (not supported)")
+	}
+
+	// waitFor: dcx always runs the full create-hook chain synchronously.
+	if cfg.WaitFor != "" {
+		ignored = append(ignored, "waitFor (dcx runs all create hooks synchronously)")
+	}
+
+	return ignored
+}
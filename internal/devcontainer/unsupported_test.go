@@ -0,0 +1,40 @@
+package devcontainer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnsupportedProperties(t *testing.T) {
+	t.Run("nil and clean configs", func(t *testing.T) {
+		assert.Empty(t, UnsupportedProperties(nil))
+		assert.Empty(t, UnsupportedProperties(&DevContainerConfig{Image: "ubuntu"}))
+	})
+
+	t.Run("dcx customizations are not flagged", func(t *testing.T) {
+		cfg := &DevContainerConfig{
+			Customizations: map[string]interface{}{
+				"dcx": map[string]interface{}{"shortcuts": map[string]interface{}{}},
+			},
+		}
+		assert.Empty(t, UnsupportedProperties(cfg))
+	})
+
+	t.Run("ignored properties are listed", func(t *testing.T) {
+		cfg := &DevContainerConfig{
+			Customizations: map[string]interface{}{
+				"vscode": map[string]interface{}{"settings": map[string]interface{}{}},
+			},
+			PortsAttributes: map[string]interface{}{
+				"3000": map[string]interface{}{"onAutoForward": "openBrowser"},
+			},
+			OtherPortsAttributes: map[string]interface{}{"onAutoForward": "ignore"},
+			WaitFor:              "postCreateCommand",
+		}
+
+		ignored := UnsupportedProperties(cfg)
+		assert.Len(t, ignored, 4)
+		assert.Contains(t, ignored[0], "customizations.vscode")
+	})
+}
@@ -2,6 +2,7 @@ package devcontainer
 
 import (
 	"fmt"
+	"os"
 	"strings"
 )
 
@@ -111,6 +112,41 @@ func Validate(cfg *DevContainerConfig) error {
 	return nil
 }
 
+// ValidateSecurityOpts checks seccomp/AppArmor securityOpt entries:
+// seccomp profile paths must exist (builtin "unconfined" and
+// "builtin"/"default" pass), and apparmor/seccomp entries must be
+// well-formed key=value pairs. Other securityOpt entries pass through
+// unchecked.
+func ValidateSecurityOpts(opts []string) error {
+	for _, opt := range opts {
+		key, value, found := strings.Cut(opt, "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "seccomp":
+			switch value {
+			case "unconfined", "builtin", "default":
+				continue
+			}
+			if _, err := os.Stat(value); err != nil {
+				return &ValidationError{
+					Field:   "securityOpt",
+					Message: fmt.Sprintf("seccomp profile %q not found", value),
+				}
+			}
+		case "apparmor":
+			if value == "" {
+				return &ValidationError{
+					Field:   "securityOpt",
+					Message: "apparmor requires a profile name or \"unconfined\"",
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // isAbsolutePath checks if a path is absolute.
 func isAbsolutePath(path string) bool {
 	// Unix absolute path
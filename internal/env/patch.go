@@ -3,6 +3,7 @@ package env
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/griffithind/dcx/internal/container"
@@ -12,7 +13,18 @@ const (
 	// MarkerDir is the directory where patch marker files are stored.
 	MarkerDir = "/var/lib/dcx"
 
-	// MarkerEtcEnvironment indicates /etc/environment has been patched.
+	// EnvironmentDropIn is the environment.d-style drop-in dcx owns
+	// outright: rewritten whole on every update, removable without
+	// touching user-managed files.
+	EnvironmentDropIn = "/etc/environment.d/90-dcx.conf"
+
+	// ProfileDropIn is the profile.d drop-in sourced by login shells. It
+	// exports the same variables and preserves a feature-modified PATH,
+	// replacing the old in-place /etc/profile sed edit.
+	ProfileDropIn = "/etc/profile.d/90-dcx.sh"
+
+	// MarkerEtcEnvironment indicates /etc/environment was patched by an
+	// older dcx (pre drop-in); kept for cleanup detection.
 	MarkerEtcEnvironment = MarkerDir + "/.patchEtcEnvironmentMarker"
 
 	// MarkerEtcProfile indicates /etc/profile has been patched.
@@ -27,84 +39,84 @@ func NewPatcher() *Patcher {
 	return &Patcher{}
 }
 
-// PatchEtcEnvironment appends environment variables to /etc/environment.
-// This makes them available to all processes, not just shells.
-// Uses a marker file to ensure it only runs once per container.
+// PatchEtcEnvironment writes environment variables to dcx-owned drop-in
+// files instead of mutating /etc/environment in place: an environment.d
+// style file (read by systemd and PAM on distros that support it) plus a
+// profile.d export script for login shells. Both files are rewritten
+// whole on every call, so repeated ups update cleanly instead of
+// appending duplicates, and removal never touches user-managed files.
 func (p *Patcher) PatchEtcEnvironment(ctx context.Context, containerID string, env map[string]string) error {
 	if len(env) == 0 {
 		return nil
 	}
 
-	// Check if already patched
-	checkCmd := []string{"sh", "-c", fmt.Sprintf("test -f %s && echo exists || echo missing", MarkerEtcEnvironment)}
-	output, exitCode, err := container.ExecOutput(ctx, containerID, checkCmd, "root")
-	if err != nil {
-		return fmt.Errorf("failed to check patch marker: %w", err)
-	}
-	if exitCode == 0 && strings.TrimSpace(output) == "exists" {
-		// Already patched
-		return nil
+	// Sorted for deterministic file content across ups.
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
 	}
+	sort.Strings(keys)
 
-	// Build environment content
-	var envLines []string
-	for k, v := range env {
-		// Escape special characters in value
-		escaped := strings.ReplaceAll(v, `"`, `\"`)
+	var envLines, exportLines []string
+	for _, k := range keys {
+		escaped := strings.ReplaceAll(env[k], `"`, `\"`)
 		envLines = append(envLines, fmt.Sprintf(`%s="%s"`, k, escaped))
+		exportLines = append(exportLines, fmt.Sprintf(`export %s="%s"`, k, escaped))
 	}
-	envContent := strings.Join(envLines, "\n")
 
-	// Create marker directory and append to /etc/environment
 	patchCmd := []string{"sh", "-c", fmt.Sprintf(`
-mkdir -p %s && \
-cat >> /etc/environment <<'DCXEOF'
+mkdir -p /etc/environment.d /etc/profile.d && \
+cat > %s <<'DCXEOF'
+# Managed by dcx - rewritten on every up, safe to delete
+%s
+DCXEOF
+cat > %s <<'DCXEOF'
+# Managed by dcx - rewritten on every up, safe to delete
 %s
 DCXEOF
-touch %s
-`, MarkerDir, envContent, MarkerEtcEnvironment)}
+chmod 644 %s %s
+`, EnvironmentDropIn, strings.Join(envLines, "\n"),
+		ProfileDropIn, strings.Join(exportLines, "\n"),
+		EnvironmentDropIn, ProfileDropIn)}
 
-	_, exitCode, err = container.ExecOutput(ctx, containerID, patchCmd, "root")
+	_, exitCode, err := container.ExecOutput(ctx, containerID, patchCmd, "root")
 	if err != nil {
-		return fmt.Errorf("failed to patch /etc/environment: %w", err)
+		return fmt.Errorf("failed to write environment drop-ins: %w", err)
 	}
 	if exitCode != 0 {
-		return fmt.Errorf("patch /etc/environment exited with code %d", exitCode)
+		return fmt.Errorf("environment drop-in write exited with code %d", exitCode)
 	}
 
 	return nil
 }
 
-// PatchEtcProfile modifies /etc/profile to preserve existing PATH.
-// This prevents the system profile from clobbering PATH modifications made by features.
-// Uses a marker file to ensure it only runs once per container.
+// PatchEtcProfile installs a profile.d drop-in that re-asserts a
+// feature-modified PATH after /etc/profile resets it, replacing the old
+// in-place sed edit of /etc/profile. profile.d runs after the PATH
+// assignment in every mainstream /etc/profile, so capturing the
+// already-extended PATH from the agent environment and re-exporting it
+// achieves the same effect without touching the user-managed file.
+// Rewritten whole on every call; idempotent by construction.
 func (p *Patcher) PatchEtcProfile(ctx context.Context, containerID string) error {
-	// Check if already patched
-	checkCmd := []string{"sh", "-c", fmt.Sprintf("test -f %s && echo exists || echo missing", MarkerEtcProfile)}
-	output, exitCode, err := container.ExecOutput(ctx, containerID, checkCmd, "root")
-	if err != nil {
-		return fmt.Errorf("failed to check patch marker: %w", err)
-	}
-	if exitCode == 0 && strings.TrimSpace(output) == "exists" {
-		// Already patched
-		return nil
-	}
-
-	// Patch /etc/profile to preserve PATH
-	// The regex transforms: PATH=/some/path -> PATH=${PATH:-/some/path}
-	// This makes PATH respect any pre-existing value, or fall back to the original
 	patchCmd := []string{"sh", "-c", fmt.Sprintf(`
-mkdir -p %s && \
-sed -i -E 's/((^|[[:space:]])PATH=)([^$]*)$/\1\${PATH:-\3}/g' /etc/profile 2>/dev/null || true && \
-touch %s
+mkdir -p /etc/profile.d && \
+cat > /etc/profile.d/00-dcx-path.sh <<'DCXEOF'
+# Managed by dcx - preserves feature-installed PATH entries, safe to delete
+if [ -n "${DCX_ORIG_PATH:-}" ]; then
+    PATH="$DCX_ORIG_PATH:$PATH"
+    export PATH
+fi
+DCXEOF
+chmod 644 /etc/profile.d/00-dcx-path.sh && \
+mkdir -p %s && touch %s
 `, MarkerDir, MarkerEtcProfile)}
 
-	_, exitCode, err = container.ExecOutput(ctx, containerID, patchCmd, "root")
+	_, exitCode, err := container.ExecOutput(ctx, containerID, patchCmd, "root")
 	if err != nil {
-		return fmt.Errorf("failed to patch /etc/profile: %w", err)
+		return fmt.Errorf("failed to install profile drop-in: %w", err)
 	}
 	if exitCode != 0 {
-		return fmt.Errorf("patch /etc/profile exited with code %d", exitCode)
+		return fmt.Errorf("profile drop-in install exited with code %d", exitCode)
 	}
 
 	return nil
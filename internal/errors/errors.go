@@ -2,10 +2,17 @@
 package errors
 
 import (
+	stderrors "errors"
 	"fmt"
 	"strings"
 )
 
+// As wraps the standard errors.As so callers of this package don't need
+// both imports.
+func As(err error, target interface{}) bool {
+	return stderrors.As(err, target)
+}
+
 // Category represents the error category.
 type Category string
 
@@ -21,8 +28,51 @@ const (
 	CategoryOCI       Category = "oci"
 	CategoryIO        Category = "io"
 	CategoryInternal  Category = "internal"
+	CategoryHostReqs  Category = "host-requirements"
+)
+
+// Process exit codes per failure class, so scripts and CI can branch on
+// the kind of failure without parsing messages.
+const (
+	ExitGeneral           = 1
+	ExitConfigInvalid     = 2
+	ExitDockerUnreachable = 3
+	ExitBuildFailed       = 4
+	ExitHooksFailed       = 5
+	ExitHostRequirements  = 6
 )
 
+// ExitCode maps an error to its process exit code: typed DCXErrors map
+// by category, everything else is the general failure code.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var dcxErr *DCXError
+	if !As(err, &dcxErr) {
+		return ExitGeneral
+	}
+	switch dcxErr.Category {
+	case CategoryConfig:
+		return ExitConfigInvalid
+	case CategoryDocker:
+		return ExitDockerUnreachable
+	case CategoryBuild, CategoryFeatures:
+		return ExitBuildFailed
+	case CategoryLifecycle:
+		return ExitHooksFailed
+	case CategoryHostReqs:
+		return ExitHostRequirements
+	default:
+		return ExitGeneral
+	}
+}
+
+// New creates a DCXError.
+func New(category Category, code, message string) *DCXError {
+	return &DCXError{Category: category, Code: code, Message: message}
+}
+
 // Error codes for each category.
 const (
 	// Config errors
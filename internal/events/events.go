@@ -0,0 +1,118 @@
+// Package events emits dcx lifecycle events (container created, hooks
+// finished, ports forwarded, state changed) to pluggable sinks: an
+// append-only JSONL log that `dcx events --follow` tails, an optional
+// webhook URL, and an optional exec'd user script — enabling notifications
+// and custom automation without wrapping the CLI.
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/griffithind/dcx/internal/config"
+)
+
+// Event types emitted by dcx.
+const (
+	TypeContainerCreated = "container.created"
+	TypeHooksFinished    = "hooks.finished"
+	TypePortsForwarded   = "ports.forwarded"
+	TypeStateChanged     = "state.changed"
+)
+
+// Event is a single lifecycle event.
+type Event struct {
+	Time          time.Time         `json:"time"`
+	Type          string            `json:"type"`
+	WorkspaceID   string            `json:"workspaceID,omitempty"`
+	WorkspaceName string            `json:"workspaceName,omitempty"`
+	Data          map[string]string `json:"data,omitempty"`
+}
+
+// LogPath returns the JSONL event log location (~/.dcx/events.jsonl).
+func LogPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".dcx", "events.jsonl")
+}
+
+// Emit delivers an event to every configured sink. Sink failures are
+// silent — events are advisory and must never break a lifecycle
+// operation.
+func Emit(evtType, workspaceID, workspaceName string, data map[string]string) {
+	evt := Event{
+		Time:          time.Now(),
+		Type:          evtType,
+		WorkspaceID:   workspaceID,
+		WorkspaceName: workspaceName,
+		Data:          data,
+	}
+
+	payload, err := json.Marshal(evt)
+	if err != nil {
+		return
+	}
+
+	appendToLog(payload)
+
+	userCfg, err := config.User()
+	if err != nil {
+		return
+	}
+	if userCfg.Events.Webhook != "" {
+		postWebhook(userCfg.Events.Webhook, payload)
+	}
+	if userCfg.Events.ExecHook != "" {
+		runExecHook(userCfg.Events.ExecHook, evt, payload)
+	}
+}
+
+// appendToLog appends the event line to the JSONL log.
+func appendToLog(payload []byte) {
+	path := LogPath()
+	if path == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	defer func() { _ = f.Close() }()
+	_, _ = f.Write(append(payload, '\n'))
+}
+
+// postWebhook POSTs the event JSON to the configured URL with a short
+// timeout so a slow endpoint can't stall lifecycle operations.
+func postWebhook(url string, payload []byte) {
+	client := &http.Client{Timeout: 3 * time.Second}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return
+	}
+	_ = resp.Body.Close()
+}
+
+// runExecHook invokes the user script with the event type as argument and
+// the full JSON payload on stdin plus DCX_EVENT_* variables in the
+// environment.
+func runExecHook(script string, evt Event, payload []byte) {
+	cmd := exec.Command("sh", "-c", script+" "+evt.Type)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(),
+		"DCX_EVENT_TYPE="+evt.Type,
+		"DCX_EVENT_WORKSPACE="+evt.WorkspaceName,
+		"DCX_EVENT_WORKSPACE_ID="+evt.WorkspaceID,
+	)
+	_ = cmd.Start()
+	go func() { _ = cmd.Wait() }()
+}
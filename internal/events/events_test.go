@@ -0,0 +1,54 @@
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEmitAppendsToLog(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	Emit(TypeContainerCreated, "ws1", "demo", map[string]string{"container": "demo"})
+	Emit(TypeHooksFinished, "ws1", "demo", nil)
+
+	f, err := os.Open(filepath.Join(home, ".dcx", "events.jsonl"))
+	if err != nil {
+		t.Fatalf("event log not written: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var events []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("log line is not JSON: %v", err)
+		}
+		events = append(events, e)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if events[0].Type != TypeContainerCreated || events[0].Data["container"] != "demo" {
+		t.Errorf("first event malformed: %+v", events[0])
+	}
+	if events[0].Time.IsZero() || events[0].WorkspaceID != "ws1" {
+		t.Errorf("event missing time/workspace: %+v", events[0])
+	}
+	if events[1].Type != TypeHooksFinished {
+		t.Errorf("second event malformed: %+v", events[1])
+	}
+}
+
+func TestLogPathUsesHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if got := LogPath(); got != filepath.Join(home, ".dcx", "events.jsonl") {
+		t.Errorf("LogPath() = %q", got)
+	}
+}
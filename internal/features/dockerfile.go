@@ -17,6 +17,7 @@ type DockerfileGenerator struct {
 	containerUser     string
 	containerUserHome string
 	metadata          string
+	cacheDirs         []string
 }
 
 // NewDockerfileGenerator creates a new Dockerfile generator.
@@ -54,6 +55,12 @@ func (g *DockerfileGenerator) SetMetadata(metadata string) {
 	g.metadata = metadata
 }
 
+// SetCacheDirs configures persistent BuildKit cache mounts attached to
+// every feature install RUN (opt-in via customizations.dcx.buildCache).
+func (g *DockerfileGenerator) SetCacheDirs(dirs []string) {
+	g.cacheDirs = dirs
+}
+
 // Generate creates the Dockerfile content.
 // Uses BuildKit build contexts with RUN --mount for efficient feature installation.
 func (g *DockerfileGenerator) Generate() string {
@@ -144,6 +151,9 @@ func (g *DockerfileGenerator) generateFeatureInstall(sb *strings.Builder, featur
 	// Use RUN --mount to bind mount feature content from build context
 	// This is more efficient than COPY as content is not persisted in the layer
 	fmt.Fprintf(sb, "RUN --mount=type=bind,from=dev_containers_feature_content_source,source=%s,target=/tmp/build-features-src/%s \\\n", featureDir, featureDir)
+	for _, dir := range g.cacheDirs {
+		fmt.Fprintf(sb, "    --mount=type=cache,target=%s,sharing=locked,id=dcx%s \\\n", dir, strings.ReplaceAll(dir, "/", "-"))
+	}
 	fmt.Fprintf(sb, "    cp -ar /tmp/build-features-src/%s /tmp/dcx-features/%s && \\\n", featureDir, featureDir)
 	fmt.Fprintf(sb, "    chmod -R 0755 /tmp/dcx-features/%s && \\\n", featureDir)
 	fmt.Fprintf(sb, "    cd /tmp/dcx-features/%s && \\\n", featureDir)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/griffithind/dcx/internal/lockfile"
+	"github.com/griffithind/dcx/internal/ui"
 )
 
 // Manager handles feature resolution, ordering, and installation.
@@ -49,6 +50,12 @@ func (m *Manager) ResolveAll(ctx context.Context, featuresConfig map[string]inte
 	// Track resolved features by their metadata ID
 	resolved := make(map[string]*Feature)
 
+	// Render feature fetches as concurrent-style progress lines (plain
+	// log lines when not a TTY).
+	progress := ui.NewMultiProgress()
+	progress.Start()
+	defer progress.Stop()
+
 	// Resolve each feature from config
 	for id, optionsRaw := range featuresConfig {
 		// Parse options
@@ -67,10 +74,13 @@ func (m *Manager) ResolveAll(ctx context.Context, featuresConfig map[string]inte
 		}
 
 		// Resolve the feature (with lockfile if set)
+		task := progress.AddTask("fetch " + id)
 		feature, err := m.resolver.ResolveWithLockfile(ctx, id, options, m.lockfile)
 		if err != nil {
+			task.Fail()
 			return nil, fmt.Errorf("failed to resolve feature %q: %w", id, err)
 		}
+		task.Done()
 
 		// Use metadata ID as key if available
 		key := id
@@ -0,0 +1,128 @@
+package features
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateOptions checks every user-supplied option against the feature's
+// devcontainer-feature.json options schema: unknown names (with a
+// did-you-mean suggestion for near-misses), type mismatches, and values
+// outside an enum. Called at Load time so a typo fails fast instead of as
+// a cryptic install.sh error mid-build. Features without metadata are
+// skipped.
+func ValidateOptions(feature *Feature) error {
+	if feature.Metadata == nil || len(feature.Options) == 0 {
+		return nil
+	}
+	defs := feature.Metadata.Options
+
+	for name, value := range feature.Options {
+		def, ok := defs[name]
+		if !ok {
+			if suggestion := nearestOptionName(name, defs); suggestion != "" {
+				return fmt.Errorf("feature %s: option %q is not defined, did you mean %q?", feature.ID, name, suggestion)
+			}
+			return fmt.Errorf("feature %s: option %q is not defined", feature.ID, name)
+		}
+
+		if err := checkOptionValue(def, value); err != nil {
+			return fmt.Errorf("feature %s: option %q: %w", feature.ID, name, err)
+		}
+	}
+	return nil
+}
+
+// checkOptionValue validates a single value against its definition.
+func checkOptionValue(def OptionDefinition, value interface{}) error {
+	switch def.Type {
+	case "boolean":
+		switch v := value.(type) {
+		case bool:
+			return nil
+		case string:
+			// The spec tolerates "true"/"false" strings for booleans.
+			if v == "true" || v == "false" {
+				return nil
+			}
+			return fmt.Errorf("expected a boolean, got %q", v)
+		default:
+			return fmt.Errorf("expected a boolean, got %T", value)
+		}
+
+	case "string", "":
+		str, ok := value.(string)
+		if !ok {
+			// Numbers are commonly written unquoted for version options;
+			// the spec stringifies them, so accept scalars.
+			switch value.(type) {
+			case float64, int, bool:
+				return nil
+			}
+			return fmt.Errorf("expected a string, got %T", value)
+		}
+		if len(def.Enum) > 0 && !containsOption(def.Enum, str) {
+			return fmt.Errorf("invalid value %q (one of: %s)", str, strings.Join(def.Enum, ", "))
+		}
+		return nil
+	}
+	// Unknown schema type — leave validation to the feature itself.
+	return nil
+}
+
+// nearestOptionName finds a defined option within edit distance 2 of the
+// given name, for "did you mean" suggestions on typos.
+func nearestOptionName(name string, defs map[string]OptionDefinition) string {
+	best := ""
+	bestDist := 3 // only suggest close matches
+	for candidate := range defs {
+		if d := editDistance(strings.ToLower(name), strings.ToLower(candidate)); d < bestDist {
+			best = candidate
+			bestDist = d
+		}
+	}
+	return best
+}
+
+// editDistance computes the Levenshtein distance between two strings.
+func editDistance(a, b string) int {
+	if a == b {
+		return 0
+	}
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := 0; j <= len(b); j++ {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+func containsOption(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package features
+
+import (
+	"strings"
+	"testing"
+)
+
+func featureWithOptions(opts map[string]interface{}, defs map[string]OptionDefinition) *Feature {
+	return &Feature{
+		ID:       "ghcr.io/devcontainers/features/go:1",
+		Options:  opts,
+		Metadata: &FeatureMetadata{Options: defs},
+	}
+}
+
+func TestValidateOptionsValid(t *testing.T) {
+	f := featureWithOptions(
+		map[string]interface{}{"version": "1.22", "installTools": true},
+		map[string]OptionDefinition{
+			"version":      {Type: "string", Proposals: []string{"latest", "1.22"}},
+			"installTools": {Type: "boolean", Default: true},
+		},
+	)
+	if err := ValidateOptions(f); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateOptionsTypoSuggestion(t *testing.T) {
+	f := featureWithOptions(
+		map[string]interface{}{"verison": "1.22"},
+		map[string]OptionDefinition{"version": {Type: "string"}},
+	)
+	err := ValidateOptions(f)
+	if err == nil {
+		t.Fatal("expected error for unknown option")
+	}
+	if !strings.Contains(err.Error(), `did you mean "version"`) {
+		t.Errorf("expected a did-you-mean suggestion, got: %v", err)
+	}
+}
+
+func TestValidateOptionsEnum(t *testing.T) {
+	defs := map[string]OptionDefinition{
+		"flavor": {Type: "string", Enum: []string{"full", "minimal"}},
+	}
+
+	if err := ValidateOptions(featureWithOptions(map[string]interface{}{"flavor": "minimal"}, defs)); err != nil {
+		t.Errorf("valid enum value rejected: %v", err)
+	}
+	err := ValidateOptions(featureWithOptions(map[string]interface{}{"flavor": "huge"}, defs))
+	if err == nil || !strings.Contains(err.Error(), "full, minimal") {
+		t.Errorf("expected enum error listing values, got: %v", err)
+	}
+}
+
+func TestValidateOptionsBooleanType(t *testing.T) {
+	defs := map[string]OptionDefinition{"enabled": {Type: "boolean"}}
+
+	if err := ValidateOptions(featureWithOptions(map[string]interface{}{"enabled": "true"}, defs)); err != nil {
+		t.Errorf("string boolean should be accepted: %v", err)
+	}
+	if err := ValidateOptions(featureWithOptions(map[string]interface{}{"enabled": 42.0}, defs)); err == nil {
+		t.Error("expected type error for numeric boolean")
+	}
+}
+
+func TestValidateOptionsNoMetadata(t *testing.T) {
+	f := &Feature{ID: "local", Options: map[string]interface{}{"anything": 1}}
+	if err := ValidateOptions(f); err != nil {
+		t.Errorf("features without metadata should be skipped: %v", err)
+	}
+}
@@ -0,0 +1,174 @@
+// Package hostvm detects which container VM flavor backs the Docker daemon
+// on macOS (Docker Desktop, Colima, Lima, Rancher Desktop). Non-Desktop VMs
+// differ in mount semantics, SSH agent socket forwarding, and
+// host.docker.internal availability, so callers use the detected kind to
+// adjust behavior and surface flavor-specific guidance instead of generic
+// errors.
+package hostvm
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// Kind identifies the VM flavor backing the Docker daemon.
+type Kind string
+
+const (
+	// KindNone means the daemon runs natively (Linux hosts).
+	KindNone Kind = "none"
+
+	// KindDockerDesktop is Docker Desktop's VM.
+	KindDockerDesktop Kind = "docker-desktop"
+
+	// KindColima is a Colima-managed Lima VM.
+	KindColima Kind = "colima"
+
+	// KindLima is a plain Lima VM (not managed by Colima or Rancher Desktop).
+	KindLima Kind = "lima"
+
+	// KindRancherDesktop is Rancher Desktop's Lima VM.
+	KindRancherDesktop Kind = "rancher-desktop"
+
+	// KindUnknown means a VM is likely present but could not be classified.
+	KindUnknown Kind = "unknown"
+)
+
+// Info describes the detected VM and the behavioral quirks dcx cares about.
+type Info struct {
+	Kind Kind
+
+	// VirtiofsLikely reports whether the VM most likely shares the
+	// filesystem via virtiofs, where the legacy cached/delegated mount
+	// consistency flags are no-ops (and newer daemons warn about them).
+	VirtiofsLikely bool
+
+	// HostDockerInternal reports whether host.docker.internal resolves
+	// inside containers without extra configuration.
+	HostDockerInternal bool
+}
+
+var (
+	detected   *Info
+	detectOnce sync.Once
+)
+
+// Detect classifies the VM backing the current Docker daemon. The result is
+// cached for the process lifetime. On Linux hosts this always returns
+// KindNone.
+func Detect(ctx context.Context) *Info {
+	detectOnce.Do(func() {
+		detected = detect(ctx)
+	})
+	return detected
+}
+
+func detect(ctx context.Context) *Info {
+	if runtime.GOOS == "linux" {
+		return &Info{Kind: KindNone, HostDockerInternal: false}
+	}
+
+	name, operatingSystem := daemonIdentity(ctx)
+	kind := Classify(name, operatingSystem, os.Getenv("DOCKER_HOST"))
+	return infoFor(kind)
+}
+
+// daemonIdentity returns the daemon's Name and OperatingSystem fields from
+// `docker info`. Both are empty if the daemon is unreachable.
+func daemonIdentity(ctx context.Context) (name, operatingSystem string) {
+	cmd := exec.CommandContext(ctx, "docker", "info", "--format", "{{.Name}}\t{{.OperatingSystem}}")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", ""
+	}
+	parts := strings.SplitN(strings.TrimSpace(string(output)), "\t", 2)
+	name = parts[0]
+	if len(parts) == 2 {
+		operatingSystem = parts[1]
+	}
+	return name, operatingSystem
+}
+
+// Classify maps the daemon identity (docker info Name/OperatingSystem) and
+// the DOCKER_HOST endpoint to a VM kind. Split out from Detect for
+// testability; it does not touch the daemon.
+func Classify(name, operatingSystem, dockerHost string) Kind {
+	switch {
+	case strings.Contains(operatingSystem, "Docker Desktop"):
+		return KindDockerDesktop
+	case name == "colima" || strings.HasPrefix(name, "colima-"):
+		return KindColima
+	case strings.Contains(name, "rancher-desktop"):
+		return KindRancherDesktop
+	case strings.HasPrefix(name, "lima-"):
+		return KindLima
+	}
+
+	// Fall back to well-known socket locations in DOCKER_HOST.
+	switch {
+	case strings.Contains(dockerHost, "/.colima/"):
+		return KindColima
+	case strings.Contains(dockerHost, "/.rd/"):
+		return KindRancherDesktop
+	case strings.Contains(dockerHost, "/.lima/"):
+		return KindLima
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		if _, err := os.Stat(filepath.Join(home, ".colima", "default", "docker.sock")); err == nil {
+			return KindColima
+		}
+	}
+
+	return KindUnknown
+}
+
+// infoFor fills in the behavioral quirks for a classified kind.
+func infoFor(kind Kind) *Info {
+	switch kind {
+	case KindDockerDesktop:
+		return &Info{Kind: kind, VirtiofsLikely: true, HostDockerInternal: true}
+	case KindColima, KindLima:
+		// Lima-based VMs default to virtiofs on recent versions; they do
+		// not wire up host.docker.internal unless configured.
+		return &Info{Kind: kind, VirtiofsLikely: true, HostDockerInternal: false}
+	case KindRancherDesktop:
+		return &Info{Kind: kind, VirtiofsLikely: true, HostDockerInternal: true}
+	default:
+		return &Info{Kind: kind}
+	}
+}
+
+// SuppressMountConsistency reports whether cached/delegated mount
+// consistency flags should be dropped for this VM (virtiofs ignores them
+// and newer daemons warn).
+func (i *Info) SuppressMountConsistency() bool {
+	return i.VirtiofsLikely && i.Kind != KindNone
+}
+
+// Guidance returns flavor-specific hints surfaced by `dcx doctor`.
+func (i *Info) Guidance() []string {
+	switch i.Kind {
+	case KindColima:
+		return []string{
+			"host.docker.internal is not available by default; start colima with --network-address or add an extra_hosts entry",
+			"SSH agent forwarding requires colima started with --ssh-agent",
+		}
+	case KindLima:
+		return []string{
+			"host.docker.internal is not available by default inside Lima VMs",
+			"forward your SSH agent socket into the VM via lima's ssh.forwardAgent setting",
+		}
+	case KindRancherDesktop:
+		return []string{
+			"bind mount performance depends on the configured volume mount type (virtiofs recommended)",
+		}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,92 @@
+// Package idle implements idle detection for dcx-managed containers,
+// shared by the `dcx idle-watch` command and the background daemon. The
+// dcx-agent stamps its last SSH activity inside each container; Sweep
+// reads those timestamps and stops containers idle beyond a timeout.
+package idle
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/griffithind/dcx/internal/container"
+	"github.com/griffithind/dcx/internal/ssh/server"
+	"github.com/griffithind/dcx/internal/state"
+	"github.com/griffithind/dcx/internal/ui"
+)
+
+// Sweep stops every running dcx container idle beyond the timeout.
+// Containers whose devcontainer.json sets shutdownAction to "none" are
+// never stopped. Errors are reported but don't abort the sweep.
+func Sweep(ctx context.Context, docker *container.Docker, timeout time.Duration) {
+	containers, err := docker.ListContainersWithLabels(ctx, map[string]string{
+		state.LabelManaged: "true",
+	})
+	if err != nil {
+		ui.Warning("idle: failed to list containers: %v", err)
+		return
+	}
+
+	for _, c := range containers {
+		if !c.Running {
+			continue
+		}
+		lbls := state.ContainerLabelsFromMap(c.Labels)
+		if lbls.ShutdownAction == "none" {
+			continue
+		}
+
+		// A live session is never idle: the agent only stamps activity
+		// at session start/end, so a long-running shell or editor
+		// connection would otherwise look stale and get stopped out
+		// from under the user.
+		if ActiveSessions(ctx, docker, c.Name) > 0 {
+			continue
+		}
+
+		idleFor, ok := Duration(ctx, docker, c.Name)
+		if !ok || idleFor < timeout {
+			continue
+		}
+
+		ui.Printf("Stopping %s (idle %s)", c.Name, idleFor.Round(time.Minute))
+		if err := docker.StopContainer(ctx, c.ID, nil); err != nil {
+			ui.Warning("idle: failed to stop %s: %v", c.Name, err)
+		}
+	}
+}
+
+// ActiveSessions reads the agent's live session count from the
+// container. Returns 0 when the file is missing (no agent or no session
+// yet).
+func ActiveSessions(ctx context.Context, docker *container.Docker, containerName string) int {
+	output, err := docker.SimpleExecInContainer(ctx, containerName, container.SimpleExecOptions{
+		Cmd: []string{"cat", server.SessionCountPath},
+	})
+	if err != nil {
+		return 0
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(string(output)))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+// Duration reads the agent's last-activity timestamp from the container.
+// Returns ok=false when the file is missing (no agent, or no session yet —
+// treated as "not idle" so fresh containers aren't stopped).
+func Duration(ctx context.Context, docker *container.Docker, containerName string) (time.Duration, bool) {
+	output, err := docker.SimpleExecInContainer(ctx, containerName, container.SimpleExecOptions{
+		Cmd: []string{"cat", server.LastActivityPath},
+	})
+	if err != nil {
+		return 0, false
+	}
+	ts, err := strconv.ParseInt(strings.TrimSpace(string(output)), 10, 64)
+	if err != nil || ts <= 0 {
+		return 0, false
+	}
+	return time.Since(time.Unix(ts, 0)), true
+}
@@ -0,0 +1,79 @@
+// Package logging routes slog output to a persistent, size-rotated log
+// file under the XDG state directory, so intermittent failures (e.g.
+// during background port forwarding) can be diagnosed after the fact via
+// `dcx logs --self`.
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// maxLogSize is the rotation threshold for the current log file.
+const maxLogSize = 5 * 1024 * 1024
+
+// rotatedGenerations is how many rotated files are kept (dcx.log.1 ..).
+const rotatedGenerations = 3
+
+// Dir returns the log directory: $XDG_STATE_HOME/dcx/logs, defaulting to
+// ~/.local/state/dcx/logs.
+func Dir() string {
+	if stateHome := os.Getenv("XDG_STATE_HOME"); stateHome != "" {
+		return filepath.Join(stateHome, "dcx", "logs")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".local", "state", "dcx", "logs")
+}
+
+// Path returns the current log file location.
+func Path() string {
+	dir := Dir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "dcx.log")
+}
+
+// Setup rotates the log file if needed, opens it, and installs a JSON
+// slog handler at the given level as the default logger. Best-effort: on
+// any failure the default logger is left untouched and dcx runs without
+// a log file.
+func Setup(level slog.Level) {
+	path := Path()
+	if path == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+
+	rotateIfNeeded(path)
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+
+	handler := slog.NewJSONHandler(f, &slog.HandlerOptions{Level: level})
+	slog.SetDefault(slog.New(handler))
+}
+
+// rotateIfNeeded shifts dcx.log → dcx.log.1 → … when the current file
+// exceeds the size threshold, dropping the oldest generation.
+func rotateIfNeeded(path string) {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() < maxLogSize {
+		return
+	}
+
+	_ = os.Remove(fmt.Sprintf("%s.%d", path, rotatedGenerations))
+	for i := rotatedGenerations - 1; i >= 1; i-- {
+		_ = os.Rename(fmt.Sprintf("%s.%d", path, i), fmt.Sprintf("%s.%d", path, i+1))
+	}
+	_ = os.Rename(path, path+".1")
+}
@@ -0,0 +1,101 @@
+// Package metrics implements the explicitly opt-in usage metrics module:
+// command counts, durations, and error categories, written to a local
+// JSONL file and optionally POSTed to a team-configured endpoint.
+// Fully disabled unless the user config sets telemetry: true — no
+// payload ever leaves the machine by default.
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/griffithind/dcx/internal/config"
+	dcxerrors "github.com/griffithind/dcx/internal/errors"
+)
+
+// Record is one command invocation. No identifiers beyond the command
+// name are collected — no arguments, paths, or usernames.
+type Record struct {
+	Time          time.Time `json:"time"`
+	Command       string    `json:"command"`
+	DurationMS    int64     `json:"durationMs"`
+	ErrorCategory string    `json:"errorCategory,omitempty"`
+	Version       string    `json:"version,omitempty"`
+}
+
+// FilePath returns the local metrics sink.
+func FilePath() string {
+	if stateHome := os.Getenv("XDG_STATE_HOME"); stateHome != "" {
+		return filepath.Join(stateHome, "dcx", "metrics.jsonl")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".local", "state", "dcx", "metrics.jsonl")
+}
+
+// Report writes one record to the configured sinks. A complete no-op
+// unless telemetry is opted in.
+func Report(record Record) {
+	userCfg, err := config.User()
+	if err != nil || !userCfg.TelemetryEnabled() {
+		return
+	}
+
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+
+	appendLocal(payload)
+
+	if endpoint := userCfg.TelemetryEndpoint; endpoint != "" {
+		postEndpoint(endpoint, payload)
+	}
+}
+
+// appendLocal appends the record to the local metrics file.
+func appendLocal(payload []byte) {
+	path := FilePath()
+	if path == "" {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	defer func() { _ = f.Close() }()
+	_, _ = f.Write(append(payload, '\n'))
+}
+
+// postEndpoint POSTs the record with a short timeout; failures are
+// silent — metrics must never affect command behavior.
+func postEndpoint(endpoint string, payload []byte) {
+	client := &http.Client{Timeout: 2 * time.Second}
+	resp, err := client.Post(endpoint, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return
+	}
+	_ = resp.Body.Close()
+}
+
+// Categorize maps an error to its DCXError category for aggregation
+// without leaking message contents; untyped errors fall into "other".
+func Categorize(err error) string {
+	if err == nil {
+		return ""
+	}
+	var dcxErr *dcxerrors.DCXError
+	if dcxerrors.As(err, &dcxErr) {
+		return string(dcxErr.Category)
+	}
+	return "other"
+}
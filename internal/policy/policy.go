@@ -0,0 +1,190 @@
+// Package policy evaluates org-level restrictions on devcontainer
+// configurations: which registries, images, and features may be used,
+// whether privileged settings are allowed, and whether lockfiles are
+// required. The policy file lives at a configured path or URL so platform
+// teams can enforce it fleet-wide.
+package policy
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/griffithind/dcx/internal/devcontainer"
+)
+
+// Policy is the parsed policy document (YAML).
+type Policy struct {
+	// AllowedRegistries restricts image and feature references to these
+	// registry hosts (prefix match on the host). Empty allows all.
+	AllowedRegistries []string `yaml:"allowedRegistries,omitempty"`
+
+	// AllowedImages restricts base images to these prefixes. Empty
+	// allows all (subject to allowedRegistries).
+	AllowedImages []string `yaml:"allowedImages,omitempty"`
+
+	// AllowedFeatures restricts feature references to these prefixes.
+	AllowedFeatures []string `yaml:"allowedFeatures,omitempty"`
+
+	// ForbidPrivileged rejects privileged containers (config or
+	// feature-required).
+	ForbidPrivileged bool `yaml:"forbidPrivileged,omitempty"`
+
+	// ForbidCapAdd rejects the listed added capabilities; "*" rejects
+	// any capAdd.
+	ForbidCapAdd []string `yaml:"forbidCapAdd,omitempty"`
+
+	// RequireLockfile rejects feature-using configs without a
+	// devcontainer-lock.json.
+	RequireLockfile bool `yaml:"requireLockfile,omitempty"`
+}
+
+// Violation is one failed policy check.
+type Violation struct {
+	Rule    string
+	Message string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s", v.Rule, v.Message)
+}
+
+// Load reads a policy from a local path or an http(s) URL. Empty source
+// returns (nil, nil) — no policy configured.
+func Load(source string) (*Policy, error) {
+	if source == "" {
+		return nil, nil
+	}
+
+	var data []byte
+	var err error
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		data, err = fetchURL(source)
+	} else {
+		data, err = os.ReadFile(source)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load policy %s: %w", source, err)
+	}
+
+	var p Policy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parse policy %s: %w", source, err)
+	}
+	return &p, nil
+}
+
+// fetchURL retrieves a remote policy with a short timeout.
+func fetchURL(url string) ([]byte, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// Evaluate checks a resolved configuration against the policy and
+// returns every violation (empty = compliant).
+func (p *Policy) Evaluate(resolved *devcontainer.ResolvedDevContainer, hasLockfile bool) []Violation {
+	if p == nil || resolved == nil {
+		return nil
+	}
+	var violations []Violation
+
+	if resolved.BaseImage != "" {
+		if !refAllowed(resolved.BaseImage, p.AllowedRegistries, p.AllowedImages) {
+			violations = append(violations, Violation{
+				Rule:    "allowedImages",
+				Message: fmt.Sprintf("image %q is not allowed by policy", resolved.BaseImage),
+			})
+		}
+	}
+
+	for _, f := range resolved.Features {
+		if !refAllowed(f.ID, p.AllowedRegistries, p.AllowedFeatures) {
+			violations = append(violations, Violation{
+				Rule:    "allowedFeatures",
+				Message: fmt.Sprintf("feature %q is not allowed by policy", f.ID),
+			})
+		}
+	}
+
+	if p.ForbidPrivileged && resolved.Privileged {
+		violations = append(violations, Violation{
+			Rule:    "forbidPrivileged",
+			Message: "privileged containers are forbidden by policy",
+		})
+	}
+
+	if len(p.ForbidCapAdd) > 0 {
+		for _, cap := range resolved.CapAdd {
+			if capForbidden(cap, p.ForbidCapAdd) {
+				violations = append(violations, Violation{
+					Rule:    "forbidCapAdd",
+					Message: fmt.Sprintf("capability %q is forbidden by policy", cap),
+				})
+			}
+		}
+	}
+
+	if p.RequireLockfile && len(resolved.Features) > 0 && !hasLockfile {
+		violations = append(violations, Violation{
+			Rule:    "requireLockfile",
+			Message: "features are used but no devcontainer-lock.json exists (run 'dcx lock')",
+		})
+	}
+
+	return violations
+}
+
+// refAllowed checks an image/feature reference against registry and
+// prefix allowlists. Local references (no registry host) pass the
+// registry check.
+func refAllowed(ref string, registries, prefixes []string) bool {
+	if len(registries) > 0 && !strings.HasPrefix(ref, "./") && !strings.HasPrefix(ref, "/") {
+		host := strings.SplitN(ref, "/", 2)[0]
+		if strings.Contains(host, ".") || strings.Contains(host, ":") {
+			allowed := false
+			for _, registry := range registries {
+				if host == registry {
+					allowed = true
+					break
+				}
+			}
+			if !allowed {
+				return false
+			}
+		}
+	}
+
+	if len(prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(ref, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// capForbidden checks a capability against the forbidden list ("*"
+// forbids everything).
+func capForbidden(cap string, forbidden []string) bool {
+	for _, f := range forbidden {
+		if f == "*" || strings.EqualFold(f, cap) {
+			return true
+		}
+	}
+	return false
+}
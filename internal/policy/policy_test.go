@@ -0,0 +1,93 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/griffithind/dcx/internal/devcontainer"
+	"github.com/griffithind/dcx/internal/features"
+)
+
+func policyResolved() *devcontainer.ResolvedDevContainer {
+	return &devcontainer.ResolvedDevContainer{
+		BaseImage: "ghcr.io/acme/base:1",
+		Features: []*features.Feature{
+			{ID: "ghcr.io/devcontainers/features/go:1"},
+		},
+	}
+}
+
+func TestEvaluateCompliant(t *testing.T) {
+	p := &Policy{
+		AllowedRegistries: []string{"ghcr.io"},
+		ForbidPrivileged:  true,
+		RequireLockfile:   true,
+	}
+	if violations := p.Evaluate(policyResolved(), true); len(violations) != 0 {
+		t.Errorf("expected no violations, got %v", violations)
+	}
+}
+
+func TestEvaluateViolations(t *testing.T) {
+	p := &Policy{
+		AllowedRegistries: []string{"registry.internal"},
+		ForbidPrivileged:  true,
+		ForbidCapAdd:      []string{"*"},
+		RequireLockfile:   true,
+	}
+	resolved := policyResolved()
+	resolved.Privileged = true
+	resolved.CapAdd = []string{"SYS_PTRACE"}
+
+	violations := p.Evaluate(resolved, false)
+	rules := make(map[string]bool)
+	for _, v := range violations {
+		rules[v.Rule] = true
+	}
+	for _, want := range []string{"allowedImages", "allowedFeatures", "forbidPrivileged", "forbidCapAdd", "requireLockfile"} {
+		if !rules[want] {
+			t.Errorf("expected a %s violation, got %v", want, violations)
+		}
+	}
+}
+
+func TestEvaluateImagePrefixes(t *testing.T) {
+	p := &Policy{AllowedImages: []string{"ghcr.io/acme/"}}
+
+	if violations := p.Evaluate(policyResolved(), true); len(violations) != 0 {
+		t.Errorf("prefixed image should pass: %v", violations)
+	}
+
+	other := policyResolved()
+	other.BaseImage = "docker.io/library/ubuntu"
+	other.Features = nil
+	if violations := p.Evaluate(other, true); len(violations) != 1 {
+		t.Errorf("expected one image violation, got %v", violations)
+	}
+}
+
+func TestLoadPolicyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	content := `
+allowedRegistries:
+  - ghcr.io
+forbidPrivileged: true
+requireLockfile: true
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := Load(path)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if !p.ForbidPrivileged || !p.RequireLockfile || len(p.AllowedRegistries) != 1 {
+		t.Errorf("policy not parsed: %+v", p)
+	}
+
+	if p, err := Load(""); err != nil || p != nil {
+		t.Errorf("empty source should be (nil, nil), got %v %v", p, err)
+	}
+}
@@ -5,6 +5,7 @@ package secrets
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"os/exec"
@@ -13,10 +14,20 @@ import (
 	"github.com/griffithind/dcx/internal/devcontainer"
 )
 
-// Secret represents a fetched secret with its name and value.
+// Secret represents a fetched secret with its name and value, plus
+// delivery options carried from its configuration.
 type Secret struct {
 	Name  string
 	Value []byte
+
+	// Target overrides the filename under the secrets directory.
+	Target string
+
+	// Mode is the octal file mode ("" = default 0400).
+	Mode string
+
+	// Owner is the in-container file owner ("" = caller's default).
+	Owner string
 }
 
 // Fetcher fetches secrets by executing commands on the host.
@@ -46,14 +57,23 @@ func (f *Fetcher) FetchSecrets(ctx context.Context, configs map[string]devcontai
 	for name, config := range configs {
 		f.logger.Debug("Fetching secret", "name", name)
 
-		value, err := f.executeCommand(ctx, string(config))
+		var value []byte
+		var err error
+		if config.Provider != "" {
+			value, err = f.executeProvider(ctx, name, config)
+		} else {
+			value, err = f.executeCommand(ctx, config.Command)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch secret %q: %w", name, err)
 		}
 
 		result = append(result, Secret{
-			Name:  name,
-			Value: value,
+			Name:   name,
+			Value:  value,
+			Target: config.Target,
+			Mode:   config.Mode,
+			Owner:  config.Owner,
 		})
 
 		f.logger.Debug("Successfully fetched secret", "name", name)
@@ -62,6 +82,50 @@ func (f *Fetcher) FetchSecrets(ctx context.Context, configs map[string]devcontai
 	return result, nil
 }
 
+// providerRequest is the JSON document sent to provider binaries.
+type providerRequest struct {
+	// Name is the secret's configured name.
+	Name string `json:"name"`
+
+	// Ref is the provider-specific reference from the config.
+	Ref string `json:"ref,omitempty"`
+}
+
+// executeProvider runs an exec-based secret provider: the
+// dcx-secret-<provider> binary on PATH receives a JSON request on stdin
+// and prints the secret value on stdout. Non-zero exit is a failure with
+// stderr surfaced. This lets organizations add custom backends without
+// forking this package.
+func (f *Fetcher) executeProvider(ctx context.Context, name string, config devcontainer.SecretConfig) ([]byte, error) {
+	binary := "dcx-secret-" + config.Provider
+	path, err := exec.LookPath(binary)
+	if err != nil {
+		return nil, fmt.Errorf("secret provider %q not found (looked for %s on PATH)", config.Provider, binary)
+	}
+
+	request, err := json.Marshal(providerRequest{Name: name, Ref: config.Ref})
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Stdin = bytes.NewReader(request)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		stderrStr := strings.TrimSpace(stderr.String())
+		if stderrStr != "" {
+			return nil, fmt.Errorf("provider %s failed: %w\nstderr: %s", config.Provider, err, stderrStr)
+		}
+		return nil, fmt.Errorf("provider %s failed: %w", config.Provider, err)
+	}
+
+	return bytes.TrimSuffix(stdout.Bytes(), []byte("\n")), nil
+}
+
 // executeCommand runs a shell command and returns its stdout.
 func (f *Fetcher) executeCommand(ctx context.Context, command string) ([]byte, error) {
 	// Use shell to execute the command
@@ -89,6 +153,13 @@ func (f *Fetcher) executeCommand(ctx context.Context, command string) ([]byte, e
 // The caller is responsible for cleaning up the files.
 // Returns a map of secret name to temp file path and a cleanup function.
 func WriteToTempFiles(secrets []Secret, prefix string) (map[string]string, func(), error) {
+	return WriteToDir(secrets, prefix, "")
+}
+
+// WriteToDir writes secrets into dir (empty = os.TempDir). Used with the
+// per-workspace runtime dir so leftovers from crashed runs are swept by
+// `dcx prune` rather than lingering in the shared temp directory.
+func WriteToDir(secrets []Secret, prefix, dir string) (map[string]string, func(), error) {
 	if len(secrets) == 0 {
 		return nil, func() {}, nil
 	}
@@ -103,7 +174,7 @@ func WriteToTempFiles(secrets []Secret, prefix string) (map[string]string, func(
 	}
 
 	for _, secret := range secrets {
-		path, cleanupFn, err := writeTempFile(secret, prefix)
+		path, cleanupFn, err := writeTempFile(secret, prefix, dir)
 		if err != nil {
 			cleanup() // Clean up any files we've already created
 			return nil, nil, fmt.Errorf("failed to write secret %q to temp file: %w", secret.Name, err)
@@ -7,10 +7,13 @@ import (
 
 // writeTempFile writes a secret to a temporary file with restrictive permissions.
 // Returns the file path and a cleanup function that removes the file.
-func writeTempFile(secret Secret, prefix string) (string, func(), error) {
+// When dir is non-empty the file is created there (per-workspace runtime
+// dir, cleaned by `dcx prune`) instead of os.TempDir, so crashed runs
+// don't strand secret files in a shared location.
+func writeTempFile(secret Secret, prefix, dir string) (string, func(), error) {
 	// Create temp file with prefix for identification
 	pattern := fmt.Sprintf("%s-%s-*", prefix, secret.Name)
-	tmpFile, err := os.CreateTemp("", pattern)
+	tmpFile, err := os.CreateTemp(dir, pattern)
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to create temp file: %w", err)
 	}
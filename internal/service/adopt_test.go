@@ -0,0 +1,61 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/griffithind/dcx/internal/state"
+)
+
+// adoptSummary builds a labeled container summary for candidate tests.
+func adoptSummary(name, workspaceName, workspaceID, workspacePath string, primary bool) state.ContainerSummary {
+	labels := map[string]string{
+		state.LabelManaged:       "true",
+		state.LabelWorkspaceName: workspaceName,
+		state.LabelWorkspaceID:   workspaceID,
+		state.LabelWorkspacePath: workspacePath,
+	}
+	if primary {
+		labels[state.LabelIsPrimary] = "true"
+	}
+	return state.ContainerSummary{ID: "id-" + name, Name: name, Labels: labels}
+}
+
+func TestPickAdoptionCandidate(t *testing.T) {
+	containers := []state.ContainerSummary{
+		// Same project, old clone path: the adoptable one.
+		adoptSummary("myapp-old", "myapp", "ws_old", "/home/u/old/myapp", true),
+		// Different project entirely.
+		adoptSummary("other", "other", "ws_other", "/home/u/other", true),
+		// Same project but non-primary (sidecar) — never adopted.
+		adoptSummary("myapp-db", "myapp", "ws_old", "/home/u/old/myapp", false),
+	}
+
+	candidate := pickAdoptionCandidate(containers, "myapp", "ws_new", "/home/u/new/myapp")
+	assert.NotNil(t, candidate)
+	assert.Equal(t, "myapp-old", candidate.Name)
+}
+
+func TestPickAdoptionCandidateRejections(t *testing.T) {
+	t.Run("same workspace id is not foreign", func(t *testing.T) {
+		containers := []state.ContainerSummary{
+			adoptSummary("myapp", "myapp", "ws1", "/home/u/myapp", true),
+		}
+		assert.Nil(t, pickAdoptionCandidate(containers, "myapp", "ws1", "/home/u/myapp"))
+	})
+
+	t.Run("same path is not a move", func(t *testing.T) {
+		containers := []state.ContainerSummary{
+			adoptSummary("myapp", "myapp", "ws_old", "/home/u/myapp", true),
+		}
+		assert.Nil(t, pickAdoptionCandidate(containers, "myapp", "ws_new", "/home/u/myapp"))
+	})
+
+	t.Run("different name never matches", func(t *testing.T) {
+		containers := []state.ContainerSummary{
+			adoptSummary("other", "other", "ws_old", "/home/u/old", true),
+		}
+		assert.Nil(t, pickAdoptionCandidate(containers, "myapp", "ws_new", "/home/u/new"))
+	})
+}
@@ -4,21 +4,30 @@ import (
 	"context"
 	"crypto/ed25519"
 	"crypto/rand"
+	"encoding/json"
 	"encoding/pem"
 	"fmt"
 	"log/slog"
 	"net"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/griffithind/dcx/internal/audit"
+	"github.com/griffithind/dcx/internal/build"
 	"github.com/griffithind/dcx/internal/common"
+	"github.com/griffithind/dcx/internal/config"
 	"github.com/griffithind/dcx/internal/container"
 	"github.com/griffithind/dcx/internal/devcontainer"
 	"github.com/griffithind/dcx/internal/env"
+	dcxerrors "github.com/griffithind/dcx/internal/errors"
+	"github.com/griffithind/dcx/internal/events"
 	"github.com/griffithind/dcx/internal/features"
 	"github.com/griffithind/dcx/internal/lifecycle"
 	"github.com/griffithind/dcx/internal/lockfile"
+	"github.com/griffithind/dcx/internal/policy"
 	"github.com/griffithind/dcx/internal/secrets"
 	dcxssh "github.com/griffithind/dcx/internal/ssh"
 	"github.com/griffithind/dcx/internal/ssh/deploy"
@@ -114,6 +123,41 @@ type UpOptions struct {
 	// SSHAllowedCIDRs lists CIDRs the agent's ConnCallback accepts in
 	// addition to loopback. Empty means loopback-only.
 	SSHAllowedCIDRs []string
+
+	// NoRecreateOnEnvChange updates the container in place (patch
+	// /etc/environment, refresh the agent) when only
+	// containerEnv/remoteEnv/forwardPorts changed, instead of recreating.
+	NoRecreateOnEnvChange bool
+
+	// WaitForLock blocks on the per-workspace operation lock instead of
+	// failing when another dcx operation is in progress.
+	WaitForLock bool
+
+	// RemapPorts switches conflicting host ports to Docker-assigned
+	// ephemeral ports instead of failing.
+	RemapPorts bool
+
+	// ApplyResourceLimits enforces hostRequirements.cpus/memory as
+	// container limits (opt-in).
+	ApplyResourceLimits bool
+
+	// IsolatedUserns prefers user-namespace isolation over the
+	// UID-rewrite image layer: on daemons with userns support (rootless
+	// or userns-remap) host file ownership is preserved by the kernel
+	// mapping and the -uidN image is skipped; elsewhere it falls back to
+	// the UID layer with a warning.
+	IsolatedUserns bool
+
+	// forwardSSHAgent is set internally when a buildSecrets entry
+	// requests SSH agent forwarding ("type=ssh").
+	forwardSSHAgent bool
+
+	// ExtraMounts/ExtraEnv/ExtraPorts are one-off overrides layered on
+	// top of the resolved config after hashing, so they never mark the
+	// container stale (persist them with `dcx up --save`).
+	ExtraMounts []string
+	ExtraEnv    []string
+	ExtraPorts  []string
 }
 
 // PlanOptions configures the Plan operation.
@@ -175,9 +219,15 @@ func (s *DevContainerService) Load(ctx context.Context) (*devcontainer.ResolvedD
 func (s *DevContainerService) LoadWithOptions(ctx context.Context, opts LoadOptions) (*devcontainer.ResolvedDevContainer, error) {
 	cfg, configPath, err := devcontainer.Load(s.workspacePath, s.configPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load configuration: %w", err)
+		return nil, dcxerrors.New(dcxerrors.CategoryConfig, dcxerrors.CodeConfigInvalid, "failed to load configuration").
+			WithCause(err).
+			WithHint("Run 'dcx validate' to see configuration problems with positions")
 	}
 
+	// Merge user-level injection policy (default features/mounts/env)
+	// before hashing so staleness detection sees policy changes.
+	cfg = applyUserInjection(cfg)
+
 	// Merge image metadata if available (per spec)
 	cfg = s.mergeImageMetadata(ctx, cfg)
 
@@ -212,6 +262,12 @@ func (s *DevContainerService) LoadWithOptions(ctx context.Context, opts LoadOpti
 		return nil, fmt.Errorf("failed to resolve devcontainer: %w", err)
 	}
 
+	// Surface substitution variables that survived all passes (usually a
+	// missing host env var) as a single warning.
+	if unresolved := devcontainer.UnresolvedVariables(cfg); len(unresolved) > 0 {
+		ui.Warning("Unresolved variables in configuration: %s", strings.Join(unresolved, ", "))
+	}
+
 	s.lastResolved = resolved
 	return resolved, nil
 }
@@ -265,16 +321,78 @@ func (s *DevContainerService) mergeImageMetadata(ctx context.Context, cfg *devco
 
 // Up brings up a devcontainer environment.
 func (s *DevContainerService) Up(ctx context.Context, opts UpOptions) error {
+	timings := newTimingRecorder()
+
+	// Serialize mutating operations per workspace.
+	if ids, err := s.GetIdentifiers(); err == nil {
+		lock, err := acquireOpLock(ids.WorkspaceID, opts.WaitForLock)
+		if err != nil {
+			return err
+		}
+		defer lock.release()
+	}
+
+	endLoad := timings.phase("load")
 	resolved, err := s.LoadWithOptions(ctx, LoadOptions{
 		ForcePull:   opts.Pull,
 		UseLockfile: true,
 	})
+	endLoad()
 	if err != nil {
 		return err
 	}
 
+	// One-off overrides apply after hashing so staleness is unaffected.
+	applyOneOffOverrides(resolved, opts)
+
 	ids, _ := s.GetIdentifiers()
 
+	// --isolated-userns: use the daemon's user-namespace mapping instead
+	// of baking the host UID into an image variant, when supported.
+	if opts.IsolatedUserns && resolved.ShouldUpdateUID {
+		if info, err := container.MustDocker().Info(ctx); err == nil {
+			if info.Rootless || info.UsernsRemap {
+				ui.Println("User-namespace isolation active; skipping UID-rewrite layer")
+				resolved.ShouldUpdateUID = false
+			} else {
+				ui.Warning("Daemon has no user-namespace support (rootless or userns-remap required); falling back to the UID-rewrite layer")
+			}
+		}
+	}
+
+	// Org policy evaluation: violations abort before any Docker work.
+	if userCfg, cfgErr := config.User(); cfgErr == nil && userCfg.Policy != "" {
+		orgPolicy, err := policy.Load(userCfg.Policy)
+		if err != nil {
+			return fmt.Errorf("failed to load org policy: %w", err)
+		}
+		lf, _, _ := lockfile.Load(resolved.ConfigPath)
+		if violations := orgPolicy.Evaluate(resolved, lf != nil); len(violations) > 0 {
+			for _, v := range violations {
+				ui.Error("policy violation — %s", v)
+			}
+			return fmt.Errorf("%d policy violation(s)", len(violations))
+		}
+	}
+
+	// Host-side pre-up hook (VPNs, DNS registration, ...): a failure
+	// aborts before any Docker work.
+	hostHooks := s.hostHooksFor(resolved.RawConfig)
+	if hostHooks != nil {
+		if err := s.runHostHook(ctx, "preUp", hostHooks.PreUp, resolved.ID); err != nil {
+			return fmt.Errorf("preUp host hook failed: %w", err)
+		}
+	}
+
+	// Windows containers need a different runtime surface everywhere
+	// (mount syntax, user model, no UID layer, cmd-based hooks); fail
+	// early with the capability matrix instead of emitting linux-style
+	// docker run args that break halfway through.
+	if info, err := container.MustDocker().Info(ctx); err == nil && info.OSType == "windows" {
+		return dcxerrors.New(dcxerrors.CategoryDocker, "WINDOWS_CONTAINERS", "the daemon runs Windows containers, which dcx does not support").
+			WithHint("Unsupported on Windows containers: bind-mount syntax, remoteUser/UID mapping, sh-based lifecycle hooks, the dcx-agent SSH server. Switch the daemon to Linux containers (Docker Desktop: 'Switch to Linux containers')")
+	}
+
 	// Validate host requirements
 	if resolved.RawConfig != nil && resolved.RawConfig.HostRequirements != nil {
 		dockerInfo, err := container.MustDocker().Info(ctx)
@@ -285,6 +403,9 @@ func (s *DevContainerService) Up(ctx context.Context, opts UpOptions) error {
 				CPUs:   dockerInfo.NCPU,
 				Memory: dockerInfo.MemTotal,
 			}
+			if free, err := container.MustDocker().DataRootFree(ctx); err == nil {
+				dockerRes.StorageFree = free
+			}
 			result := devcontainer.ValidateHostRequirementsWithDocker(resolved.RawConfig.HostRequirements, dockerRes)
 			for _, warning := range result.Warnings {
 				ui.Warning("%s", warning)
@@ -293,13 +414,14 @@ func (s *DevContainerService) Up(ctx context.Context, opts UpOptions) error {
 				for _, errMsg := range result.Errors {
 					ui.Error("%s", errMsg)
 				}
-				return fmt.Errorf("host requirements not satisfied")
+				return dcxerrors.New(dcxerrors.CategoryHostReqs, "HOST_REQUIREMENTS", "host requirements not satisfied").
+					WithHint("Raise Docker's resource limits, or adjust hostRequirements in devcontainer.json")
 			}
 		}
 	}
 
 	// Check current state first to determine what actions are needed
-	currentState, _, err := s.stateManager.GetStateWithProjectAndHash(
+	currentState, staleInfo, err := s.stateManager.GetStateWithProjectAndHash(
 		ctx, ids.ProjectName, resolved.ID, resolved.ConfigHash)
 	if err != nil {
 		return fmt.Errorf("failed to get state: %w", err)
@@ -312,6 +434,9 @@ func (s *DevContainerService) Up(ctx context.Context, opts UpOptions) error {
 	// Early return if already running and no rebuild/recreate requested
 	if currentState == state.StateRunning && !opts.Recreate && !opts.Rebuild {
 		ui.Println("Devcontainer is already running")
+		if staleInfo != nil {
+			s.refreshRuntimeSecrets(ctx, staleInfo)
+		}
 		return nil
 	}
 
@@ -322,31 +447,68 @@ func (s *DevContainerService) Up(ctx context.Context, opts UpOptions) error {
 		currentState == state.StateBroken ||
 		opts.Rebuild || opts.Recreate
 
+	// Check requested host ports before creating anything: a port held by
+	// another workspace (or unrelated process) would otherwise surface as
+	// an opaque docker run error mid-create.
+	if isCreatingNew {
+		if err := s.checkPortConflicts(ctx, resolved, opts.RemapPorts); err != nil {
+			return err
+		}
+	}
+
 	// Fetch secrets only when creating new containers
 	var runtimeSecrets []secrets.Secret
+	var runtimeSecretFiles map[string]string
 	var buildSecretPaths map[string]string
 	var secretsCleanup func()
+	var forwardSSHAgent bool
+
+	_, isComposePlan := resolved.Plan.(*devcontainer.ComposePlan)
 
 	if isCreatingNew {
 		fetcher := secrets.NewFetcher(s.logger)
 
-		// Fetch runtime secrets (mounted after container starts)
+		// Fetch runtime secrets. Compose plans receive them as native
+		// compose secrets (present before the entrypoint runs); single
+		// containers get the post-start tmpfs mount.
 		if len(resolved.RuntimeSecrets) > 0 {
 			ui.Println("Fetching runtime secrets...")
 			runtimeSecrets, err = fetcher.FetchSecrets(ctx, resolved.RuntimeSecrets)
 			if err != nil {
 				return fmt.Errorf("failed to fetch secrets: %w", err)
 			}
+			audit.Record(audit.ActionSecretsFetched, resolved.ID, resolved.ConfigHash, map[string]string{
+				"count": fmt.Sprintf("%d", len(runtimeSecrets)),
+			})
+			if isComposePlan {
+				// The files stay for the container's lifetime — compose
+				// bind-mounts them and restarts re-read them; `dcx down`
+				// plus `dcx prune` clears them.
+				runtimeSecretFiles, _, err = secrets.WriteToDir(runtimeSecrets, "dcx-runtime-secret", common.WorkspaceRuntimeDir(resolved.ID))
+				if err != nil {
+					return fmt.Errorf("failed to write runtime secrets: %w", err)
+				}
+				runtimeSecrets = nil
+			}
 		}
 
-		// Fetch build secrets (passed to docker build)
-		if len(resolved.BuildSecrets) > 0 {
+		// Fetch build secrets (passed to docker build). "type=ssh"
+		// entries request agent forwarding rather than a value.
+		buildSecretConfigs := make(map[string]devcontainer.SecretConfig, len(resolved.BuildSecrets))
+		for name, secretCfg := range resolved.BuildSecrets {
+			if secretCfg.IsSSHForward() {
+				forwardSSHAgent = true
+				continue
+			}
+			buildSecretConfigs[name] = secretCfg
+		}
+		if len(buildSecretConfigs) > 0 {
 			ui.Println("Fetching build secrets...")
-			buildSecrets, err := fetcher.FetchSecrets(ctx, resolved.BuildSecrets)
+			buildSecrets, err := fetcher.FetchSecrets(ctx, buildSecretConfigs)
 			if err != nil {
 				return fmt.Errorf("failed to fetch build secrets: %w", err)
 			}
-			buildSecretPaths, secretsCleanup, err = secrets.WriteToTempFiles(buildSecrets, "dcx-build-secret")
+			buildSecretPaths, secretsCleanup, err = secrets.WriteToDir(buildSecrets, "dcx-build-secret", common.WorkspaceRuntimeDir(resolved.ID))
 			if err != nil {
 				return fmt.Errorf("failed to write build secrets: %w", err)
 			}
@@ -354,27 +516,64 @@ func (s *DevContainerService) Up(ctx context.Context, opts UpOptions) error {
 		}
 	}
 
+	// Env-only changes (containerEnv/remoteEnv/forwardPorts) can be
+	// applied in place when requested: the structural hash still matches,
+	// so the image and container shape are unchanged. Labels are
+	// immutable, so the container keeps reading as STALE and the patch is
+	// re-applied (idempotently) on each up until the next recreate.
+	if currentState == state.StateStale && opts.NoRecreateOnEnvChange &&
+		!opts.Rebuild && !opts.Recreate &&
+		staleInfo != nil && staleInfo.Labels.HashStructural != "" &&
+		staleInfo.Labels.HashStructural == resolved.StructuralHash {
+		return s.updateEnvInPlace(ctx, resolved, staleInfo, opts)
+	}
+
 	// Handle state transitions
 	var isNewEnvironment bool
 	var needsRebuild bool
 
+	// preservedPrev is the old container kept under a -prev name during a
+	// blue/green recreate. Removed once the new container passes its
+	// hooks; left in place (for `dcx rollback`) if anything fails.
+	var preservedPrev string
+
 	switch currentState {
 	case state.StateRunning:
 		// Already handled early return above, this is rebuild/recreate case
 		fallthrough
 	case state.StateStale, state.StateBroken:
-		if s.verbose {
-			ui.Println("Removing existing devcontainer...")
-		}
-		if err := s.DownWithIDs(ctx, ids.ProjectName, resolved.ID, DownOptions{RemoveVolumes: true}); err != nil {
-			return fmt.Errorf("failed to remove existing environment: %w", err)
+		// Blue/green for single containers: keep the old container
+		// stopped under a -prev name until the replacement passes its
+		// hooks, so a broken config change doesn't destroy a working
+		// environment. Compose projects can't be renamed as a unit, so
+		// they keep the remove-then-create path.
+		if staleInfo != nil && staleInfo.IsSingleContainer() {
+			prevName, err := s.preserveContainer(ctx, staleInfo)
+			if err != nil {
+				return fmt.Errorf("failed to preserve existing container: %w", err)
+			}
+			preservedPrev = prevName
+		} else {
+			if s.verbose {
+				ui.Println("Removing existing devcontainer...")
+			}
+			if err := s.DownWithIDs(ctx, ids.ProjectName, resolved.ID, DownOptions{RemoveVolumes: true}); err != nil {
+				return fmt.Errorf("failed to remove existing environment: %w", err)
+			}
 		}
 		needsRebuild = true
 		fallthrough
 	case state.StateAbsent:
 		createOpts := opts
 		createOpts.Rebuild = opts.Rebuild || needsRebuild
-		if err := s.create(ctx, resolved, createOpts, buildSecretPaths); err != nil {
+		endCreate := timings.phase("build/create")
+		createOpts.forwardSSHAgent = forwardSSHAgent
+		err := s.create(ctx, resolved, createOpts, buildSecretPaths, runtimeSecretFiles)
+		endCreate()
+		if err != nil {
+			if preservedPrev != "" {
+				ui.Warning("Previous environment preserved as %s; run 'dcx rollback' to restore it", preservedPrev)
+			}
 			return err
 		}
 		isNewEnvironment = true
@@ -384,6 +583,14 @@ func (s *DevContainerService) Up(ctx context.Context, opts UpOptions) error {
 		}
 	}
 
+	// Secrets marked refreshOnStart are re-fetched for existing
+	// containers (new containers just received fresh values).
+	if !isCreatingNew {
+		if _, info, err := s.stateManager.GetStateWithProject(ctx, ids.ProjectName, resolved.ID); err == nil && info != nil {
+			s.refreshRuntimeSecrets(ctx, info)
+		}
+	}
+
 	// Get container info for subsequent operations
 	_, containerInfo, err := s.stateManager.GetStateWithProject(ctx, ids.ProjectName, resolved.ID)
 	if err != nil {
@@ -393,11 +600,21 @@ func (s *DevContainerService) Up(ctx context.Context, opts UpOptions) error {
 	// Pre-deploy agent binary before lifecycle hooks
 	if containerInfo != nil {
 		ui.Println("Installing dcx agent...")
-		if err := deploy.PreDeployAgent(ctx, containerInfo.Name); err != nil {
+		endAgent := timings.phase("agent")
+		err := deploy.PreDeployAgent(ctx, containerInfo.Name)
+		endAgent()
+		if err != nil {
 			return fmt.Errorf("failed to install dcx agent: %w", err)
 		}
 	}
 
+	// Surface Docker-assigned ephemeral forwardPorts assignments inside
+	// the container as DCX_PORT_<containerPort> so tools and hooks can
+	// discover them without asking the host.
+	if containerInfo != nil {
+		s.publishEphemeralPortEnv(ctx, resolved, containerInfo)
+	}
+
 	// Mount dcx-managed SSH secrets (host key + authorized_keys). This runs
 	// before lifecycle hooks so dcx exec paths used by hooks have SSH
 	// available immediately.
@@ -408,29 +625,424 @@ func (s *DevContainerService) Up(ctx context.Context, opts UpOptions) error {
 		if err := s.launchSSHAgent(ctx, resolved, containerInfo, opts.SSHAllowedCIDRs); err != nil {
 			return fmt.Errorf("failed to launch SSH agent: %w", err)
 		}
+		// Best-effort: sshd or other in-container SSH servers can then
+		// authenticate the user's keys without the dcx agent.
+		if err := s.installUserAuthorizedKeys(ctx, resolved, containerInfo); err != nil {
+			ui.Warning("Failed to install authorized keys for %s: %v", resolved.EffectiveUser, err)
+		}
+	}
+
+	// A bind-mounted docker socket usually carries a host GID the remote
+	// user isn't in; fix access before hooks run docker commands.
+	if containerInfo != nil {
+		s.fixDockerSocketAccess(ctx, resolved, containerInfo)
 	}
 
 	// Mount runtime secrets before lifecycle hooks
 	if len(runtimeSecrets) > 0 && containerInfo != nil {
 		ui.Println("Mounting secrets...")
-		if err := container.MountSecretsToContainer(ctx, containerInfo.Name, runtimeSecrets, resolved.EffectiveUser); err != nil {
+		if err := container.MountSecretsToContainerDir(ctx, containerInfo.Name, runtimeSecrets, resolved.EffectiveUser, resolved.SecretsDir); err != nil {
 			return fmt.Errorf("failed to mount secrets: %w", err)
 		}
 	}
 
+	if isNewEnvironment && containerInfo != nil {
+		events.Emit(events.TypeContainerCreated, resolved.ID, resolved.Name, map[string]string{
+			"container": containerInfo.Name,
+		})
+	}
+
+	// Hooks commonly run docker commands in docker-in-docker setups;
+	// wait for the inner daemon instead of letting the first hook fail.
+	if containerInfo != nil && hasDockerInDockerFeature(resolved) {
+		s.waitForInnerDocker(ctx, containerInfo)
+	}
+
 	// Run lifecycle hooks
-	if err := s.runLifecycleHooks(ctx, resolved, containerInfo, isNewEnvironment); err != nil {
-		return fmt.Errorf("lifecycle hooks failed: %w", err)
+	endHooks := timings.phase("hooks")
+	hooksErr := s.runLifecycleHooks(ctx, resolved, containerInfo, isNewEnvironment)
+	endHooks()
+	if err := hooksErr; err != nil {
+		if preservedPrev != "" {
+			ui.Warning("Previous environment preserved as %s; run 'dcx rollback' to restore it", preservedPrev)
+		}
+		return dcxerrors.New(dcxerrors.CategoryLifecycle, dcxerrors.CodeLifecycleFailed, "lifecycle hooks failed").
+			WithCause(err).
+			WithHint("Inspect hook output above; re-run with 'dcx up --recreate' after fixing the command")
+	}
+
+	events.Emit(events.TypeHooksFinished, resolved.ID, resolved.Name, nil)
+
+	// The new container passed its hooks — the preserved one can go,
+	// anonymous volumes included (the pre-blue/green recreate path
+	// removed volumes too; leaving them would leak one set per recreate).
+	if preservedPrev != "" {
+		if err := container.MustDocker().RemoveContainer(ctx, preservedPrev, true, true); err != nil {
+			ui.Warning("Failed to remove preserved container %s: %v", preservedPrev, err)
+		}
 	}
 
 	// Setup SSH server access
+	endSSH := timings.phase("ssh")
 	if err := s.setupSSHAccess(ctx, resolved, containerInfo); err != nil {
 		ui.Warning("Failed to setup SSH access: %v", err)
 	}
+	endSSH()
+
+	if hostHooks != nil {
+		if err := s.runHostHook(ctx, "postUp", hostHooks.PostUp, resolved.ID); err != nil {
+			ui.Warning("postUp host hook failed: %v", err)
+		}
+	}
+
+	// Retention: after a successful rebuild, drop superseded build
+	// images for this workspace beyond the configured count.
+	if isNewEnvironment {
+		keep := 2
+		if userCfg, err := config.User(); err == nil && userCfg.ImageRetention > 0 {
+			keep = userCfg.ImageRetention
+		}
+		protect := []string{resolved.DerivedImage}
+		if n := container.MustDocker().PruneWorkspaceImages(ctx, resolved.ID, keep, protect...); n > 0 {
+			ui.Printf("Pruned %d superseded image(s)", n)
+		}
+	}
+
+	timings.finish(resolved.ID)
+	return nil
+}
+
+// preserveContainer stops the container and renames it with the -prev
+// suffix, replacing any earlier preserved container for the workspace.
+// Returns the preserved name.
+func (s *DevContainerService) preserveContainer(ctx context.Context, containerInfo *state.ContainerInfo) (string, error) {
+	docker := container.MustDocker()
+	prevName := containerInfo.Name + state.PrevSuffix
+
+	// Drop any stale -prev from an earlier recreate, volumes included —
+	// it is superseded twice over.
+	if _, err := docker.InspectContainer(ctx, prevName); err == nil {
+		if err := docker.RemoveContainer(ctx, prevName, true, true); err != nil {
+			return "", err
+		}
+	}
+
+	if containerInfo.Running {
+		if err := docker.StopContainer(ctx, containerInfo.ID, nil); err != nil {
+			return "", err
+		}
+	}
+	if err := docker.RenameContainer(ctx, containerInfo.ID, prevName); err != nil {
+		return "", err
+	}
+	ui.Printf("Previous container preserved as %s", prevName)
+	return prevName, nil
+}
+
+// Rollback restores the preserved pre-recreate container: the current
+// primary (if any) is removed, the -prev container gets its original name
+// back and is started.
+func (s *DevContainerService) Rollback(ctx context.Context) error {
+	ids, err := s.GetIdentifiers()
+	if err != nil {
+		return err
+	}
 
+	docker := container.MustDocker()
+	containers, err := docker.ListContainersWithLabels(ctx, map[string]string{
+		state.LabelWorkspaceID: ids.WorkspaceID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	var prev, current *state.ContainerSummary
+	for i := range containers {
+		if !state.IsPrimaryContainer(containers[i].Labels) {
+			continue
+		}
+		if strings.HasSuffix(containers[i].Name, state.PrevSuffix) {
+			prev = &containers[i]
+		} else {
+			current = &containers[i]
+		}
+	}
+	if prev == nil {
+		return fmt.Errorf("no preserved container found; nothing to roll back to")
+	}
+
+	if current != nil {
+		ui.Printf("Removing %s...", current.Name)
+		if err := docker.RemoveContainer(ctx, current.ID, true, false); err != nil {
+			return fmt.Errorf("failed to remove current container: %w", err)
+		}
+	}
+
+	originalName := strings.TrimSuffix(prev.Name, state.PrevSuffix)
+	if err := docker.RenameContainer(ctx, prev.ID, originalName); err != nil {
+		return fmt.Errorf("failed to restore container name: %w", err)
+	}
+	if err := docker.StartContainer(ctx, prev.ID); err != nil {
+		return fmt.Errorf("failed to start restored container: %w", err)
+	}
+
+	ui.Printf("Rolled back to %s", originalName)
 	return nil
 }
 
+// DryRun prints every action a `dcx up` would take — images to pull or
+// build with their tags, the container create arguments, the generated
+// compose override, and the hooks that would run — without mutating
+// anything.
+func (s *DevContainerService) DryRun(ctx context.Context, opts UpOptions) error {
+	plan, err := s.Plan(ctx, PlanOptions{Recreate: opts.Recreate, Rebuild: opts.Rebuild})
+	if err != nil {
+		return err
+	}
+	resolved := plan.Resolved
+
+	ui.Println(ui.Bold("Dry run — no changes will be made"))
+	ui.Printf("%s", ui.FormatLabel("State", string(plan.State)))
+	ui.Printf("%s", ui.FormatLabel("Action", string(plan.Action)))
+	if plan.Reason != "" {
+		ui.Printf("%s", ui.FormatLabel("Reason", plan.Reason))
+	}
+
+	runtime, err := container.NewUnifiedRuntime(resolved)
+	if err != nil {
+		return err
+	}
+
+	ui.Println("")
+	switch p := resolved.Plan.(type) {
+	case *devcontainer.ImagePlan:
+		exists, _ := container.MustDocker().ImageExists(ctx, p.Image)
+		if exists {
+			ui.Printf("Image: %s (present locally)", p.Image)
+		} else {
+			ui.Printf("Would pull image: %s", p.Image)
+		}
+	case *devcontainer.DockerfilePlan:
+		ui.Printf("Would build image from %s (context %s)", p.Dockerfile, p.Context)
+	case *devcontainer.ComposePlan:
+		ui.Printf("Would run: docker compose -p %s up -d (files: %s)", p.ProjectName, strings.Join(p.Files, ", "))
+	}
+
+	if len(resolved.Features) > 0 {
+		ui.Printf("Would build derived image: %s", runtime.DerivedImageTag())
+		for _, f := range resolved.Features {
+			ui.Printf("  + feature %s", f.ID)
+		}
+	}
+	if resolved.ShouldUpdateUID {
+		ui.Printf("Would apply UID update layer (%d:%d for %s)", resolved.HostUID, resolved.HostGID, resolved.EffectiveUser)
+	}
+
+	if _, ok := resolved.Plan.(*devcontainer.ComposePlan); ok {
+		if override, err := runtime.RenderComposeOverride(); err == nil {
+			ui.Println("")
+			ui.Println(ui.Bold("Generated compose override"))
+			ui.Println(override)
+		}
+	} else {
+		createOpts := runtime.BuildCreateOptions(ctx, "<final-image>")
+		ui.Println("")
+		ui.Println(ui.Bold("Container create options"))
+		if data, err := json.MarshalIndent(createOpts, "", "  "); err == nil {
+			ui.Println(string(data))
+		}
+	}
+
+	ui.Println("")
+	ui.Println(ui.Bold("Hooks that would run"))
+	printHook := func(name string, v interface{}) {
+		if v != nil {
+			ui.Printf("  %s", name)
+		}
+	}
+	cfg := resolved.RawConfig
+	if cfg != nil {
+		printHook("initializeCommand", cfg.InitializeCommand)
+		printHook("onCreateCommand", cfg.OnCreateCommand)
+		printHook("updateContentCommand", cfg.UpdateContentCommand)
+		printHook("postCreateCommand", cfg.PostCreateCommand)
+		printHook("postStartCommand", cfg.PostStartCommand)
+		printHook("postAttachCommand", cfg.PostAttachCommand)
+	}
+	if n := len(resolved.Features); n > 0 {
+		ui.Printf("  (plus lifecycle hooks from %d feature(s))", n)
+	}
+
+	return nil
+}
+
+// applyOneOffOverrides layers --mount/--env/--port values onto the
+// resolved configuration. They run after hash computation, so the
+// container isn't marked stale by them — and conversely a later up
+// without the flags won't notice they're missing either; that's the
+// documented one-off semantics.
+func applyOneOffOverrides(resolved *devcontainer.ResolvedDevContainer, opts UpOptions) {
+	for _, spec := range opts.ExtraMounts {
+		if parsed := devcontainer.ParseWorkspaceMount(spec); parsed != nil {
+			resolved.Mounts = append(resolved.Mounts, *parsed)
+		} else {
+			ui.Warning("Ignoring invalid --mount %q", spec)
+		}
+	}
+	for _, kv := range opts.ExtraEnv {
+		if key, value, found := strings.Cut(kv, "="); found {
+			resolved.ContainerEnv[key] = value
+		} else {
+			ui.Warning("Ignoring invalid --env %q (want KEY=VALUE)", kv)
+		}
+	}
+	for _, spec := range opts.ExtraPorts {
+		forwards := devcontainer.ParsePortForwards(spec)
+		if len(forwards) == 0 {
+			ui.Warning("Ignoring invalid --port %q", spec)
+			continue
+		}
+		resolved.ForwardPorts = append(resolved.ForwardPorts, forwards...)
+	}
+}
+
+// updateEnvInPlace applies an env-only configuration change to an
+// existing container: patches /etc/environment with the new
+// containerEnv/remoteEnv and relaunches the agent so SSH sessions pick up
+// the new remoteEnv. Published ports cannot change on a live container, so
+// forwardPorts edits still need a recreate (noted to the user).
+func (s *DevContainerService) updateEnvInPlace(ctx context.Context, resolved *devcontainer.ResolvedDevContainer, containerInfo *state.ContainerInfo, opts UpOptions) error {
+	ui.Println("Only environment configuration changed; updating in place...")
+
+	if !containerInfo.Running {
+		ids, _ := s.GetIdentifiers()
+		if err := s.QuickStart(ctx, containerInfo, ids.ProjectName, resolved.ID); err != nil {
+			return err
+		}
+	}
+
+	envToPatch := make(map[string]string, len(resolved.ContainerEnv)+len(resolved.RemoteEnv))
+	for k, v := range resolved.ContainerEnv {
+		envToPatch[k] = v
+	}
+	for k, v := range resolved.RemoteEnv {
+		envToPatch[k] = v
+	}
+	if len(envToPatch) > 0 {
+		if err := env.NewPatcher().PatchEtcEnvironment(ctx, containerInfo.ID, envToPatch); err != nil {
+			return fmt.Errorf("failed to patch /etc/environment: %w", err)
+		}
+	}
+
+	// Restart the agent so its --env (remoteEnv) reflects the new values.
+	_ = container.MustDocker().ExecInContainer(ctx, containerInfo.Name, []string{"pkill", "-f", "dcx-agent listen"})
+	if err := s.launchSSHAgent(ctx, resolved, containerInfo, opts.SSHAllowedCIDRs); err != nil {
+		return fmt.Errorf("failed to relaunch SSH agent: %w", err)
+	}
+
+	ui.Printf("%s", "Note: forwardPorts changes (if any) still require a recreate")
+	return nil
+}
+
+// checkPortConflicts verifies that every fixed host port the config
+// requests is still free. On conflict it either fails naming the holder —
+// the other dcx workspace when one owns the port, otherwise the bare
+// port — or, with remap, converts the forward to a Docker-assigned
+// ephemeral port (surfaced later via publishEphemeralPortEnv/dcx ports).
+func (s *DevContainerService) checkPortConflicts(ctx context.Context, resolved *devcontainer.ResolvedDevContainer, remap bool) error {
+	holders := s.dcxPortHolders(ctx)
+
+	checkSet := func(ports []devcontainer.PortForward, kind string) error {
+		for i := range ports {
+			pf := &ports[i]
+			if pf.EphemeralHostPort {
+				continue
+			}
+			hostPort := pf.HostPort
+			if hostPort == 0 {
+				hostPort = pf.ContainerPort
+			}
+			if dcxssh.IsHostPortAvailable(hostPort) {
+				continue
+			}
+			if remap {
+				ui.Printf("Port %d is busy; remapping %s %d to an ephemeral host port", hostPort, kind, pf.ContainerPort)
+				pf.HostPort = 0
+				pf.EphemeralHostPort = true
+				continue
+			}
+			if holder := holders[hostPort]; holder != "" && holder != resolved.Name {
+				return fmt.Errorf("host port %d is already used by workspace %q; run 'dcx up --remap-ports' or free the port", hostPort, holder)
+			}
+			return fmt.Errorf("host port %d is already in use; run 'dcx up --remap-ports' or free the port", hostPort)
+		}
+		return nil
+	}
+
+	if err := checkSet(resolved.ForwardPorts, "forwardPort"); err != nil {
+		return err
+	}
+	return checkSet(resolved.AppPorts, "appPort")
+}
+
+// dcxPortHolders maps bound host ports to the dcx workspace name holding
+// them, for conflict messages that name the culprit.
+func (s *DevContainerService) dcxPortHolders(ctx context.Context) map[int]string {
+	holders := make(map[int]string)
+	containers, err := container.MustDocker().ListContainersWithLabels(ctx, map[string]string{
+		state.LabelManaged: "true",
+	})
+	if err != nil {
+		return holders
+	}
+	for _, c := range containers {
+		if !c.Running {
+			continue
+		}
+		lbls := state.ContainerLabelsFromMap(c.Labels)
+		mappings, err := container.MustDocker().ContainerPortMappings(ctx, c.Name)
+		if err != nil {
+			continue
+		}
+		for _, m := range mappings {
+			if m.HostPort > 0 {
+				holders[m.HostPort] = lbls.WorkspaceName
+			}
+		}
+	}
+	return holders
+}
+
+// publishEphemeralPortEnv records Docker-assigned host ports for
+// ephemeral forwardPorts entries in the container's /etc/environment
+// (DCX_PORT_<containerPort>=<hostPort>) and echoes them to the user.
+func (s *DevContainerService) publishEphemeralPortEnv(ctx context.Context, resolved *devcontainer.ResolvedDevContainer, containerInfo *state.ContainerInfo) {
+	var assigned map[string]string
+	for _, pf := range resolved.ForwardPorts {
+		if !pf.EphemeralHostPort {
+			continue
+		}
+		proto := pf.Protocol
+		if proto == "" {
+			proto = "tcp"
+		}
+		hostPort, err := container.MustDocker().PortMapping(ctx, containerInfo.Name, pf.ContainerPort, proto)
+		if err != nil {
+			continue
+		}
+		if assigned == nil {
+			assigned = make(map[string]string)
+		}
+		assigned[fmt.Sprintf("DCX_PORT_%d", pf.ContainerPort)] = fmt.Sprintf("%d", hostPort)
+		ui.Printf("Port %d forwarded to host port %d", pf.ContainerPort, hostPort)
+	}
+	if len(assigned) > 0 {
+		if err := env.NewPatcher().PatchEtcEnvironment(ctx, containerInfo.ID, assigned); err != nil {
+			ui.Warning("Failed to record port assignments in container env: %v", err)
+		}
+	}
+}
+
 // mountSSHSecrets writes the persistent host key and the user's authorized
 // public key into /run/secrets/dcx/.
 //
@@ -494,6 +1106,96 @@ func (s *DevContainerService) mountSSHSecrets(ctx context.Context, resolved *dev
 	})
 }
 
+// installUserAuthorizedKeys merges the user's chosen public keys into the
+// remoteUser's ~/.ssh/authorized_keys inside the container, so external
+// tools can SSH directly (e.g. via an sshd feature) without the dcx
+// transport. The key list comes from ssh.authorizedKeyFiles in the user
+// config (globs allowed), defaulting to ~/.ssh/id_*.pub, and always
+// includes SSH-agent identities — covering hardware-backed keys that
+// never exist on disk.
+func (s *DevContainerService) installUserAuthorizedKeys(ctx context.Context, resolved *devcontainer.ResolvedDevContainer, containerInfo *state.ContainerInfo) error {
+	keys, err := collectConfiguredPublicKeys()
+	if err != nil || len(keys) == 0 {
+		return err
+	}
+
+	user := resolved.EffectiveUser
+	if user == "" {
+		user = "root"
+	}
+
+	docker := container.MustDocker()
+	const staged = "/tmp/.dcx-authorized-keys"
+	if err := docker.WriteFileInContainer(ctx, containerInfo.Name, staged, keys, user); err != nil {
+		return err
+	}
+
+	// Merge idempotently: union of existing and staged lines.
+	script := `set -e
+mkdir -p "$HOME/.ssh"
+chmod 700 "$HOME/.ssh"
+touch "$HOME/.ssh/authorized_keys"
+cat "$HOME/.ssh/authorized_keys" ` + staged + ` | sort -u > "$HOME/.ssh/authorized_keys.tmp"
+mv "$HOME/.ssh/authorized_keys.tmp" "$HOME/.ssh/authorized_keys"
+chmod 600 "$HOME/.ssh/authorized_keys"
+rm -f ` + staged
+	if output, err := docker.SimpleExecInContainer(ctx, containerInfo.Name, container.SimpleExecOptions{
+		User: user,
+		Cmd:  []string{"sh", "-c", script},
+	}); err != nil {
+		return fmt.Errorf("%s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// collectConfiguredPublicKeys gathers the pubkeys to install: configured
+// files (globs allowed) or the ~/.ssh/id_*.pub default, plus SSH-agent
+// identities.
+func collectConfiguredPublicKeys() ([]byte, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	patterns := []string{filepath.Join(home, ".ssh", "id_*.pub")}
+	if userCfg, err := config.User(); err == nil && len(userCfg.SSH.AuthorizedKeyFiles) > 0 {
+		patterns = userCfg.SSH.AuthorizedKeyFiles
+	}
+
+	var keys []byte
+	appendBlock := func(block []byte) {
+		if len(block) == 0 {
+			return
+		}
+		keys = append(keys, block...)
+		if block[len(block)-1] != '\n' {
+			keys = append(keys, '\n')
+		}
+	}
+
+	for _, pattern := range patterns {
+		if strings.HasPrefix(pattern, "~/") {
+			pattern = filepath.Join(home, pattern[2:])
+		}
+		matches, _ := filepath.Glob(pattern)
+		for _, path := range matches {
+			if data, err := os.ReadFile(path); err == nil {
+				appendBlock(data)
+			}
+		}
+	}
+
+	// Hardware-backed keys (yubikey, Secretive, 1Password) only exist in
+	// the agent.
+	if sock := os.Getenv("SSH_AUTH_SOCK"); sock != "" {
+		if agentKeys, err := readAgentPubkeys(sock); err == nil {
+			appendBlock(agentKeys)
+		}
+	}
+
+	return keys, nil
+}
+
 // launchSSHAgent starts the dcx-agent SSH listener in the container.
 //
 // The agent runs as a detached background process; it is re-spawned
@@ -525,9 +1227,21 @@ func (s *DevContainerService) launchSSHAgent(ctx context.Context, resolved *devc
 		"--user", user,
 		"--workdir", workDir,
 	}
+	// Config-level agent options extend the flag-driven ones.
+	if resolved.Dcx != nil && resolved.Dcx.Agent != nil {
+		if resolved.Dcx.Agent.Shell != "" {
+			argv = append(argv, "--shell", resolved.Dcx.Agent.Shell)
+		}
+		allowedCIDRs = append(allowedCIDRs, resolved.Dcx.Agent.AllowCIDRs...)
+	}
 	if len(allowedCIDRs) > 0 {
 		argv = append(argv, "--allow-cidrs", strings.Join(allowedCIDRs, ","))
 	}
+	// remoteEnv rides along so external SSH sessions see it too. Values
+	// are already substituted by the builder.
+	for k, v := range resolved.RemoteEnv {
+		argv = append(argv, "--env", fmt.Sprintf("%s=%s", k, v))
+	}
 	// The agent inherits the container's default user (typically the image's
 	// USER or remoteUser after UID remap). mountSSHSecrets chowns the host
 	// key and authorized_keys to that same user so the agent can read them.
@@ -548,11 +1262,54 @@ func (s *DevContainerService) QuickStart(ctx context.Context, containerInfo *sta
 			return fmt.Errorf("failed to start containers: %w", err)
 		}
 	}
+
+	// Tokens expire between sessions: re-fetch secrets marked
+	// refreshOnStart. Cheap config load only — no feature resolution.
+	s.refreshRuntimeSecrets(ctx, containerInfo)
 	return nil
 }
 
+// refreshRuntimeSecrets re-fetches and re-mounts runtime secrets marked
+// refreshOnStart into an already-running container. Best-effort: a
+// provider failure is a warning, not a failed start.
+func (s *DevContainerService) refreshRuntimeSecrets(ctx context.Context, containerInfo *state.ContainerInfo) {
+	cfg, _, err := devcontainer.Load(s.workspacePath, s.configPath)
+	if err != nil {
+		return
+	}
+	dcxCfg := devcontainer.GetDcxCustomizations(cfg)
+	if dcxCfg == nil {
+		return
+	}
+
+	toRefresh := make(map[string]devcontainer.SecretConfig)
+	for name, secretCfg := range dcxCfg.Secrets {
+		if secretCfg.RefreshOnStart {
+			toRefresh[name] = secretCfg
+		}
+	}
+	if len(toRefresh) == 0 {
+		return
+	}
+
+	ui.Println("Refreshing runtime secrets...")
+	fetched, err := secrets.NewFetcher(s.logger).FetchSecrets(ctx, toRefresh)
+	if err != nil {
+		ui.Warning("Failed to refresh secrets: %v", err)
+		return
+	}
+
+	owner := cfg.RemoteUser
+	if owner == "" {
+		owner = cfg.ContainerUser
+	}
+	if err := container.MountSecretsToContainerDir(ctx, containerInfo.Name, fetched, owner, dcxCfg.SecretsDir); err != nil {
+		ui.Warning("Failed to re-mount refreshed secrets: %v", err)
+	}
+}
+
 // create creates a new environment.
-func (s *DevContainerService) create(ctx context.Context, resolved *devcontainer.ResolvedDevContainer, opts UpOptions, buildSecrets map[string]string) error {
+func (s *DevContainerService) create(ctx context.Context, resolved *devcontainer.ResolvedDevContainer, opts UpOptions, buildSecrets, runtimeSecretFiles map[string]string) error {
 	runtime, err := container.NewUnifiedRuntime(resolved)
 	if err != nil {
 		return fmt.Errorf("failed to create runtime: %w", err)
@@ -565,11 +1322,14 @@ func (s *DevContainerService) create(ctx context.Context, resolved *devcontainer
 	}
 
 	return runtime.Up(ctx, container.UpOptions{
-		Build:        opts.Rebuild,
-		Rebuild:      opts.Rebuild,
-		Pull:         opts.Pull,
-		BuildSecrets: buildSecrets,
-		SSHBindHost:  opts.SSHBindHost,
+		Build:               opts.Rebuild,
+		Rebuild:             opts.Rebuild,
+		Pull:                opts.Pull,
+		BuildSecrets:        buildSecrets,
+		SSHBindHost:         opts.SSHBindHost,
+		ApplyResourceLimits: opts.ApplyResourceLimits,
+		RuntimeSecretFiles:  runtimeSecretFiles,
+		ForwardSSHAgent:     opts.forwardSSHAgent,
 	})
 }
 
@@ -585,6 +1345,86 @@ func (s *DevContainerService) start(ctx context.Context, resolved *devcontainer.
 	return runtime.Start(ctx)
 }
 
+// hasDockerInDockerFeature reports whether the docker-in-docker feature
+// is among the resolved features.
+func hasDockerInDockerFeature(resolved *devcontainer.ResolvedDevContainer) bool {
+	for _, f := range resolved.Features {
+		if strings.Contains(f.ID, "docker-in-docker") {
+			return true
+		}
+	}
+	return false
+}
+
+// waitForInnerDocker polls the inner dockerd (started by the
+// docker-in-docker feature's entrypoint) until it answers `docker info`
+// or the timeout passes. Best-effort: hooks proceed either way, but with
+// a healthy daemon when possible.
+func (s *DevContainerService) waitForInnerDocker(ctx context.Context, containerInfo *state.ContainerInfo) {
+	const timeout = 30 * time.Second
+	deadline := time.Now().Add(timeout)
+
+	for time.Now().Before(deadline) {
+		if err := container.MustDocker().ExecInContainer(ctx, containerInfo.Name, []string{"docker", "info"}); err == nil {
+			if s.verbose {
+				ui.Println("  [dind] inner docker daemon is ready")
+			}
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+	ui.Warning("Inner docker daemon not ready after %s; hooks may fail", timeout)
+}
+
+// fixDockerSocketAccess makes a bind-mounted /var/run/docker.sock usable
+// by the remote user (docker-outside-of-docker setups). The socket's GID
+// comes from the host, so the container user typically isn't a member of
+// any matching group: join the user to the group owning the socket's GID
+// (creating one when the GID is unassigned, handling both shadow-utils
+// and busybox tooling), falling back to chmod 666 when group management
+// isn't available. Best-effort and silent when no socket is mounted.
+func (s *DevContainerService) fixDockerSocketAccess(ctx context.Context, resolved *devcontainer.ResolvedDevContainer, containerInfo *state.ContainerInfo) {
+	user := resolved.EffectiveUser
+	if user == "" || user == "root" {
+		return
+	}
+
+	script := `
+sock=/var/run/docker.sock
+[ -S "$sock" ] || exit 0
+# Already accessible?
+if su "$DCX_USER" -s /bin/sh -c "test -w $sock" 2>/dev/null; then exit 0; fi
+gid=$(stat -c %g "$sock" 2>/dev/null) || exit 0
+group=$(getent group "$gid" 2>/dev/null | cut -d: -f1)
+if [ -z "$group" ]; then
+    group=docker-host
+    groupadd -g "$gid" "$group" 2>/dev/null || addgroup -g "$gid" "$group" 2>/dev/null || group=""
+fi
+if [ -n "$group" ]; then
+    usermod -aG "$group" "$DCX_USER" 2>/dev/null || addgroup "$DCX_USER" "$group" 2>/dev/null || chmod 666 "$sock"
+else
+    chmod 666 "$sock"
+fi
+`
+	output, err := container.MustDocker().SimpleExecInContainer(ctx, containerInfo.Name, container.SimpleExecOptions{
+		User: "root",
+		Cmd:  []string{"env", "DCX_USER=" + user, "sh", "-c", script},
+	})
+	if err != nil {
+		if s.verbose {
+			ui.Warning("docker socket access fix failed: %v (%s)", err, strings.TrimSpace(string(output)))
+		}
+		return
+	}
+	if s.verbose {
+		ui.Printf("  [docker] socket access verified for %s", user)
+	}
+}
+
 // runLifecycleHooks runs appropriate lifecycle hooks.
 func (s *DevContainerService) runLifecycleHooks(ctx context.Context, resolved *devcontainer.ResolvedDevContainer, containerInfo *state.ContainerInfo, isNew bool) error {
 	if containerInfo == nil {
@@ -750,6 +1590,16 @@ func (s *DevContainerService) setupSSHAccess(ctx context.Context, resolved *devc
 
 	knownHosts, _ := dcxssh.KnownHostsPath()
 
+	var sshOpts hostconfig.Options
+	if userCfg, err := config.User(); err == nil {
+		sshOpts = hostconfig.Options{
+			ControlMaster:       userCfg.SSH.ControlMaster,
+			ServerAliveInterval: userCfg.SSH.ServerAliveInterval,
+			ForwardAgent:        userCfg.SSH.ForwardAgent,
+			IdentityFile:        userCfg.SSH.IdentityFile,
+		}
+	}
+
 	if err := hostconfig.AddSSHConfig(hostconfig.Entry{
 		HostName:       ids.SSHHost,
 		ContainerName:  containerInfo.Name,
@@ -758,11 +1608,15 @@ func (s *DevContainerService) setupSSHAccess(ctx context.Context, resolved *devc
 		BindHost:       "127.0.0.1",
 		Port:           port,
 		KnownHostsPath: knownHosts,
+		Options:        sshOpts,
 	}); err != nil {
 		return fmt.Errorf("failed to update SSH config: %w", err)
 	}
 
 	ui.Printf("SSH configured: ssh %s  (127.0.0.1:%d)", ids.SSHHost, port)
+	events.Emit(events.TypePortsForwarded, resolved.ID, resolved.Name, map[string]string{
+		"sshPort": fmt.Sprintf("%d", port),
+	})
 	return nil
 }
 
@@ -905,6 +1759,263 @@ func readAgentPubkeys(sock string) ([]byte, error) {
 	return out, nil
 }
 
+// VSCodeLocalFolderLabel is the label VS Code Remote Containers and the
+// devcontainers/cli stamp with the host workspace folder.
+const VSCodeLocalFolderLabel = "devcontainer.local_folder"
+
+// Adopt rebinds a container to the current workspace path. With from=""
+// it targets a dcx container created for this project at a previous path
+// (repo cloned to a new directory); with from="vscode" it takes over a
+// container created by VS Code Remote Containers / devcontainers-cli for
+// this workspace folder. Container labels are immutable, so adoption
+// commits the old container's filesystem, removes it, and recreates the
+// container from that image with fresh labels and the new workspace
+// mount — named volumes and installed state survive, and create hooks
+// are skipped.
+func (s *DevContainerService) Adopt(ctx context.Context, from string) error {
+	resolved, err := s.Load(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := resolved.Plan.(*devcontainer.ComposePlan); ok {
+		return fmt.Errorf("adopt is not supported for compose environments; use docker compose -p with the old project name")
+	}
+
+	var candidate *state.ContainerSummary
+	switch from {
+	case "":
+		candidate, err = s.findAdoptionCandidate(ctx, resolved)
+	case "vscode", "devcontainers-cli":
+		candidate, err = s.findForeignCandidate(ctx)
+	default:
+		return fmt.Errorf("unknown adoption source %q (supported: vscode)", from)
+	}
+	if err != nil {
+		return err
+	}
+
+	previous := state.ContainerLabelsFromMap(candidate.Labels).WorkspacePath
+	if previous == "" {
+		previous = candidate.Labels[VSCodeLocalFolderLabel]
+	}
+	ui.Printf("Adopting %s (previously at %s)", candidate.Name, previous)
+
+	docker := container.MustDocker()
+	imageRef := fmt.Sprintf("dcx-adopt/%s:latest", resolved.ID)
+	if err := docker.CommitContainer(ctx, candidate.ID, imageRef); err != nil {
+		return fmt.Errorf("failed to preserve container state: %w", err)
+	}
+	if err := docker.RemoveContainer(ctx, candidate.ID, true, false); err != nil {
+		return fmt.Errorf("failed to remove old container: %w", err)
+	}
+
+	runtime, err := container.NewUnifiedRuntime(resolved)
+	if err != nil {
+		return err
+	}
+	if err := runtime.CreateFromImage(ctx, imageRef); err != nil {
+		return err
+	}
+
+	ids, _ := s.GetIdentifiers()
+	_, containerInfo, err := s.stateManager.GetStateWithProject(ctx, ids.ProjectName, resolved.ID)
+	if err != nil || containerInfo == nil {
+		return fmt.Errorf("adopted container not found: %v", err)
+	}
+
+	if err := deploy.PreDeployAgent(ctx, containerInfo.Name); err != nil {
+		return fmt.Errorf("failed to install dcx agent: %w", err)
+	}
+	if err := s.mountSSHSecrets(ctx, resolved, containerInfo); err != nil {
+		return fmt.Errorf("failed to mount SSH secrets: %w", err)
+	}
+	if err := s.launchSSHAgent(ctx, resolved, containerInfo, nil); err != nil {
+		return fmt.Errorf("failed to launch SSH agent: %w", err)
+	}
+	if err := s.setupSSHAccess(ctx, resolved, containerInfo); err != nil {
+		ui.Warning("Failed to setup SSH access: %v", err)
+	}
+	return nil
+}
+
+// findAdoptionCandidate locates a dcx container for this project created
+// at a different workspace path: same workspace name, different ID.
+func (s *DevContainerService) findAdoptionCandidate(ctx context.Context, resolved *devcontainer.ResolvedDevContainer) (*state.ContainerSummary, error) {
+	containers, err := container.MustDocker().ListContainersWithLabels(ctx, map[string]string{
+		state.LabelManaged: "true",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	candidate := pickAdoptionCandidate(containers, resolved.Name, resolved.ID, s.workspacePath)
+	if candidate == nil {
+		return nil, fmt.Errorf("no adoptable container found for project %q (looking for a dcx container with the same name but a different workspace path)", resolved.Name)
+	}
+	return candidate, nil
+}
+
+// pickAdoptionCandidate selects a primary container with the same
+// workspace name but a different ID and path — a container for this
+// project created before the repo moved. Pure selection logic, split out
+// for testing.
+func pickAdoptionCandidate(containers []state.ContainerSummary, workspaceName, workspaceID, workspacePath string) *state.ContainerSummary {
+	for i := range containers {
+		lbls := state.ContainerLabelsFromMap(containers[i].Labels)
+		if !state.IsPrimaryContainer(containers[i].Labels) {
+			continue
+		}
+		if lbls.WorkspaceName != workspaceName || lbls.WorkspaceID == workspaceID {
+			continue
+		}
+		if lbls.WorkspacePath == workspacePath {
+			continue
+		}
+		return &containers[i]
+	}
+	return nil
+}
+
+// findForeignCandidate locates a VS Code / devcontainers-cli container
+// for this workspace folder.
+func (s *DevContainerService) findForeignCandidate(ctx context.Context) (*state.ContainerSummary, error) {
+	containers, err := container.MustDocker().ListContainersWithLabels(ctx, map[string]string{
+		VSCodeLocalFolderLabel: s.workspacePath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+	if len(containers) == 0 {
+		return nil, fmt.Errorf("no VS Code / devcontainers-cli container found for %s", s.workspacePath)
+	}
+	return &containers[0], nil
+}
+
+// runHostHook executes a host-side hook command with the operation
+// context in the environment. Returns the command's error; callers
+// decide whether that aborts (pre hooks) or just warns (post hooks).
+func (s *DevContainerService) runHostHook(ctx context.Context, hookName, command, workspaceID string) error {
+	if command == "" {
+		return nil
+	}
+	ui.Printf("Running host hook %s...", hookName)
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = s.workspacePath
+	cmd.Env = append(os.Environ(),
+		"DCX_HOOK="+hookName,
+		"DCX_WORKSPACE="+s.workspacePath,
+		"DCX_WORKSPACE_ID="+workspaceID,
+	)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// hostHooksFor returns the configured host hooks, best-effort.
+func (s *DevContainerService) hostHooksFor(cfg *devcontainer.DevContainerConfig) *devcontainer.HostHooks {
+	if cfg == nil {
+		return nil
+	}
+	dcxCfg := devcontainer.GetDcxCustomizations(cfg)
+	if dcxCfg == nil {
+		return nil
+	}
+	return dcxCfg.HostHooks
+}
+
+// snapshotImageRepo returns the image repository used for a workspace's
+// snapshots. Tags are the user-supplied snapshot names.
+func snapshotImageRepo(workspaceID string) string {
+	return "dcx-snapshot/" + workspaceID
+}
+
+// SnapshotCreate commits the running container's filesystem as a named
+// snapshot image. Returns the image reference.
+func (s *DevContainerService) SnapshotCreate(ctx context.Context, name string) (string, error) {
+	ids, err := s.GetIdentifiers()
+	if err != nil {
+		return "", err
+	}
+
+	currentState, containerInfo, err := s.stateManager.GetStateWithProject(ctx, ids.ProjectName, ids.WorkspaceID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get state: %w", err)
+	}
+	if currentState == state.StateAbsent || containerInfo == nil {
+		return "", fmt.Errorf("no devcontainer found; run 'dcx up' first")
+	}
+	if !containerInfo.IsSingleContainer() {
+		return "", fmt.Errorf("snapshots are not supported for compose environments")
+	}
+
+	imageRef := fmt.Sprintf("%s:%s", snapshotImageRepo(containerInfo.WorkspaceID), common.SanitizeProjectName(name))
+	ui.Printf("Committing %s to %s...", containerInfo.Name, imageRef)
+	if err := container.MustDocker().CommitContainer(ctx, containerInfo.ID, imageRef); err != nil {
+		return "", err
+	}
+	return imageRef, nil
+}
+
+// SnapshotRestore replaces the environment's container with one created
+// from a snapshot image. Lifecycle create hooks are skipped — the snapshot
+// already contains their results — but the agent and SSH access are set
+// up again so the restored environment is immediately usable.
+func (s *DevContainerService) SnapshotRestore(ctx context.Context, name string) error {
+	resolved, err := s.Load(ctx)
+	if err != nil {
+		return err
+	}
+	ids, _ := s.GetIdentifiers()
+
+	if _, ok := resolved.Plan.(*devcontainer.ComposePlan); ok {
+		return fmt.Errorf("snapshots are not supported for compose environments")
+	}
+
+	imageRef := fmt.Sprintf("%s:%s", snapshotImageRepo(resolved.ID), common.SanitizeProjectName(name))
+	exists, err := container.MustDocker().ImageExists(ctx, imageRef)
+	if err != nil {
+		return fmt.Errorf("failed to check snapshot image: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("snapshot %q not found (image %s)", name, imageRef)
+	}
+
+	// Remove the current container (volumes survive).
+	if err := s.DownWithIDs(ctx, ids.ProjectName, resolved.ID, DownOptions{}); err != nil {
+		return fmt.Errorf("failed to remove current container: %w", err)
+	}
+
+	runtime, err := container.NewUnifiedRuntime(resolved)
+	if err != nil {
+		return fmt.Errorf("failed to create runtime: %w", err)
+	}
+	ui.Printf("Restoring from %s...", imageRef)
+	if err := runtime.CreateFromImage(ctx, imageRef); err != nil {
+		return err
+	}
+
+	_, containerInfo, err := s.stateManager.GetStateWithProject(ctx, ids.ProjectName, resolved.ID)
+	if err != nil || containerInfo == nil {
+		return fmt.Errorf("restored container not found: %v", err)
+	}
+
+	if err := deploy.PreDeployAgent(ctx, containerInfo.Name); err != nil {
+		return fmt.Errorf("failed to install dcx agent: %w", err)
+	}
+	if err := s.mountSSHSecrets(ctx, resolved, containerInfo); err != nil {
+		return fmt.Errorf("failed to mount SSH secrets: %w", err)
+	}
+	if err := s.launchSSHAgent(ctx, resolved, containerInfo, nil); err != nil {
+		return fmt.Errorf("failed to launch SSH agent: %w", err)
+	}
+	if err := s.setupSSHAccess(ctx, resolved, containerInfo); err != nil {
+		ui.Warning("Failed to setup SSH access: %v", err)
+	}
+	return nil
+}
+
 // DownOptions contains options for tearing down a devcontainer.
 type DownOptions struct {
 	RemoveVolumes bool
@@ -913,13 +2024,31 @@ type DownOptions struct {
 
 // DownWithIDs removes the environment using just project name and workspace ID.
 func (s *DevContainerService) DownWithIDs(ctx context.Context, projectName, workspaceID string, opts DownOptions) error {
+	// Host-side down hooks (config load is best-effort — down must work
+	// without a loadable config).
+	var hostHooks *devcontainer.HostHooks
+	if cfg, _, err := devcontainer.Load(s.workspacePath, s.configPath); err == nil {
+		hostHooks = s.hostHooksFor(cfg)
+	}
+	if hostHooks != nil {
+		if err := s.runHostHook(ctx, "preDown", hostHooks.PreDown, workspaceID); err != nil {
+			return fmt.Errorf("preDown host hook failed: %w", err)
+		}
+	}
+
+	// Down may run nested inside Up (stale recreate); flock is
+	// per-process and re-entrant for the same fd owner, but we take the
+	// lock only when called directly: a held lock from this process would
+	// deadlock on a second OpenFile+flock. Detection is cheap — Up holds
+	// the lock for the whole operation — so rely on Up's lock when nested
+	// and skip locking here.
 	currentState, containerInfo, err := s.stateManager.GetStateWithProject(ctx, projectName, workspaceID)
 	if err != nil {
 		return fmt.Errorf("failed to get state: %w", err)
 	}
 
 	if currentState == state.StateAbsent {
-		ui.Println("No devcontainer found")
+		ui.Println(ui.T(ui.MsgNoDevcontainer))
 		return nil
 	}
 
@@ -933,6 +2062,9 @@ func (s *DevContainerService) DownWithIDs(ctx context.Context, projectName, work
 		if err := container.MustDocker().RemoveContainer(ctx, containerInfo.ID, true, opts.RemoveVolumes); err != nil {
 			return fmt.Errorf("failed to remove container: %w", err)
 		}
+
+		// Sidecars and the shared workspace network go with the primary.
+		s.removeSidecars(ctx, containerInfo.WorkspaceID)
 	} else {
 		actualProject := containerInfo.GetComposeProject(projectName)
 		configDir := containerInfo.GetConfigDir(s.workspacePath)
@@ -955,15 +2087,64 @@ func (s *DevContainerService) DownWithIDs(ctx context.Context, projectName, work
 		_ = dcxssh.RemoveHost(workspaceID)
 	}
 
-	ui.Println("Devcontainer removed")
+	ui.Println(ui.T(ui.MsgDownRemoved))
+	events.Emit(events.TypeStateChanged, workspaceID, projectName, map[string]string{
+		"state": string(state.StateAbsent),
+	})
+
+	if hostHooks != nil {
+		if err := s.runHostHook(ctx, "postDown", hostHooks.PostDown, workspaceID); err != nil {
+			ui.Warning("postDown host hook failed: %v", err)
+		}
+	}
 	return nil
 }
 
+// removeSidecars removes any remaining workspace containers (sidecars)
+// and the shared network. Best-effort: the network removal fails while
+// foreign containers are attached, which is fine.
+func (s *DevContainerService) removeSidecars(ctx context.Context, workspaceID string) {
+	if workspaceID == "" {
+		return
+	}
+	docker := container.MustDocker()
+	containers, err := docker.ListContainersWithLabels(ctx, map[string]string{
+		state.LabelWorkspaceID: workspaceID,
+	})
+	if err == nil {
+		for _, c := range containers {
+			_ = docker.RemoveContainer(ctx, c.ID, true, false)
+		}
+	}
+	network := container.WorkspaceNetworkName(workspaceID)
+	if docker.NetworkExists(ctx, network) {
+		_ = docker.RemoveNetwork(ctx, network)
+	}
+
+	// Networks dcx created for runArgs --network carry the workspace
+	// label; pre-existing user networks don't and stay untouched.
+	if created, err := docker.ListNetworksWithLabel(ctx, state.LabelWorkspaceID+"="+workspaceID); err == nil {
+		for _, name := range created {
+			_ = docker.RemoveNetwork(ctx, name)
+		}
+	}
+}
+
 // BuildOptions configures the Build operation.
 type BuildOptions struct {
 	NoCache bool
 	Pull    bool
 
+	// Platform targets a different platform than the daemon's native one
+	// (e.g. "linux/arm64"); requires buildx with QEMU for foreign
+	// architectures.
+	Platform string
+
+	// SBOM/Provenance request buildx attestations (SPDX SBOM, SLSA
+	// provenance) on images built for this environment.
+	SBOM       bool
+	Provenance bool
+
 	// UpdateLockfile updates the lockfile after successful build
 	UpdateLockfile bool
 	// FrozenLockfile fails if lockfile doesn't match resolved features
@@ -1016,17 +2197,42 @@ func (s *DevContainerService) Build(ctx context.Context, opts BuildOptions) erro
 		return err
 	}
 
+	if opts.Platform != "" {
+		s.warnIfCrossBuildUnsupported(ctx, opts.Platform)
+	}
+
 	runtime, err := container.NewUnifiedRuntime(resolved)
 	if err != nil {
 		return fmt.Errorf("failed to create runtime: %w", err)
 	}
 
 	return runtime.Build(ctx, container.BuildOptions{
-		NoCache: opts.NoCache,
-		Pull:    opts.Pull,
+		NoCache:    opts.NoCache,
+		Pull:       opts.Pull,
+		Platform:   opts.Platform,
+		SBOM:       opts.SBOM,
+		Provenance: opts.Provenance,
 	})
 }
 
+// warnIfCrossBuildUnsupported warns when the requested platform differs
+// from the daemon's architecture and no QEMU binfmt handler is registered,
+// which makes the foreign-architecture build fail partway through feature
+// install scripts rather than up front.
+func (s *DevContainerService) warnIfCrossBuildUnsupported(ctx context.Context, platform string) {
+	info, err := container.MustDocker().Info(ctx)
+	if err != nil {
+		return
+	}
+	targetArch := build.PlatformArch(platform)
+	if targetArch == build.NormalizeArch(info.Architecture) {
+		return
+	}
+	if !build.QEMUAvailable(targetArch) {
+		ui.Warning("Cross-building for %s but no QEMU binfmt handler found; install one with: docker run --privileged --rm tonistiigi/binfmt --install all", platform)
+	}
+}
+
 // Lock generates, verifies, or checks the devcontainer-lock.json file.
 func (s *DevContainerService) Lock(ctx context.Context, opts LockOptions) (*LockResult, error) {
 	// Load and resolve the devcontainer configuration
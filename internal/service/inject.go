@@ -0,0 +1,102 @@
+package service
+
+import (
+	"encoding/json"
+
+	"github.com/griffithind/dcx/internal/config"
+	"github.com/griffithind/dcx/internal/devcontainer"
+)
+
+// injectionDisabled is set from the CLI's --no-inject flag to skip
+// user-level policy injection for a single invocation.
+var injectionDisabled bool
+
+// SetInjectionDisabled disables user-level default injection (features,
+// mounts, env) for this process. Driven by `dcx --no-inject`.
+func SetInjectionDisabled(v bool) {
+	injectionDisabled = v
+}
+
+// applyUserInjection merges the user-level injection policy
+// (defaultFeatures, defaultMounts, defaultEnv from ~/.config/dcx) into the
+// loaded configuration. Workspace configuration always wins: existing
+// features, mount targets, and env keys are never overridden.
+//
+// The injected payload is appended to the config's raw JSON so it
+// participates in ComputeConfigHash — staleness detection notices policy
+// changes the same way it notices devcontainer.json edits.
+func applyUserInjection(cfg *devcontainer.DevContainerConfig) *devcontainer.DevContainerConfig {
+	if injectionDisabled || cfg == nil {
+		return cfg
+	}
+	userCfg, err := config.User()
+	if err != nil {
+		return cfg
+	}
+	if len(userCfg.DefaultFeatures) == 0 && len(userCfg.DefaultMounts) == 0 && len(userCfg.DefaultEnv) == 0 {
+		return cfg
+	}
+
+	injected := struct {
+		Features map[string]map[string]interface{} `json:"features,omitempty"`
+		Mounts   []string                          `json:"mounts,omitempty"`
+		Env      map[string]string                 `json:"env,omitempty"`
+	}{}
+
+	// Features: inject unless the workspace already references the feature.
+	for ref, opts := range userCfg.DefaultFeatures {
+		if cfg.Features == nil {
+			cfg.Features = make(map[string]interface{})
+		}
+		if _, exists := cfg.Features[ref]; exists {
+			continue
+		}
+		cfg.Features[ref] = map[string]interface{}(opts)
+		if injected.Features == nil {
+			injected.Features = make(map[string]map[string]interface{})
+		}
+		injected.Features[ref] = opts
+	}
+
+	// Mounts: inject unless a workspace mount already claims the target.
+	targets := make(map[string]bool, len(cfg.Mounts))
+	for _, m := range cfg.Mounts {
+		targets[m.Target] = true
+	}
+	for _, spec := range userCfg.DefaultMounts {
+		parsed := devcontainer.ParseWorkspaceMount(spec)
+		if parsed == nil || targets[parsed.Target] {
+			continue
+		}
+		targets[parsed.Target] = true
+		cfg.Mounts = append(cfg.Mounts, *parsed)
+		injected.Mounts = append(injected.Mounts, spec)
+	}
+
+	// Env: inject unless the workspace sets the variable.
+	for k, v := range userCfg.DefaultEnv {
+		if cfg.ContainerEnv == nil {
+			cfg.ContainerEnv = make(map[string]string)
+		}
+		if _, exists := cfg.ContainerEnv[k]; exists {
+			continue
+		}
+		cfg.ContainerEnv[k] = v
+		if injected.Env == nil {
+			injected.Env = make(map[string]string)
+		}
+		injected.Env[k] = v
+	}
+
+	// Fold the injected payload into the hash input.
+	if len(injected.Features) > 0 || len(injected.Mounts) > 0 || len(injected.Env) > 0 {
+		if payload, err := json.Marshal(injected); err == nil {
+			raw := cfg.GetRawJSON()
+			raw = append(raw, []byte("\x00dcx-inject\x00")...)
+			raw = append(raw, payload...)
+			cfg.SetRawJSON(raw)
+		}
+	}
+
+	return cfg
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// opLock is a per-workspace advisory lock guarding mutating operations
+// (up/down/build). Two simultaneous dcx runs — editor plugin plus
+// terminal — otherwise race on container creation and override files.
+type opLock struct {
+	file *os.File
+}
+
+// lockPath returns the lock file for a workspace.
+func lockPath(workspaceID string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(home, ".dcx", "locks")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, workspaceID+".lock"), nil
+}
+
+// acquireOpLock takes the workspace's advisory lock. With wait=false a
+// held lock returns an error naming the holding pid; with wait=true the
+// call blocks until the other operation finishes.
+func acquireOpLock(workspaceID string, wait bool) (*opLock, error) {
+	path, err := lockPath(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	how := syscall.LOCK_EX
+	if !wait {
+		how |= syscall.LOCK_NB
+	}
+	if err := syscall.Flock(int(f.Fd()), how); err != nil {
+		holder := readLockHolder(f)
+		_ = f.Close()
+		if holder != 0 {
+			return nil, fmt.Errorf("another dcx operation is in progress (pid %d); use --wait to queue", holder)
+		}
+		return nil, fmt.Errorf("another dcx operation is in progress; use --wait to queue")
+	}
+
+	// Record our pid for the "in progress (pid …)" message on contention.
+	_ = f.Truncate(0)
+	_, _ = f.Seek(0, 0)
+	_, _ = fmt.Fprintf(f, "%d\n", os.Getpid())
+	_ = f.Sync()
+
+	return &opLock{file: f}, nil
+}
+
+// readLockHolder returns the pid recorded in the lock file, or 0.
+func readLockHolder(f *os.File) int {
+	buf := make([]byte, 32)
+	n, err := f.ReadAt(buf, 0)
+	if n == 0 && err != nil {
+		return 0
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(buf[:n])))
+	if err != nil {
+		return 0
+	}
+	return pid
+}
+
+// release drops the lock. The file stays on disk — flock state, not file
+// existence, is the lock.
+func (l *opLock) release() {
+	if l == nil || l.file == nil {
+		return
+	}
+	_ = syscall.Flock(int(l.file.Fd()), syscall.LOCK_UN)
+	_ = l.file.Close()
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/griffithind/dcx/internal/common"
+)
+
+func TestSnapshotImageRepo(t *testing.T) {
+	assert.Equal(t, "dcx-snapshot/ws_abc123", snapshotImageRepo("ws_abc123"))
+}
+
+func TestSnapshotImageRefSanitizesNames(t *testing.T) {
+	// Snapshot names come from user input and become image tags; the
+	// sanitized form must be a valid docker tag component.
+	ref := snapshotImageRepo("ws1") + ":" + common.SanitizeProjectName("Before Risky Upgrade!")
+
+	assert.True(t, strings.HasPrefix(ref, "dcx-snapshot/ws1:"))
+	tag := strings.TrimPrefix(ref, "dcx-snapshot/ws1:")
+	assert.NotContains(t, tag, " ")
+	assert.NotContains(t, tag, "!")
+	assert.NotEmpty(t, tag)
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/griffithind/dcx/internal/ui"
+)
+
+// TimingRecord is one `dcx up` run's phase-by-phase timing breakdown,
+// persisted per workspace so `dcx status --timings` can show historical
+// startup cost and regressions.
+type TimingRecord struct {
+	Time   time.Time     `json:"time"`
+	Total  float64       `json:"totalSeconds"`
+	Phases []PhaseTiming `json:"phases"`
+}
+
+// PhaseTiming is the duration of one up phase.
+type PhaseTiming struct {
+	Name    string  `json:"name"`
+	Seconds float64 `json:"seconds"`
+}
+
+// timingRecorder accumulates phase durations during an up run.
+type timingRecorder struct {
+	start  time.Time
+	phases []PhaseTiming
+}
+
+func newTimingRecorder() *timingRecorder {
+	return &timingRecorder{start: time.Now()}
+}
+
+// phase starts timing a named phase; the returned func ends it. Phases
+// shorter than 50ms are dropped from the summary as noise.
+func (t *timingRecorder) phase(name string) func() {
+	phaseStart := time.Now()
+	return func() {
+		seconds := time.Since(phaseStart).Seconds()
+		if seconds < 0.05 {
+			return
+		}
+		t.phases = append(t.phases, PhaseTiming{Name: name, Seconds: seconds})
+	}
+}
+
+// finish prints the timing summary and appends the record to the
+// workspace's timing history.
+func (t *timingRecorder) finish(workspaceID string) {
+	record := TimingRecord{
+		Time:   time.Now(),
+		Total:  time.Since(t.start).Seconds(),
+		Phases: t.phases,
+	}
+
+	if len(record.Phases) > 0 {
+		summary := ""
+		for i, p := range record.Phases {
+			if i > 0 {
+				summary += ", "
+			}
+			summary += fmt.Sprintf("%s %.1fs", p.Name, p.Seconds)
+		}
+		ui.Printf("Timing: %s (total %.1fs)", summary, record.Total)
+	}
+
+	_ = appendTimingRecord(workspaceID, record)
+}
+
+// TimingsPath returns the timing history file for a workspace.
+func TimingsPath(workspaceID string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".dcx", "timings", workspaceID+".jsonl")
+}
+
+// appendTimingRecord appends a record to the workspace's timing log.
+func appendTimingRecord(workspaceID string, record TimingRecord) error {
+	path := TimingsPath(workspaceID)
+	if path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// LoadTimings returns up to limit most recent timing records for a
+// workspace, newest last.
+func LoadTimings(workspaceID string, limit int) ([]TimingRecord, error) {
+	path := TimingsPath(workspaceID)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var records []TimingRecord
+	for _, line := range splitLines(data) {
+		var r TimingRecord
+		if err := json.Unmarshal(line, &r); err == nil {
+			records = append(records, r)
+		}
+	}
+	if limit > 0 && len(records) > limit {
+		records = records[len(records)-limit:]
+	}
+	return records, nil
+}
+
+// splitLines splits raw bytes into non-empty lines.
+func splitLines(data []byte) [][]byte {
+	var lines [][]byte
+	start := 0
+	for i, b := range data {
+		if b == '\n' {
+			if i > start {
+				lines = append(lines, data[start:i])
+			}
+			start = i + 1
+		}
+	}
+	if start < len(data) {
+		lines = append(lines, data[start:])
+	}
+	return lines
+}
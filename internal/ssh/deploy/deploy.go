@@ -11,18 +11,38 @@ import (
 
 	dcxembed "github.com/griffithind/dcx"
 	"github.com/griffithind/dcx/internal/common"
+	"github.com/griffithind/dcx/internal/version"
 )
 
 // DeployToContainer deploys the dcx-agent binary to a container.
-// It checks if the binary is already deployed and skips if so.
+// An already-deployed agent is kept only when its reported version
+// matches this build; long-lived containers get a mismatched agent
+// replaced automatically so host and agent stay in lockstep.
 func DeployToContainer(ctx context.Context, containerName, binaryPath string) error {
-	checkCmd := exec.CommandContext(ctx, "docker", "exec", containerName, "test", "-f", binaryPath)
-	if err := checkCmd.Run(); err == nil {
-		return nil
+	if deployed := DeployedAgentVersion(ctx, containerName, binaryPath); deployed != "" {
+		if deployed == version.Version {
+			return nil
+		}
+		// Version mismatch: stop a running listener so the replacement
+		// binary takes over on the next launch.
+		_ = exec.CommandContext(ctx, "docker", "exec", containerName, "pkill", "-f", "dcx-agent listen").Run()
 	}
 	return copyBinaryToContainer(ctx, containerName, binaryPath)
 }
 
+// DeployedAgentVersion returns the version reported by an agent binary
+// already present in the container, or "" when none responds (missing
+// binary, or an old agent without the version subcommand — treated as a
+// mismatch and redeployed).
+func DeployedAgentVersion(ctx context.Context, containerName, binaryPath string) string {
+	cmd := exec.CommandContext(ctx, "docker", "exec", containerName, binaryPath, "version")
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
 func copyBinaryToContainer(ctx context.Context, containerName, binaryPath string) error {
 	containerArch := getContainerArch(ctx, containerName)
 	agentPath := getAgentBinaryPath(containerArch)
@@ -50,6 +70,14 @@ func copyBinaryToContainer(ctx context.Context, containerName, binaryPath string
 		return fmt.Errorf("failed to make agent executable: %w", err)
 	}
 
+	// Smoke-test the copied binary. It is statically linked (CGO off,
+	// osusergo/netgo), so musl-based images like alpine work; a failure
+	// here means an architecture mismatch rather than a libc problem.
+	verifyCmd := exec.CommandContext(ctx, "docker", "exec", containerName, binaryPath, "version")
+	if err := verifyCmd.Run(); err != nil {
+		return fmt.Errorf("deployed agent does not execute in container (architecture %s): %w", containerArch, err)
+	}
+
 	return nil
 }
 
@@ -72,11 +100,40 @@ func getAgentBinaryPath(arch string) string {
 	return tmpFile.Name()
 }
 
+// getContainerArch determines the container's CPU architecture before
+// copying, so arm64 containers on amd64 hosts (and vice versa) get the
+// right binary. Falls back from `uname -m` (absent in distroless images)
+// to the image manifest's architecture, then the host's.
 func getContainerArch(ctx context.Context, containerName string) string {
 	cmd := exec.CommandContext(ctx, "docker", "exec", containerName, "uname", "-m")
+	if output, err := cmd.Output(); err == nil {
+		if arch := strings.TrimSpace(string(output)); arch != "" {
+			return arch
+		}
+	}
+
+	if arch := imageArch(ctx, containerName); arch != "" {
+		return arch
+	}
+	return runtime.GOARCH
+}
+
+// imageArch reads the architecture from the container image's config.
+func imageArch(ctx context.Context, containerName string) string {
+	cmd := exec.CommandContext(ctx, "docker", "inspect", "--format", "{{.Image}}", containerName)
 	output, err := cmd.Output()
 	if err != nil {
-		return runtime.GOARCH
+		return ""
+	}
+	imageID := strings.TrimSpace(string(output))
+	if imageID == "" {
+		return ""
+	}
+
+	cmd = exec.CommandContext(ctx, "docker", "image", "inspect", "--format", "{{.Architecture}}", imageID)
+	output, err = cmd.Output()
+	if err != nil {
+		return ""
 	}
 	return strings.TrimSpace(string(output))
 }
@@ -15,11 +15,14 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"path"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"syscall"
 	"time"
 
+	"github.com/griffithind/dcx/internal/config"
 	"github.com/griffithind/dcx/internal/container"
 	"github.com/griffithind/dcx/internal/devcontainer"
 	dcxssh "github.com/griffithind/dcx/internal/ssh"
@@ -46,6 +49,24 @@ type ContainerExecOptions struct {
 	Stdout        io.Writer // defaults to os.Stdout
 	Stderr        io.Writer // defaults to os.Stderr
 	TTY           *bool     // nil = auto-detect from stdin
+
+	// Forwards are -L/-R style port forwards kept open for the session's
+	// lifetime.
+	Forwards []ForwardSpec
+}
+
+// ForwardSpec describes one port forward attached to a session.
+type ForwardSpec struct {
+	// Reverse forwards a container-side listener to a host-side target
+	// (-R); otherwise a host-side listener dials into the container (-L).
+	Reverse bool
+
+	// BindAddr is the listen address ("127.0.0.1:8080").
+	BindAddr string
+
+	// TargetAddr is where accepted connections are dialed
+	// ("localhost:80").
+	TargetAddr string
 }
 
 // ExecInContainer runs a command (or interactive shell) inside a container
@@ -53,7 +74,23 @@ type ContainerExecOptions struct {
 func ExecInContainer(ctx context.Context, opts ContainerExecOptions) (int, error) {
 	user, workDir := resolveUserAndWorkDir(opts.Config, opts.WorkspacePath)
 
-	env := buildExecEnvironment(user, opts.Config)
+	// Running from a workspace subdirectory maps the host cwd onto the
+	// corresponding container path, so `dcx exec -- make` behaves like
+	// running make locally.
+	if mapped := mapHostCwd(opts.WorkspacePath, workDir); mapped != "" {
+		workDir = mapped
+	}
+
+	// Resolve ${containerEnv:VAR} references in remoteEnv against the
+	// container's actual environment — applied per session, so remoteEnv
+	// edits take effect without a container recreate.
+	var containerEnv map[string]string
+	if opts.ContainerName != "" {
+		containerEnv, _ = container.MustDocker().ContainerEnv(ctx, opts.ContainerName)
+	}
+
+	env := buildExecEnvironment(user, opts.Config, containerEnv)
+	env = append(env, forwardedHostEnv()...)
 	env = append(env, opts.Env...)
 
 	tty := false
@@ -82,6 +119,13 @@ func ExecInContainer(ctx context.Context, opts ContainerExecOptions) (int, error
 	}
 	defer func() { _ = client.Close() }()
 
+	// Port forwards live for the duration of the session.
+	for _, fwd := range opts.Forwards {
+		if err := startForward(ctx, client, fwd); err != nil {
+			return -1, fmt.Errorf("port forward %s: %w", fwd.BindAddr, err)
+		}
+	}
+
 	session, err := client.NewSession()
 	if err != nil {
 		return -1, fmt.Errorf("new ssh session: %w", err)
@@ -131,14 +175,13 @@ func ExecInContainer(ctx context.Context, opts ContainerExecOptions) (int, error
 		}
 	}
 
+	// The agent honors DCX_WORKDIR as the session working directory.
+	_ = session.Setenv("DCX_WORKDIR", workDir)
+
 	session.Stdin = stdin
 	session.Stdout = stdout
 	session.Stderr = stderr
 
-	// Set working directory (via env; the server respects it via Config.WorkDir
-	// but for ssh-session cd we rely on the shell picking up PWD).
-	_ = workDir // server uses its own workdir; preserved for future use
-
 	var runErr error
 	if len(opts.Command) == 0 {
 		runErr = session.Shell()
@@ -161,6 +204,55 @@ func ExecInContainer(ctx context.Context, opts ContainerExecOptions) (int, error
 	return 0, nil
 }
 
+// startForward wires one -L/-R forward over the SSH connection. Local
+// forwards listen on the host and dial through the container; reverse
+// forwards listen inside the container (requires an agent that permits
+// reverse forwarding) and dial host-side targets.
+func startForward(ctx context.Context, client *ssh.Client, fwd ForwardSpec) error {
+	var ln net.Listener
+	var err error
+	if fwd.Reverse {
+		ln, err = client.Listen("tcp", fwd.BindAddr)
+	} else {
+		ln, err = net.Listen("tcp", fwd.BindAddr)
+	}
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		defer func() { _ = ln.Close() }()
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func(src net.Conn) {
+				defer func() { _ = src.Close() }()
+				var dst net.Conn
+				var err error
+				if fwd.Reverse {
+					dst, err = net.Dial("tcp", fwd.TargetAddr)
+				} else {
+					dst, err = client.Dial("tcp", fwd.TargetAddr)
+				}
+				if err != nil {
+					return
+				}
+				defer func() { _ = dst.Close() }()
+				go func() { _, _ = io.Copy(dst, src) }()
+				_, _ = io.Copy(src, dst)
+			}(conn)
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		_ = ln.Close()
+	}()
+	return nil
+}
+
 // connect dials the dcx-agent's TCP listener for containerName and
 // completes the SSH handshake.
 //
@@ -355,6 +447,55 @@ func handleWindowResize(ctx context.Context, session *ssh.Session, fd int) {
 	}
 }
 
+// defaultForwardEnv are host variables forwarded into every session:
+// terminal and locale settings that make tools behave correctly inside
+// the container without leaking the full host environment.
+var defaultForwardEnv = []string{"TERM", "LANG", "LC_ALL", "COLORTERM"}
+
+// forwardedHostEnv returns KEY=VALUE pairs for the whitelisted host
+// variables that are actually set: the built-in terminal/locale defaults
+// plus ssh.forwardEnv from the user config.
+func forwardedHostEnv() []string {
+	keys := append([]string{}, defaultForwardEnv...)
+	if userCfg, err := config.User(); err == nil {
+		keys = append(keys, userCfg.SSH.ForwardEnv...)
+	}
+
+	var env []string
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if value, ok := os.LookupEnv(key); ok {
+			env = append(env, key+"="+value)
+		}
+	}
+	return env
+}
+
+// mapHostCwd maps the host working directory to its container
+// counterpart when it sits inside the workspace: workspaceFolder plus
+// the relative path. Returns "" when the cwd is outside the workspace.
+func mapHostCwd(workspacePath, workspaceFolder string) string {
+	if workspacePath == "" || workspaceFolder == "" {
+		return ""
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	rel, err := filepath.Rel(workspacePath, cwd)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+		return ""
+	}
+	if rel == "." {
+		return workspaceFolder
+	}
+	return path.Join(workspaceFolder, filepath.ToSlash(rel))
+}
+
 // resolveUserAndWorkDir determines the user and working directory for
 // container execution. It uses values from the devcontainer config if
 // available, with sensible defaults.
@@ -383,14 +524,16 @@ func resolveUserAndWorkDir(cfg *devcontainer.DevContainerConfig, workspacePath s
 }
 
 // buildExecEnvironment creates the base environment for container
-// execution.
-func buildExecEnvironment(user string, cfg *devcontainer.DevContainerConfig) []string {
+// execution. remoteEnv values are substituted against the container's
+// actual environment so ${containerEnv:VAR} resolves per spec.
+func buildExecEnvironment(user string, cfg *devcontainer.DevContainerConfig, containerEnv map[string]string) []string {
 	env := []string{
 		"USER=" + user,
 	}
 	if cfg != nil {
+		subCtx := &devcontainer.SubstitutionContext{ContainerEnv: containerEnv}
 		for k, v := range cfg.RemoteEnv {
-			env = append(env, k+"="+v)
+			env = append(env, k+"="+devcontainer.Substitute(v, subCtx))
 		}
 	}
 	return env
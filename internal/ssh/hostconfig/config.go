@@ -50,6 +50,24 @@ func withConfigLock(fn func() error) error {
 	return fn()
 }
 
+// Options are user-configurable ssh_config options applied to every
+// generated host block (from the user config's ssh: section).
+type Options struct {
+	// ControlMaster enables multiplexing (ControlMaster auto with a
+	// per-host ControlPath and 10m ControlPersist).
+	ControlMaster bool
+
+	// ServerAliveInterval in seconds; 0 omits the option.
+	ServerAliveInterval int
+
+	// ForwardAgent; nil means the default (yes).
+	ForwardAgent *bool
+
+	// IdentityFile overrides the advertised identity (default
+	// ~/.dcx/id_ed25519).
+	IdentityFile string
+}
+
 // Entry captures everything needed to render one ~/.ssh/config block.
 //
 // HostName is almost always "127.0.0.1" — the only case that varies is
@@ -62,17 +80,28 @@ type Entry struct {
 	BindHost       string // HostName in the generated block (usually "127.0.0.1")
 	Port           int
 	KnownHostsPath string // usually ~/.dcx/known_hosts
+	Options        Options
 }
 
-// AddSSHConfig writes or replaces the ssh_config block for a container.
-// Safe for concurrent use from multiple dcx invocations.
+// AddSSHConfig writes or replaces the ssh_config block for a container in
+// the dedicated ~/.ssh/dcx_config include file, ensuring the main
+// ~/.ssh/config carries an Include directive for it. Safe for concurrent
+// use from multiple dcx invocations.
 func AddSSHConfig(entry Entry) error {
 	return withConfigLock(func() error {
-		configPath := getSSHConfigPath()
+		includePath := getDcxConfigPath()
 
-		content, _ := os.ReadFile(configPath)
+		content, _ := os.ReadFile(includePath)
 		content = removeSSHConfigEntry(content, entry.ContainerName)
 
+		// Migrate: drop any legacy block from the main config.
+		if mainContent, err := os.ReadFile(getSSHConfigPath()); err == nil {
+			cleaned := removeSSHConfigEntry(mainContent, entry.ContainerName)
+			if string(cleaned) != string(mainContent) {
+				_ = os.WriteFile(getSSHConfigPath(), cleaned, 0600)
+			}
+		}
+
 		bindHost := entry.BindHost
 		if bindHost == "" {
 			bindHost = "127.0.0.1"
@@ -88,13 +117,35 @@ func AddSSHConfig(entry Entry) error {
 		block := renderBlock(entry, bindHost, knownHosts)
 		newContent := append(content, []byte(block)...)
 
-		if err := os.MkdirAll(filepath.Dir(configPath), 0700); err != nil {
+		if err := os.MkdirAll(filepath.Dir(includePath), 0700); err != nil {
 			return fmt.Errorf("create .ssh dir: %w", err)
 		}
-		return os.WriteFile(configPath, newContent, 0600)
+		if err := os.WriteFile(includePath, newContent, 0600); err != nil {
+			return err
+		}
+		return ensureInclude()
 	})
 }
 
+// ensureInclude prepends "Include ~/.ssh/dcx_config" to the main
+// ~/.ssh/config if it isn't referenced yet. Include must appear before
+// the first Host block to apply globally, so it goes at the top.
+func ensureInclude() error {
+	configPath := getSSHConfigPath()
+	includeLine := "Include " + getDcxConfigPath()
+
+	content, _ := os.ReadFile(configPath)
+	if strings.Contains(string(content), includeLine) {
+		return nil
+	}
+
+	newContent := includeLine + "\n\n" + string(content)
+	if err := os.MkdirAll(filepath.Dir(configPath), 0700); err != nil {
+		return fmt.Errorf("create .ssh dir: %w", err)
+	}
+	return os.WriteFile(configPath, []byte(newContent), 0600)
+}
+
 // renderBlock formats the config stanza dcx writes.
 func renderBlock(e Entry, bindHost, knownHosts string) string {
 	var b strings.Builder
@@ -121,47 +172,78 @@ func renderBlock(e Entry, bindHost, knownHosts string) string {
 	// ~/.ssh/id_* (or an agent-loaded identity) can still connect via a
 	// plain `ssh <host>` invocation. If the file is absent, OpenSSH silently
 	// skips it, so this is safe to add unconditionally.
-	if home, err := os.UserHomeDir(); err == nil {
-		fmt.Fprintf(&b, "  IdentityFile %s\n", filepath.Join(home, ".dcx", "id_ed25519"))
+	identity := e.Options.IdentityFile
+	if identity == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			identity = filepath.Join(home, ".dcx", "id_ed25519")
+		}
+	}
+	if identity != "" {
+		fmt.Fprintf(&b, "  IdentityFile %s\n", identity)
+	}
+	if e.Options.ControlMaster {
+		fmt.Fprintln(&b, "  ControlMaster auto")
+		if home, err := os.UserHomeDir(); err == nil {
+			fmt.Fprintf(&b, "  ControlPath %s\n", filepath.Join(home, ".ssh", "dcx-%r@%h:%p"))
+		}
+		fmt.Fprintln(&b, "  ControlPersist 10m")
+	}
+	if e.Options.ServerAliveInterval > 0 {
+		fmt.Fprintf(&b, "  ServerAliveInterval %d\n", e.Options.ServerAliveInterval)
+	}
+	forwardAgent := "yes"
+	if e.Options.ForwardAgent != nil && !*e.Options.ForwardAgent {
+		forwardAgent = "no"
 	}
-	fmt.Fprintln(&b, "  ForwardAgent yes")
+	fmt.Fprintf(&b, "  ForwardAgent %s\n", forwardAgent)
 	fmt.Fprintln(&b, "  IdentitiesOnly no")
 	fmt.Fprintln(&b, "  LogLevel ERROR")
 	fmt.Fprintf(&b, "%s%s\n\n", sshConfigMarkerEnd, e.ContainerName)
 	return b.String()
 }
 
-// RemoveSSHConfig removes the SSH config entry for a container.
-// Safe for concurrent use from multiple processes.
+// RemoveSSHConfig removes the SSH config entry for a container from both
+// the dcx include file and (legacy) the main config. Safe for concurrent
+// use from multiple processes.
 func RemoveSSHConfig(containerName string) error {
 	return withConfigLock(func() error {
-		configPath := getSSHConfigPath()
-		content, err := os.ReadFile(configPath)
-		if err != nil {
-			return nil // No config file, nothing to remove
-		}
-
-		newContent := removeSSHConfigEntry(content, containerName)
-
-		// Only write if content changed
-		if string(newContent) != string(content) {
-			return os.WriteFile(configPath, newContent, 0600)
+		for _, path := range []string{getDcxConfigPath(), getSSHConfigPath()} {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			newContent := removeSSHConfigEntry(content, containerName)
+			if string(newContent) != string(content) {
+				if err := os.WriteFile(path, newContent, 0600); err != nil {
+					return err
+				}
+			}
 		}
-
 		return nil
 	})
 }
 
-// HasSSHConfig checks if an SSH config entry exists for a container.
+// HasSSHConfig checks if an SSH config entry exists for a container in
+// either the dcx include file or (legacy) the main config.
 func HasSSHConfig(containerName string) bool {
-	configPath := getSSHConfigPath()
-	content, err := os.ReadFile(configPath)
-	if err != nil {
-		return false
+	marker := sshConfigMarkerStart + containerName
+	for _, path := range []string{getDcxConfigPath(), getSSHConfigPath()} {
+		if content, err := os.ReadFile(path); err == nil {
+			if strings.Contains(string(content), marker) {
+				return true
+			}
+		}
 	}
+	return false
+}
 
-	marker := sshConfigMarkerStart + containerName
-	return strings.Contains(string(content), marker)
+// getDcxConfigPath returns the dedicated include file dcx owns outright.
+func getDcxConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = os.Getenv("HOME")
+	}
+	return filepath.Join(home, ".ssh", "dcx_config")
 }
 
 // getSSHConfigPath returns the path to the SSH config file.
@@ -108,10 +108,16 @@ func TestAddSSHConfig(t *testing.T) {
 		err := AddSSHConfig(sampleEntry())
 		require.NoError(t, err)
 
-		content, err := os.ReadFile(filepath.Join(sshDir, "config"))
+		content, err := os.ReadFile(filepath.Join(sshDir, "dcx_config"))
 		require.NoError(t, err)
 		s := string(content)
 
+		// The main config only carries the Include directive.
+		mainContent, err := os.ReadFile(filepath.Join(sshDir, "config"))
+		require.NoError(t, err)
+		assert.Contains(t, string(mainContent), "Include ")
+		assert.NotContains(t, string(mainContent), "# DCX managed")
+
 		assert.Contains(t, s, "# DCX managed - test-container")
 		assert.Contains(t, s, "Host test.dcx")
 		assert.Contains(t, s, "HostName 127.0.0.1")
@@ -130,7 +136,7 @@ func TestAddSSHConfig(t *testing.T) {
 		err := AddSSHConfig(e)
 		require.NoError(t, err)
 
-		content, err := os.ReadFile(filepath.Join(sshDir, "config"))
+		content, err := os.ReadFile(filepath.Join(sshDir, "dcx_config"))
 		require.NoError(t, err)
 
 		count := strings.Count(string(content), "# DCX managed - test-container")
@@ -149,7 +155,7 @@ func TestAddSSHConfigStrictHostKeyCheckingWhenKnownHostsSet(t *testing.T) {
 	e.KnownHostsPath = filepath.Join(tmpDir, ".dcx", "known_hosts")
 	require.NoError(t, AddSSHConfig(e))
 
-	content, _ := os.ReadFile(filepath.Join(tmpDir, ".ssh", "config"))
+	content, _ := os.ReadFile(filepath.Join(tmpDir, ".ssh", "dcx_config"))
 	assert.Contains(t, string(content), "StrictHostKeyChecking yes")
 	assert.Contains(t, string(content), "UserKnownHostsFile "+e.KnownHostsPath)
 }
@@ -171,7 +177,7 @@ func TestRemoveSSHConfig(t *testing.T) {
 		err := RemoveSSHConfig("test-container")
 		require.NoError(t, err)
 
-		content, _ := os.ReadFile(filepath.Join(tmpDir, ".ssh", "config"))
+		content, _ := os.ReadFile(filepath.Join(tmpDir, ".ssh", "dcx_config"))
 		assert.NotContains(t, string(content), "test-container")
 	})
 }
@@ -227,9 +233,11 @@ Host test.dcx
 
 	require.NoError(t, AddSSHConfig(sampleEntry()))
 
-	content, _ := os.ReadFile(filepath.Join(tmpDir, ".ssh", "config"))
+	mainContent, _ := os.ReadFile(filepath.Join(tmpDir, ".ssh", "config"))
+	assert.NotContains(t, string(mainContent), "ProxyCommand", "legacy ProxyCommand should be removed")
+
+	content, _ := os.ReadFile(filepath.Join(tmpDir, ".ssh", "dcx_config"))
 	s := string(content)
-	assert.NotContains(t, s, "ProxyCommand", "legacy ProxyCommand should be removed")
 	assert.Contains(t, s, "HostName 127.0.0.1")
 	assert.Contains(t, s, "Port 53412")
 }
@@ -13,6 +13,7 @@ import (
 	"syscall"
 
 	"github.com/griffithind/dcx/internal/common"
+	"github.com/griffithind/dcx/internal/version"
 )
 
 // Execute runs the agent CLI.
@@ -27,6 +28,11 @@ func Execute() error {
 		return runListenCmd(os.Args[2:])
 	case "ping":
 		return runPingCmd(os.Args[2:])
+	case "watch":
+		return runWatchCmd(os.Args[2:])
+	case "version":
+		fmt.Println(version.Version)
+		return nil
 	case "-h", "--help", "help":
 		printUsage()
 		return nil
@@ -45,6 +51,8 @@ Usage:
 Commands:
   listen  Run SSH server listening on a TCP address
   ping    Probe whether a listener is live (used by host for health checks)
+  watch   Watch a directory and run a command on changes
+  version Print the agent version
 
 Use "dcx-agent <command> --help" for more information about a command.
 `)
@@ -62,6 +70,8 @@ func runListenCmd(args []string) error {
 	hostKey := fs.String("host-key", defaultHostKeyPath(), "Path to persistent host key")
 	authKeys := fs.String("authorized-keys", defaultAuthorizedKeysPath(), "Primary authorized_keys file")
 	allowCIDRs := fs.String("allow-cidrs", "", "Comma-separated CIDR list to accept in addition to loopback")
+	var envVars stringList
+	fs.Var(&envVars, "env", "KEY=VALUE applied to every session (repeatable; carries remoteEnv)")
 
 	if err := fs.Parse(args); err != nil {
 		return err
@@ -85,6 +95,7 @@ func runListenCmd(args []string) error {
 		AuthorizedKeysPaths: []string{*authKeys},
 		Gate:                gate,
 		ReadyFile:           DefaultReadyFilePath,
+		Env:                 envVars,
 	})
 	if err != nil {
 		return err
@@ -105,6 +116,16 @@ func runListenCmd(args []string) error {
 	return server.Listen(ctx, *addr)
 }
 
+// stringList is a repeatable string flag (stdlib flag has no slice type).
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ",") }
+
+func (l *stringList) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
+
 // buildGate returns a Gate that accepts loopback, the container's default
 // gateway (see below), plus any user-supplied --allow-cidrs.
 //
@@ -7,6 +7,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -148,11 +149,9 @@ func waitForReadyAddr(t *testing.T, readyFile string, timeout time.Duration) str
 	for time.Now().Before(deadline) {
 		data, err := os.ReadFile(readyFile)
 		if err == nil {
-			addr := string(data)
-			// Strip trailing newline.
-			if len(addr) > 0 && addr[len(addr)-1] == '\n' {
-				addr = addr[:len(addr)-1]
-			}
+			// First line is the listen address; the second carries the
+			// start time for `dcx agent status`.
+			addr, _, _ := strings.Cut(string(data), "\n")
 			// Verify it's actually listening before returning.
 			conn, err := net.DialTimeout("tcp", addr, 100*time.Millisecond)
 			if err == nil {
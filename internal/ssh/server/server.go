@@ -21,6 +21,8 @@ import (
 	"os/exec"
 	"os/user"
 	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -52,6 +54,10 @@ type Config struct {
 	// /var/lib/dcx/agent-ready inside the container, readable via
 	// `docker exec cat`.
 	ReadyFile string
+
+	// Env is appended to every session's environment (KEY=VALUE). The
+	// host passes remoteEnv here at agent launch.
+	Env []string
 }
 
 // Server is the SSH server.
@@ -72,6 +78,8 @@ func NewServer(cfg Config) (*Server, error) {
 		shellConfig: SetupShellIntegration(cfg.Shell),
 	}
 
+	forwardHandler := &ssh.ForwardedTCPHandler{}
+
 	server := &ssh.Server{
 		Handler: s.sessionHandler,
 		SubsystemHandlers: map[string]ssh.SubsystemHandler{
@@ -83,17 +91,22 @@ func NewServer(cfg Config) (*Server, error) {
 		LocalPortForwardingCallback: func(ctx ssh.Context, dhost string, dport uint32) bool {
 			return dhost == "127.0.0.1" || dhost == "::1" || dhost == "localhost"
 		},
-		// Reverse port forwarding is off by default — an authenticated client
-		// should not be able to bind new listeners on the container interface.
+		// Reverse port forwarding is restricted to loopback binds: an
+		// authenticated client may expose host services as
+		// localhost:<port> inside the container (reverseForwardPorts),
+		// but cannot bind listeners on the container's external
+		// interfaces.
 		ReversePortForwardingCallback: func(ctx ssh.Context, host string, port uint32) bool {
-			return false
+			return host == "127.0.0.1" || host == "::1" || host == "localhost" || host == ""
 		},
 		ChannelHandlers: map[string]ssh.ChannelHandler{
 			"direct-tcpip": ssh.DirectTCPIPHandler,
 			"session":      ssh.DefaultSessionHandler,
 		},
-		// tcpip-forward / cancel-tcpip-forward handlers intentionally omitted
-		// so reverse forwarding is not even advertised.
+		RequestHandlers: map[string]ssh.RequestHandler{
+			"tcpip-forward":        forwardHandler.HandleSSHRequest,
+			"cancel-tcpip-forward": forwardHandler.HandleSSHRequest,
+		},
 	}
 
 	if cfg.Gate == nil {
@@ -165,17 +178,55 @@ func (s *Server) Listen(ctx context.Context, addr string) error {
 	}
 }
 
-// writeReadyFile drops a minimal heartbeat file at path.
+// writeReadyFile drops a minimal heartbeat file at path: the listen
+// address on the first line, the start time (Unix seconds) on the second
+// so the host can compute agent uptime.
 // Best-effort; errors are silent because readiness is advisory.
 func writeReadyFile(path, listenAddr string) {
 	_ = os.MkdirAll(filepath.Dir(path), 0755)
-	_ = os.WriteFile(path, []byte(listenAddr+"\n"), 0644)
+	content := fmt.Sprintf("%s\n%d\n", listenAddr, time.Now().Unix())
+	_ = os.WriteFile(path, []byte(content), 0644)
 }
 
 // DefaultReadyFilePath is the sentinel the agent writes once Accept is live
 // inside the container. Readable from the host via `docker exec cat`.
 const DefaultReadyFilePath = "/var/lib/dcx/agent-ready"
 
+// SessionCountPath records the number of currently active SSH sessions,
+// rewritten by the agent as sessions open and close. Read by
+// `dcx agent status`.
+const SessionCountPath = "/var/lib/dcx/agent-sessions"
+
+// activeSessions tracks the live session count for SessionCountPath.
+var activeSessions atomic.Int64
+
+// trackSession bumps the active session count and mirrors it to
+// SessionCountPath; the returned func undoes both.
+func trackSession() func() {
+	n := activeSessions.Add(1)
+	writeSessionCount(n)
+	return func() {
+		writeSessionCount(activeSessions.Add(-1))
+	}
+}
+
+func writeSessionCount(n int64) {
+	_ = os.MkdirAll(filepath.Dir(SessionCountPath), 0755)
+	_ = os.WriteFile(SessionCountPath, []byte(fmt.Sprintf("%d\n", n)), 0644)
+}
+
+// LastActivityPath records the Unix timestamp of the most recent SSH
+// session start or end. The host-side idle watcher reads it via
+// `docker exec cat` to decide whether the environment can be stopped.
+const LastActivityPath = "/var/lib/dcx/last-activity"
+
+// touchActivity stamps LastActivityPath with the current Unix time.
+// Best-effort: idle detection degrades to "never idle" if it fails.
+func touchActivity() {
+	_ = os.MkdirAll(filepath.Dir(LastActivityPath), 0755)
+	_ = os.WriteFile(LastActivityPath, []byte(fmt.Sprintf("%d\n", time.Now().Unix())), 0644)
+}
+
 // authorize returns true if the presented public key appears in any of the
 // configured AuthorizedKeysPaths. Reads are performed per-attempt because
 // authorized_keys can be rewritten at any time via runtime secret re-mount.
@@ -219,6 +270,12 @@ func matchAuthorizedKey(authorizedKeys []byte, presented ssh.PublicKey) bool {
 
 // sessionHandler handles SSH session requests.
 func (s *Server) sessionHandler(sess ssh.Session) {
+	// Record activity at session start and end so the idle watcher sees
+	// both long-lived shells and quick execs.
+	touchActivity()
+	defer touchActivity()
+	defer trackSession()()
+
 	// Setup agent forwarding if requested
 	var agentSock string
 	if ssh.AgentRequested(sess) {
@@ -271,13 +328,31 @@ func (s *Server) buildCommand(sess ssh.Session, isPty bool) *exec.Cmd {
 		}
 	}
 
-	cmd.Dir = s.resolveWorkDir()
+	cmd.Dir = s.sessionWorkDir(sess)
 	cmd.Env = s.buildEnvironment()
 	s.applyUserCredentials(cmd)
 
 	return cmd
 }
 
+// sessionWorkDir picks the session's working directory: the client's
+// DCX_WORKDIR request (dcx exec maps the host cwd to the corresponding
+// container path) when it names an existing directory, otherwise the
+// configured default.
+func (s *Server) sessionWorkDir(sess ssh.Session) string {
+	for _, kv := range sess.Environ() {
+		dir, found := strings.CutPrefix(kv, "DCX_WORKDIR=")
+		if !found {
+			continue
+		}
+		if stat, err := os.Stat(dir); err == nil && stat.IsDir() {
+			return dir
+		}
+		break
+	}
+	return s.resolveWorkDir()
+}
+
 // applyUserCredentials configures cmd to run as s.cfg.User when the agent
 // process itself has a different effective UID (the common case: agent runs
 // as root, session should drop privs to the devcontainer's remoteUser).
@@ -366,6 +441,13 @@ func (s *Server) buildEnvironment() []string {
 	// Add shell integration env vars for terminal titles (from cached config)
 	env = append(env, s.shellConfig.Env...)
 
+	// remoteEnv passed by the host at agent launch
+	env = append(env, s.cfg.Env...)
+
+	// Feature-extended PATH as seen by the agent; the dcx profile.d
+	// drop-in re-asserts it after /etc/profile resets PATH.
+	env = append(env, "DCX_ORIG_PATH="+os.Getenv("PATH"))
+
 	return env
 }
 
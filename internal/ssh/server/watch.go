@@ -0,0 +1,121 @@
+package server
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// runWatchCmd implements `dcx-agent watch`: a dependency-free polling
+// watcher that scans a directory tree, and re-runs a command whenever
+// files matching the configured globs change. Used by host-side
+// `dcx watch` to drive updateContentCommand-style loops inside the
+// container.
+func runWatchCmd(args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	dir := fs.String("dir", "/workspace", "Directory to watch")
+	globs := fs.String("globs", "", "Comma-separated glob patterns (empty = all files)")
+	interval := fs.Duration("interval", 2*time.Second, "Poll interval")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	command := fs.Args()
+	if len(command) == 0 {
+		return fmt.Errorf("watch: no command specified")
+	}
+
+	var patterns []string
+	if *globs != "" {
+		patterns = strings.Split(*globs, ",")
+	}
+
+	fmt.Fprintf(os.Stderr, "dcx-agent: watching %s (globs: %s)\n", *dir, *globs)
+
+	last := scanTree(*dir, patterns)
+	for {
+		time.Sleep(*interval)
+		current := scanTree(*dir, patterns)
+		if current == last {
+			continue
+		}
+		last = current
+
+		fmt.Fprintf(os.Stderr, "dcx-agent: change detected, running command\n")
+		cmd := exec.Command(command[0], command[1:]...)
+		cmd.Dir = *dir
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			fmt.Fprintf(os.Stderr, "dcx-agent: command failed: %v\n", err)
+		}
+		// Re-scan after the command so its own writes don't retrigger.
+		last = scanTree(*dir, patterns)
+	}
+}
+
+// scanTree folds the matching files' paths, sizes, and mtimes into a
+// fingerprint. Hidden VCS and dependency directories are skipped to keep
+// the poll cheap.
+func scanTree(root string, patterns []string) string {
+	var fingerprint strings.Builder
+	_ = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := d.Name()
+		if d.IsDir() {
+			switch name {
+			case ".git", "node_modules", ".dcx", "__pycache__":
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return nil
+		}
+		if !matchGlobs(rel, patterns) {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			fmt.Fprintf(&fingerprint, "%s:%d:%d\n", rel, info.Size(), info.ModTime().UnixNano())
+		}
+		return nil
+	})
+	return fingerprint.String()
+}
+
+// matchGlobs matches a relative path against the patterns. A leading
+// "**/" matches any directory depth (the rest matches the base name);
+// other patterns go through path.Match against the relative path, then
+// the base name. Empty patterns match everything.
+func matchGlobs(rel string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	rel = filepath.ToSlash(rel)
+	base := path.Base(rel)
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if after, found := strings.CutPrefix(pattern, "**/"); found {
+			if ok, _ := path.Match(after, base); ok {
+				return true
+			}
+			continue
+		}
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, base); ok {
+			return true
+		}
+	}
+	return false
+}
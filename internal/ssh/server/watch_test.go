@@ -0,0 +1,75 @@
+package server
+
+import (
+	"os"
+	"testing"
+)
+
+func TestMatchGlobs(t *testing.T) {
+	tests := []struct {
+		rel      string
+		patterns []string
+		want     bool
+	}{
+		// Empty patterns match everything.
+		{"main.go", nil, true},
+		{"deep/nested/file.txt", nil, true},
+
+		// Relative-path match.
+		{"main.go", []string{"*.go"}, true},
+		{"cmd/main.go", []string{"*.go"}, true}, // base-name fallback
+		{"main.txt", []string{"*.go"}, false},
+
+		// "**/" prefix matches any depth by base name.
+		{"a/b/c/handler.go", []string{"**/*.go"}, true},
+		{"a/b/c/handler.txt", []string{"**/*.go"}, false},
+
+		// Multiple patterns: any match wins.
+		{"Makefile", []string{"*.go", "Makefile"}, true},
+
+		// Explicit relative path pattern.
+		{"src/app.py", []string{"src/*.py"}, true},
+		{"other/app.py", []string{"src/*.py"}, true}, // base-name fallback
+	}
+
+	for _, tt := range tests {
+		if got := matchGlobs(tt.rel, tt.patterns); got != tt.want {
+			t.Errorf("matchGlobs(%q, %v) = %v, want %v", tt.rel, tt.patterns, got, tt.want)
+		}
+	}
+}
+
+func TestScanTreeFingerprint(t *testing.T) {
+	dir := t.TempDir()
+	writeWatchFile(t, dir, "a.go", "package a")
+
+	first := scanTree(dir, []string{"*.go"})
+	if first == "" {
+		t.Fatal("fingerprint should not be empty with a matching file")
+	}
+
+	// Unchanged tree, unchanged fingerprint.
+	if second := scanTree(dir, []string{"*.go"}); second != first {
+		t.Error("fingerprint changed without edits")
+	}
+
+	// Content change moves the fingerprint.
+	writeWatchFile(t, dir, "a.go", "package a // edited")
+	if changed := scanTree(dir, []string{"*.go"}); changed == first {
+		t.Error("fingerprint should change when a matching file changes")
+	}
+
+	// Non-matching files don't affect it.
+	base := scanTree(dir, []string{"*.go"})
+	writeWatchFile(t, dir, "notes.txt", "ignore me")
+	if after := scanTree(dir, []string{"*.go"}); after != base {
+		t.Error("non-matching files must not affect the fingerprint")
+	}
+}
+
+func writeWatchFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(dir+"/"+name, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
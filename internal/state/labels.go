@@ -38,6 +38,11 @@ const (
 
 	// LabelConfigPath is the path to devcontainer.json relative to workspace.
 	LabelConfigPath = Prefix + ".config.path"
+
+	// LabelDockerContext is the docker context the container was created
+	// against. Environments created via different contexts stay
+	// distinguishable even when both contexts resolve to the same daemon.
+	LabelDockerContext = Prefix + ".docker.context"
 )
 
 // Hash labels for staleness detection.
@@ -45,6 +50,12 @@ const (
 	// LabelHashConfig is the combined hash of all build inputs
 	// (devcontainer.json, Dockerfiles, compose files, features).
 	LabelHashConfig = Prefix + ".hash.config"
+
+	// LabelHashStructural is LabelHashConfig with env-only inputs
+	// (containerEnv, remoteEnv, forwardPorts) excluded. A matching
+	// structural hash with a differing config hash means only env/port
+	// configuration changed.
+	LabelHashStructural = Prefix + ".hash.structural"
 )
 
 // State labels.
@@ -61,6 +72,15 @@ const (
 	// LabelLifecycleState tracks the container lifecycle state.
 	// Values: "created", "ready", "broken"
 	LabelLifecycleState = Prefix + ".lifecycle.state"
+
+	// LabelGPUDecision records how an optional GPU requirement resolved
+	// at create time: "enabled" or "unavailable".
+	LabelGPUDecision = Prefix + ".gpu.decision"
+
+	// LabelShutdownAction records devcontainer.json's shutdownAction so
+	// host-side watchers can honor "none" without loading the workspace
+	// config. Values: "none", "stopContainer", "stopCompose" ("" = default).
+	LabelShutdownAction = Prefix + ".shutdown.action"
 )
 
 // Lifecycle states.
@@ -166,15 +186,19 @@ type ContainerLabels struct {
 	WorkspaceName string
 	WorkspacePath string
 	ConfigPath    string
+	DockerContext string
 
 	// Hash
-	HashConfig string
+	HashConfig     string
+	HashStructural string
 
 	// State
 	CreatedAt      time.Time
 	CreatedBy      string
 	LastStartedAt  time.Time
 	LifecycleState string
+	ShutdownAction string
+	GPUDecision    string
 
 	// Features
 	FeaturesInstalled []string
@@ -238,9 +262,11 @@ func (l *ContainerLabels) ToMap() map[string]string {
 	setIfNotEmpty(m, LabelWorkspaceName, l.WorkspaceName)
 	setIfNotEmpty(m, LabelWorkspacePath, l.WorkspacePath)
 	setIfNotEmpty(m, LabelConfigPath, l.ConfigPath)
+	setIfNotEmpty(m, LabelDockerContext, l.DockerContext)
 
 	// Hash
 	setIfNotEmpty(m, LabelHashConfig, l.HashConfig)
+	setIfNotEmpty(m, LabelHashStructural, l.HashStructural)
 
 	// State
 	if !l.CreatedAt.IsZero() {
@@ -251,6 +277,8 @@ func (l *ContainerLabels) ToMap() map[string]string {
 		m[LabelLastStartedAt] = l.LastStartedAt.Format(time.RFC3339)
 	}
 	setIfNotEmpty(m, LabelLifecycleState, l.LifecycleState)
+	setIfNotEmpty(m, LabelShutdownAction, l.ShutdownAction)
+	setIfNotEmpty(m, LabelGPUDecision, l.GPUDecision)
 
 	// Features
 	if len(l.FeaturesInstalled) > 0 {
@@ -318,9 +346,11 @@ func ContainerLabelsFromMap(m map[string]string) *ContainerLabels {
 	l.WorkspaceName = m[LabelWorkspaceName]
 	l.WorkspacePath = m[LabelWorkspacePath]
 	l.ConfigPath = m[LabelConfigPath]
+	l.DockerContext = m[LabelDockerContext]
 
 	// Hash
 	l.HashConfig = m[LabelHashConfig]
+	l.HashStructural = m[LabelHashStructural]
 
 	// State
 	if t, err := time.Parse(time.RFC3339, m[LabelCreatedAt]); err == nil {
@@ -331,6 +361,8 @@ func ContainerLabelsFromMap(m map[string]string) *ContainerLabels {
 		l.LastStartedAt = t
 	}
 	l.LifecycleState = m[LabelLifecycleState]
+	l.ShutdownAction = m[LabelShutdownAction]
+	l.GPUDecision = m[LabelGPUDecision]
 
 	// Features
 	if data := m[LabelFeaturesInstalled]; data != "" {
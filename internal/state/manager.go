@@ -2,6 +2,7 @@ package state
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/griffithind/dcx/internal/common"
@@ -71,14 +72,7 @@ func (m *StateManager) GetState(ctx context.Context, workspaceID string) (Contai
 		return StateAbsent, nil, nil
 	}
 
-	// Find the primary container
-	var primary *ContainerSummary
-	for i := range containers {
-		if IsPrimaryContainer(containers[i].Labels) {
-			primary = &containers[i]
-			break
-		}
-	}
+	primary := findPrimary(containers)
 
 	// No primary container found - broken state
 	if primary == nil {
@@ -101,6 +95,30 @@ func (m *StateManager) GetState(ctx context.Context, workspaceID string) (Contai
 	return StateCreated, info, nil
 }
 
+// PrevSuffix marks a container preserved from before a recreate
+// (blue/green). Preserved containers keep their labels, so primary
+// selection must skip them in favor of the live replacement.
+const PrevSuffix = "-prev"
+
+// findPrimary picks the primary container, preferring one whose name does
+// not carry the PrevSuffix (a preserved pre-recreate container).
+func findPrimary(containers []ContainerSummary) *ContainerSummary {
+	var prev *ContainerSummary
+	for i := range containers {
+		if !IsPrimaryContainer(containers[i].Labels) {
+			continue
+		}
+		if strings.HasSuffix(containers[i].Name, PrevSuffix) {
+			if prev == nil {
+				prev = &containers[i]
+			}
+			continue
+		}
+		return &containers[i]
+	}
+	return prev
+}
+
 // containerInfoFromSummary creates ContainerInfo from a ContainerSummary.
 func containerInfoFromSummary(c *ContainerSummary) *ContainerInfo {
 	l := ContainerLabelsFromMap(c.Labels)
@@ -142,14 +160,7 @@ func (m *StateManager) processContainers(containers []ContainerSummary) (Contain
 		return StateAbsent, nil, nil
 	}
 
-	// Find the primary container
-	var primary *ContainerSummary
-	for i := range containers {
-		if IsPrimaryContainer(containers[i].Labels) {
-			primary = &containers[i]
-			break
-		}
-	}
+	primary := findPrimary(containers)
 
 	// No primary container found - broken state
 	if primary == nil {
@@ -0,0 +1,63 @@
+package state
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// primarySummary builds a primary-labeled container summary for the
+// blue/green selection tests.
+func primarySummary(name, workspaceID string, running bool) ContainerSummary {
+	containerState := "exited"
+	if running {
+		containerState = "running"
+	}
+	return ContainerSummary{
+		ID:      "id-" + name,
+		Name:    name,
+		State:   containerState,
+		Running: running,
+		Labels: map[string]string{
+			LabelManaged:     "true",
+			LabelWorkspaceID: workspaceID,
+			LabelIsPrimary:   "true",
+		},
+	}
+}
+
+func TestFindPrimarySkipsPreservedContainer(t *testing.T) {
+	containers := []ContainerSummary{
+		primarySummary("myapp"+PrevSuffix, "ws1", false),
+		primarySummary("myapp", "ws1", true),
+	}
+
+	primary := findPrimary(containers)
+	assert.NotNil(t, primary)
+	assert.Equal(t, "myapp", primary.Name, "live replacement should win over the -prev container")
+}
+
+func TestFindPrimaryFallsBackToPreserved(t *testing.T) {
+	containers := []ContainerSummary{
+		primarySummary("myapp"+PrevSuffix, "ws1", false),
+	}
+
+	primary := findPrimary(containers)
+	assert.NotNil(t, primary)
+	assert.Equal(t, "myapp"+PrevSuffix, primary.Name,
+		"with only the preserved container left (failed recreate), it is still the primary")
+}
+
+func TestGetStateResolvesLiveContainerDuringRecreate(t *testing.T) {
+	client := &mockContainerClient{containers: []ContainerSummary{
+		primarySummary("myapp"+PrevSuffix, "ws1", false),
+		primarySummary("myapp", "ws1", true),
+	}}
+
+	mgr := NewStateManager(client)
+	containerState, info, err := mgr.GetState(context.Background(), "ws1")
+	assert.NoError(t, err)
+	assert.Equal(t, StateRunning, containerState)
+	assert.Equal(t, "myapp", info.Name)
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Message catalog: user-facing strings referenced by stable IDs so they
+// can be localized (or restyled) in one place instead of being scattered
+// through command code. Commands call T(id, args...); unknown IDs fall
+// back to the ID itself so a missing entry is visible, not fatal.
+
+// MessageID identifies a catalog entry.
+type MessageID string
+
+// Core message IDs. Grow this list as call sites migrate.
+const (
+	MsgUpAlreadyRunning MessageID = "up.already_running"
+	MsgUpStarting       MessageID = "up.starting"
+	MsgUpStarted        MessageID = "up.started"
+	MsgUpSuccess        MessageID = "up.success"
+	MsgStopStopped      MessageID = "stop.stopped"
+	MsgDownRemoved      MessageID = "down.removed"
+	MsgNoDevcontainer   MessageID = "common.no_devcontainer"
+)
+
+// defaultCatalog is the built-in English catalog.
+var defaultCatalog = map[MessageID]string{
+	MsgUpAlreadyRunning: "Devcontainer is already running",
+	MsgUpStarting:       "Devcontainer exists and is up to date, starting...",
+	MsgUpStarted:        "Devcontainer started",
+	MsgUpSuccess:        "Devcontainer started successfully",
+	MsgStopStopped:      "Devcontainer stopped",
+	MsgDownRemoved:      "Devcontainer removed",
+	MsgNoDevcontainer:   "No devcontainer found",
+}
+
+var (
+	catalogMu sync.RWMutex
+	catalog   = defaultCatalog
+)
+
+// SetCatalog replaces the message catalog (localization); IDs missing
+// from the replacement fall back to the built-in English entries.
+func SetCatalog(overrides map[MessageID]string) {
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+	merged := make(map[MessageID]string, len(defaultCatalog)+len(overrides))
+	for id, msg := range defaultCatalog {
+		merged[id] = msg
+	}
+	for id, msg := range overrides {
+		merged[id] = msg
+	}
+	catalog = merged
+}
+
+// T resolves a message ID, applying printf-style args when given.
+func T(id MessageID, args ...interface{}) string {
+	catalogMu.RLock()
+	msg, ok := catalog[id]
+	catalogMu.RUnlock()
+	if !ok {
+		msg = string(id)
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
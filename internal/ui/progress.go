@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pterm/pterm"
+	"golang.org/x/term"
+)
+
+// MultiProgress renders several concurrent tasks (layer pulls, feature
+// fetches, compose service builds) as an in-place multi-line display with
+// spinners and durations, buildx-style. When stdout is not a TTY (CI
+// logs, pipes) it degrades to plain start/finish log lines.
+type MultiProgress struct {
+	mu      sync.Mutex
+	tasks   []*ProgressTask
+	tty     bool
+	done    chan struct{}
+	started bool
+	lines   int
+}
+
+// ProgressTask is one tracked unit of work.
+type ProgressTask struct {
+	name     string
+	start    time.Time
+	finished bool
+	failed   bool
+	elapsed  time.Duration
+
+	parent *MultiProgress
+}
+
+// spinnerFrames matches pterm's default spinner sequence.
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// NewMultiProgress creates a renderer. Start begins drawing; Stop must be
+// called when all tasks are finished.
+func NewMultiProgress() *MultiProgress {
+	return &MultiProgress{
+		tty:  term.IsTerminal(int(os.Stdout.Fd())) && !IsQuiet(),
+		done: make(chan struct{}),
+	}
+}
+
+// Start begins the render loop (no-op without a TTY).
+func (m *MultiProgress) Start() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.started || !m.tty {
+		m.started = true
+		return
+	}
+	m.started = true
+	go m.renderLoop()
+}
+
+// Stop finishes rendering, leaving the final state on screen.
+func (m *MultiProgress) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.tty && m.started {
+		close(m.done)
+		m.render()
+	}
+	m.started = false
+}
+
+// AddTask registers and starts a task.
+func (m *MultiProgress) AddTask(name string) *ProgressTask {
+	task := &ProgressTask{name: name, start: time.Now(), parent: m}
+
+	m.mu.Lock()
+	m.tasks = append(m.tasks, task)
+	m.mu.Unlock()
+
+	if !m.tty {
+		Printf("=> %s ...", name)
+	}
+	return task
+}
+
+// Done marks the task finished successfully.
+func (t *ProgressTask) Done() {
+	t.finish(false)
+}
+
+// Fail marks the task finished with an error.
+func (t *ProgressTask) Fail() {
+	t.finish(true)
+}
+
+func (t *ProgressTask) finish(failed bool) {
+	t.parent.mu.Lock()
+	t.finished = true
+	t.failed = failed
+	t.elapsed = time.Since(t.start)
+	t.parent.mu.Unlock()
+
+	if !t.parent.tty {
+		status := "done"
+		if failed {
+			status = "failed"
+		}
+		Printf("=> %s %s (%.1fs)", t.name, status, t.elapsed.Seconds())
+	}
+}
+
+// renderLoop redraws the task block until Stop.
+func (m *MultiProgress) renderLoop() {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.done:
+			return
+		case <-ticker.C:
+			m.mu.Lock()
+			m.render()
+			m.mu.Unlock()
+		}
+	}
+}
+
+// render redraws all task lines in place. Caller holds the lock.
+func (m *MultiProgress) render() {
+	// Move the cursor up over the previous frame.
+	if m.lines > 0 {
+		fmt.Printf("\033[%dA", m.lines)
+	}
+
+	frame := spinnerFrames[(time.Now().UnixMilli()/100)%int64(len(spinnerFrames))]
+	for _, task := range m.tasks {
+		fmt.Print("\033[2K") // clear line
+		switch {
+		case task.failed:
+			fmt.Printf("%s %s (%.1fs)\n", pterm.Red("✗"), task.name, task.elapsed.Seconds())
+		case task.finished:
+			fmt.Printf("%s %s (%.1fs)\n", pterm.Green("✓"), task.name, task.elapsed.Seconds())
+		default:
+			fmt.Printf("%s %s (%.1fs)\n", pterm.Cyan(frame), task.name, time.Since(task.start).Seconds())
+		}
+	}
+	m.lines = len(m.tasks)
+}
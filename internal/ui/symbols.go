@@ -34,6 +34,15 @@ func StateColor(state string) string {
 	}
 }
 
+// OutcomeColor returns "ok" in green or "failed" in red, for result
+// tables that report operation outcomes rather than container states.
+func OutcomeColor(ok bool) string {
+	if ok {
+		return pterm.FgGreen.Sprint("ok")
+	}
+	return pterm.FgRed.Sprint("failed")
+}
+
 // CheckResult represents a check result for formatting.
 type CheckResult int
 
@@ -15,7 +15,9 @@ type Verbosity int
 const (
 	VerbosityQuiet   Verbosity = -1
 	VerbosityNormal  Verbosity = 0
-	VerbosityVerbose Verbosity = 1
+	VerbosityVerbose Verbosity = 1 // -v: progress detail
+	VerbosityDebug   Verbosity = 2 // -vv: slog debug across packages
+	VerbosityTrace   Verbosity = 3 // -vvv: plus every docker invocation
 )
 
 // Config holds UI configuration.
@@ -52,6 +54,12 @@ func Configure(cfg Config) {
 		cfg.ErrWriter = os.Stderr
 	}
 
+	// NO_COLOR (https://no-color.org) is honored in addition to the
+	// --no-color flag.
+	if os.Getenv("NO_COLOR") != "" {
+		cfg.NoColor = true
+	}
+
 	config = cfg
 
 	if cfg.NoColor {